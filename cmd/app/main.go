@@ -1,10 +1,29 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+
 	"jwtgo/internal/app"
+	"jwtgo/internal/app/config"
 )
 
 func main() {
+	validateConfig := flag.Bool("validate-config", false, "check the configuration, print it with secrets redacted and exit")
+	flag.Parse()
+
+	if *validateConfig {
+		cfg, err := config.Load(config.Path())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Invalid config:", err)
+			os.Exit(1)
+		}
+
+		fmt.Print(cfg)
+		return
+	}
+
 	ginApp := app.NewApplication()
 	ginApp.Initialize()
 	ginApp.Run()
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/spf13/cobra"
+)
+
+type generatedAPIKey struct {
+	Key  string `json:"key"`
+	Hash string `json:"hash"`
+}
+
+func newAPIKeyCommand(out func() *printer) *cobra.Command {
+	apiKey := &cobra.Command{
+		Use:   "apikey",
+		Short: "Manage service API keys",
+	}
+
+	apiKey.AddCommand(&cobra.Command{
+		Use:   "generate",
+		Short: "Generate an API key and the digest to add to api_keys.hashes or api_keys.admin_hashes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			randomBytes := make([]byte, 32)
+			if _, err := rand.Read(randomBytes); err != nil {
+				return err
+			}
+
+			key := base64.RawURLEncoding.EncodeToString(randomBytes)
+			digest := sha256.Sum256([]byte(key))
+			generated := generatedAPIKey{Key: key, Hash: hex.EncodeToString(digest[:])}
+
+			return out().Print(generated, []string{"KEY", "HASH"}, [][]string{{generated.Key, generated.Hash}})
+		},
+	})
+
+	return apiKey
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestAPIKeyGenerate(t *testing.T) {
+	out, err := runCommand(t, "", "apikey", "generate", "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var generated generatedAPIKey
+	if err := json.Unmarshal([]byte(out), &generated); err != nil {
+		t.Fatalf("output is not JSON: %q", out)
+	}
+
+	digest := sha256.Sum256([]byte(generated.Key))
+	if generated.Key == "" || generated.Hash != hex.EncodeToString(digest[:]) {
+		t.Fatalf("generated = %+v, want the SHA-256 digest of the key", generated)
+	}
+}
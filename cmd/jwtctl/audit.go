@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"jwtgo/internal/pkg/audit"
+)
+
+type auditVerification struct {
+	File     string `json:"file"`
+	Verified int    `json:"verified"`
+	Valid    bool   `json:"valid"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newAuditCommand(out func() *printer) *cobra.Command {
+	auditCommand := &cobra.Command{
+		Use:   "audit",
+		Short: "Work with the audit log",
+	}
+
+	var file string
+	verify := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the hash chain of the audit log",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, _ := loadConfig()
+			if cfg.Audit.Key == "" {
+				return errors.New("audit.key is not configured")
+			}
+			if file == "" {
+				file = cfg.Audit.Path
+			}
+
+			auditFile, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer auditFile.Close()
+
+			verified, chainErr := audit.Verify(auditFile, []byte(cfg.Audit.Key))
+
+			result := auditVerification{File: file, Verified: verified, Valid: chainErr == nil}
+			if chainErr != nil {
+				result.Error = chainErr.Error()
+			}
+
+			if err = out().Print(result, []string{"FILE", "VERIFIED", "VALID", "ERROR"}, [][]string{
+				{result.File, strconv.Itoa(result.Verified), strconv.FormatBool(result.Valid), result.Error},
+			}); err != nil {
+				return err
+			}
+
+			return chainErr
+		},
+	}
+	verify.Flags().StringVar(&file, "file", "", "audit log to verify, defaults to audit.path")
+
+	auditCommand.AddCommand(verify)
+
+	return auditCommand
+}
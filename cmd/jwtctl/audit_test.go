@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"jwtgo/internal/pkg/audit"
+)
+
+func writeAuditLog(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	writer, err := audit.OpenFile(path, []byte(testAuditKey), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range []string{"signin", "refresh", "revoke"} {
+		if err := writer.Write(audit.Event{Action: action, UserId: "user-1", Outcome: "success"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestAuditVerify(t *testing.T) {
+	path := writeAuditLog(t)
+
+	out, err := runCommand(t, "", "audit", "verify", "--file", path, "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result auditVerification
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("output is not JSON: %q", out)
+	}
+	if !result.Valid || result.Verified != 3 {
+		t.Fatalf("result = %+v, want 3 verified records", result)
+	}
+}
+
+func TestAuditVerifyDetectsTampering(t *testing.T) {
+	path := writeAuditLog(t)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, bytes.Replace(content, []byte(`"refresh"`), []byte(`"signout"`), 1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runCommand(t, "", "audit", "verify", "--file", path); err == nil {
+		t.Fatal("tampered log verified")
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	var format string
+
+	root := &cobra.Command{
+		Use:          "jwtctl",
+		Short:        "Operational tasks against the jwtgo user store",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if format != outputTable && format != outputJSON {
+				return fmt.Errorf("--output must be %s or %s", outputTable, outputJSON)
+			}
+			return nil
+		},
+	}
+	root.PersistentFlags().StringVarP(&format, "output", "o", outputTable, "output format: table or json")
+
+	out := func() *printer { return newPrinter(root.OutOrStdout(), format) }
+
+	root.AddCommand(newUsersCommand(out))
+	root.AddCommand(newAuditCommand(out))
+	root.AddCommand(newAPIKeyCommand(out))
+
+	return root
+}
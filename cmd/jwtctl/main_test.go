@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jwtgo/internal/app/adapter/memory/repository"
+	"jwtgo/internal/app/config"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	"jwtgo/pkg/logging"
+)
+
+const testAuditKey = "test-audit-key"
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "jwtctl")
+	if err != nil {
+		panic(err)
+	}
+
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(`
+app:
+  host: "127.0.0.1"
+  port: "8080"
+  storage: "memory"
+security:
+  salt: "test-salt"
+  secret: "test-secret"
+  bcrypt_cost: 4
+  access_lifetime: 10
+  refresh_lifetime: 60
+audit:
+  key: "`+testAuditKey+`"
+  path: "`+filepath.Join(dir, "audit.log")+`"
+`), 0o600); err != nil {
+		panic(err)
+	}
+	os.Setenv("CONFIG_PATH", path)
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// useMemoryRepository points the commands at a fresh in-memory repository.
+func useMemoryRepository(t *testing.T) *repository.UserRepository {
+	t.Helper()
+
+	userRepository := repository.NewUserRepository()
+	openUserRepository = func(*config.Config, *logging.Logger) (repositoryInterface.UserRepository, func(), error) {
+		return userRepository, func() {}, nil
+	}
+	t.Cleanup(func() { openUserRepository = openMongoUserRepository })
+
+	return userRepository
+}
+
+func runCommand(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	root := newRootCommand()
+	root.SetArgs(args)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&out)
+	root.SetErr(&out)
+
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestRejectsUnknownOutputFormat(t *testing.T) {
+	if _, err := runCommand(t, "", "apikey", "generate", "--output", "yaml"); err == nil {
+		t.Fatal("unknown output format was accepted")
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"jwtgo/internal/app/adapter/mongodb/repository"
+	"jwtgo/internal/app/config"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	"jwtgo/pkg/client"
+	"jwtgo/pkg/logging"
+)
+
+func loadConfig() (*config.Config, *logging.Logger) {
+	logger := logging.GetLogger("warn")
+	return config.GetConfig(&logger), &logger
+}
+
+// openUserRepository is a variable so tests can run the commands against an
+// in-memory repository.
+var openUserRepository = openMongoUserRepository
+
+// openMongoUserRepository connects to the same MongoDB the server uses. The
+// in-memory store lives inside the server process and cannot be reached.
+func openMongoUserRepository(cfg *config.Config, logger *logging.Logger) (repositoryInterface.UserRepository, func(), error) {
+	if cfg.App.Storage != "mongodb" {
+		return nil, nil, fmt.Errorf("app.storage is %q, jwtctl only works against mongodb", cfg.App.Storage)
+	}
+
+	mongoClient := client.NewMongodbClient(cfg.MongoDB.Url, logger.Named("mongo")).Connect()
+	closeClient := func() { _ = mongoClient.Disconnect(context.Background()) }
+
+	return repository.NewUserRepository(mongoClient, cfg.MongoDB.Database, "users", nil, logger.Named("repo")), closeClient, nil
+}
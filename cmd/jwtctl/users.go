@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"jwtgo/internal/app/entity"
+	"jwtgo/internal/app/service"
+)
+
+const (
+	minPasswordLength = 6
+	maxPasswordLength = 64
+)
+
+type userRow struct {
+	Id         string    `json:"id"`
+	Email      string    `json:"email"`
+	Passkeys   int       `json:"passkeys"`
+	Identities []string  `json:"identities"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newUsersCommand(out func() *printer) *cobra.Command {
+	users := &cobra.Command{
+		Use:   "users",
+		Short: "Inspect and manage users",
+	}
+
+	users.AddCommand(newUsersListCommand(out))
+	users.AddCommand(newUsersResetPasswordCommand())
+
+	return users
+}
+
+func newUsersListCommand(out func() *printer) *cobra.Command {
+	var emailPattern string
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List users, optionally filtered by an email glob such as '*@example.com'",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := path.Match(emailPattern, ""); err != nil {
+				return fmt.Errorf("invalid --email pattern: %w", err)
+			}
+
+			cfg, logger := loadConfig()
+			userRepository, closeRepository, err := openUserRepository(cfg, logger)
+			if err != nil {
+				return err
+			}
+			defer closeRepository()
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			users, err := userRepository.GetAll(ctx)
+			if err != nil {
+				return err
+			}
+
+			matched := make([]userRow, 0, len(users))
+			rows := make([][]string, 0, len(users))
+			for _, user := range users {
+				if ok, _ := path.Match(strings.ToLower(emailPattern), strings.ToLower(user.Email)); !ok {
+					continue
+				}
+
+				row := mapUserRow(user)
+				matched = append(matched, row)
+				rows = append(rows, []string{row.Id, row.Email, strconv.Itoa(row.Passkeys), strings.Join(row.Identities, ","), row.CreatedAt.Format(time.RFC3339)})
+			}
+
+			return out().Print(matched, []string{"ID", "EMAIL", "PASSKEYS", "IDENTITIES", "CREATED"}, rows)
+		},
+	}
+	list.Flags().StringVar(&emailPattern, "email", "*", "email glob to match, case-insensitive")
+
+	return list
+}
+
+func newUsersResetPasswordCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset-password IDENTIFIER",
+		Short: "Set a new password for a user, read from the first line of stdin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := readPassword(cmd)
+			if err != nil {
+				return err
+			}
+
+			cfg, logger := loadConfig()
+			userRepository, closeRepository, err := openUserRepository(cfg, logger)
+			if err != nil {
+				return err
+			}
+			defer closeRepository()
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			user, err := service.NewUserResolver(userRepository, cfg.Security.IdentifierOrder).FindUserByIdentifier(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if user == nil {
+				return fmt.Errorf("no user matches %s", args[0])
+			}
+
+			passwordService := service.NewPasswordService(cfg.Security.BcryptCost, cfg.Security.Salt)
+
+			localSalt, err := passwordService.GenerateSalt(32)
+			if err != nil {
+				return err
+			}
+
+			hashedPassword, err := passwordService.HashPassword(password, localSalt)
+			if err != nil {
+				return err
+			}
+
+			if _, err = userRepository.Update(ctx, user.Id, &entity.User{Password: hashedPassword, Salt: localSalt, UpdatedAt: time.Now().UTC()}); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Password reset for %s\n", user.Email)
+			return nil
+		},
+	}
+}
+
+// readPassword takes the password from stdin so it never appears in shell
+// history or the process list.
+func readPassword(cmd *cobra.Command) (string, error) {
+	scanner := bufio.NewScanner(cmd.InOrStdin())
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.New("expected the new password on stdin")
+	}
+
+	password := strings.TrimRight(scanner.Text(), "\r")
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return "", fmt.Errorf("password must be between %d and %d characters", minPasswordLength, maxPasswordLength)
+	}
+
+	return password, nil
+}
+
+func mapUserRow(user *entity.User) userRow {
+	identities := make([]string, 0, len(user.Identities))
+	for _, identity := range user.Identities {
+		identities = append(identities, identity.Provider)
+	}
+
+	return userRow{
+		Id:         user.Id,
+		Email:      user.Email,
+		Passkeys:   len(user.WebAuthnCredentials),
+		Identities: identities,
+		CreatedAt:  user.CreatedAt,
+	}
+}
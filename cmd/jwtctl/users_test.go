@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"jwtgo/internal/app/entity"
+	"jwtgo/internal/app/service"
+)
+
+func TestUsersList(t *testing.T) {
+	userRepository := useMemoryRepository(t)
+	for _, email := range []string{"alice@example.com", "bob@example.com", "carol@other.org"} {
+		if _, err := userRepository.Create(context.Background(), &entity.User{Email: email}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runCommand(t, "", "users", "list", "--email", "*@EXAMPLE.com", "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []userRow
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		t.Fatalf("output is not JSON: %q", out)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("listed %d users, want the 2 at example.com: %+v", len(rows), rows)
+	}
+
+	out, err = runCommand(t, "", "users", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 4 || !strings.HasPrefix(lines[0], "ID") {
+		t.Fatalf("table output = %q, want a header and 3 rows", out)
+	}
+
+	if _, err := runCommand(t, "", "users", "list", "--email", "["); err == nil {
+		t.Fatal("malformed pattern was accepted")
+	}
+}
+
+func TestUsersResetPassword(t *testing.T) {
+	userRepository := useMemoryRepository(t)
+	if _, err := userRepository.Create(context.Background(), &entity.User{Email: "user@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runCommand(t, "new password\n", "users", "reset-password", "user@example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	user, _ := userRepository.GetByEmail(context.Background(), "user@example.com")
+	if !service.NewPasswordService(4, "test-salt").VerifyPassword("new password", user.Password, user.Salt) {
+		t.Fatal("the new password does not verify")
+	}
+
+	tests := []struct {
+		name       string
+		stdin      string
+		identifier string
+	}{
+		{"unknown user", "new password\n", "nobody@example.com"},
+		{"short password", "short\n", "user@example.com"},
+		{"empty stdin", "", "user@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := runCommand(t, tt.stdin, "users", "reset-password", tt.identifier); err == nil {
+				t.Fatal("reset succeeded")
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	domainEntity "jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/tracing"
+)
+
+type UserRepository struct {
+	mu         sync.RWMutex
+	users      map[string]*domainEntity.User
+	byEmail    map[string]string
+	byIdentity map[string]string
+}
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users:      make(map[string]*domainEntity.User),
+		byEmail:    make(map[string]string),
+		byIdentity: make(map[string]string),
+	}
+}
+
+// observe gives each call the same span as the MongoDB repository, so traces
+// keep their shape whichever storage the application runs on.
+func (ur *UserRepository) observe(ctx context.Context, operation string) func(error) {
+	_, span := tracing.Start(ctx, "repository."+operation,
+		attribute.String("db.system", "memory"),
+		attribute.String("db.operation.name", operation),
+	)
+
+	return func(err error) { tracing.End(span, err) }
+}
+
+func (ur *UserRepository) GetById(ctx context.Context, id string) (_ *domainEntity.User, err error) {
+	finish := ur.observe(ctx, "get_by_id")
+	defer func() { finish(err) }()
+
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+
+	return copyUser(ur.users[id]), nil
+}
+
+func (ur *UserRepository) GetByEmail(ctx context.Context, email string) (_ *domainEntity.User, err error) {
+	finish := ur.observe(ctx, "get_by_email")
+	defer func() { finish(err) }()
+
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+
+	return copyUser(ur.users[ur.byEmail[email]]), nil
+}
+
+func (ur *UserRepository) GetByIdentity(ctx context.Context, provider, subject string) (_ *domainEntity.User, err error) {
+	finish := ur.observe(ctx, "get_by_identity")
+	defer func() { finish(err) }()
+
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+
+	return copyUser(ur.users[ur.byIdentity[identityKey(provider, subject)]]), nil
+}
+
+func (ur *UserRepository) GetAll(ctx context.Context) (_ []*domainEntity.User, err error) {
+	finish := ur.observe(ctx, "get_all")
+	defer func() { finish(err) }()
+
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+
+	users := make([]*domainEntity.User, 0, len(ur.users))
+	for _, user := range ur.users {
+		users = append(users, copyUser(user))
+	}
+
+	return users, nil
+}
+
+func (ur *UserRepository) Create(ctx context.Context, domainUser *domainEntity.User) (_ bool, err error) {
+	finish := ur.observe(ctx, "create")
+	defer func() { finish(err) }()
+
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+
+	user := copyUser(domainUser)
+	if user.Id == "" {
+		id, err := newId()
+		if err != nil {
+			return false, customErr.NewInternalServerError("Failed to create a user", err)
+		}
+		user.Id = id
+	}
+
+	if _, ok := ur.users[user.Id]; ok {
+		return false, customErr.NewInternalServerError("Failed to create a user", fmt.Errorf("duplicate id %s", user.Id))
+	}
+	if _, ok := ur.byEmail[user.Email]; ok {
+		return false, customErr.NewInternalServerError("Failed to create a user", fmt.Errorf("duplicate email"))
+	}
+	if err := ur.checkIdentities(user); err != nil {
+		return false, customErr.NewInternalServerError("Failed to create a user", err)
+	}
+
+	ur.store(user)
+
+	return true, nil
+}
+
+func (ur *UserRepository) Update(ctx context.Context, id string, domainUser *domainEntity.User) (_ bool, err error) {
+	finish := ur.observe(ctx, "update")
+	defer func() { finish(err) }()
+
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+
+	existing, ok := ur.users[id]
+	if !ok {
+		return true, nil
+	}
+
+	// Mirrors the $set semantics of the MongoDB repository: zero values leave
+	// the stored field untouched.
+	user := copyUser(existing)
+	if domainUser.Email != "" {
+		user.Email = domainUser.Email
+	}
+	if domainUser.Password != "" {
+		user.Password = domainUser.Password
+	}
+	if domainUser.Salt != "" {
+		user.Salt = domainUser.Salt
+	}
+	if domainUser.Status != "" {
+		user.Status = domainUser.Status
+	}
+	if domainUser.RefreshToken != "" {
+		user.RefreshToken = domainUser.RefreshToken
+		user.RefreshNetwork = domainUser.RefreshNetwork
+	}
+	if domainUser.Roles != nil {
+		user.Roles = append([]string(nil), domainUser.Roles...)
+	}
+	if domainUser.WebAuthnCredentials != nil {
+		user.WebAuthnCredentials = append([]domainEntity.WebAuthnCredential(nil), domainUser.WebAuthnCredentials...)
+	}
+	if domainUser.Identities != nil {
+		user.Identities = append([]domainEntity.Identity(nil), domainUser.Identities...)
+	}
+	if !domainUser.AuthTime.IsZero() {
+		user.AuthTime = domainUser.AuthTime
+	}
+	if !domainUser.UpdatedAt.IsZero() {
+		user.UpdatedAt = domainUser.UpdatedAt
+	}
+
+	if otherId, ok := ur.byEmail[user.Email]; ok && otherId != id {
+		return false, customErr.NewInternalServerError("Failed to update user", fmt.Errorf("duplicate email"))
+	}
+	if err := ur.checkIdentities(user); err != nil {
+		return false, customErr.NewInternalServerError("Failed to update user", err)
+	}
+
+	ur.remove(existing)
+	ur.store(user)
+
+	return true, nil
+}
+
+func (ur *UserRepository) Delete(ctx context.Context, id string) (_ bool, err error) {
+	finish := ur.observe(ctx, "delete")
+	defer func() { finish(err) }()
+
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+
+	if existing, ok := ur.users[id]; ok {
+		ur.remove(existing)
+	}
+
+	return true, nil
+}
+
+func (ur *UserRepository) checkIdentities(user *domainEntity.User) error {
+	for _, identity := range user.Identities {
+		if otherId, ok := ur.byIdentity[identityKey(identity.Provider, identity.Subject)]; ok && otherId != user.Id {
+			return fmt.Errorf("duplicate %s identity", identity.Provider)
+		}
+	}
+	return nil
+}
+
+func (ur *UserRepository) store(user *domainEntity.User) {
+	ur.users[user.Id] = user
+	ur.byEmail[user.Email] = user.Id
+	for _, identity := range user.Identities {
+		ur.byIdentity[identityKey(identity.Provider, identity.Subject)] = user.Id
+	}
+}
+
+func (ur *UserRepository) remove(user *domainEntity.User) {
+	delete(ur.users, user.Id)
+	delete(ur.byEmail, user.Email)
+	for _, identity := range user.Identities {
+		delete(ur.byIdentity, identityKey(identity.Provider, identity.Subject))
+	}
+}
+
+func copyUser(user *domainEntity.User) *domainEntity.User {
+	if user == nil {
+		return nil
+	}
+
+	userCopy := *user
+	if user.Roles != nil {
+		userCopy.Roles = append([]string(nil), user.Roles...)
+	}
+	if user.WebAuthnCredentials != nil {
+		userCopy.WebAuthnCredentials = append([]domainEntity.WebAuthnCredential(nil), user.WebAuthnCredentials...)
+	}
+	if user.Identities != nil {
+		userCopy.Identities = append([]domainEntity.Identity(nil), user.Identities...)
+	}
+
+	return &userCopy
+}
+
+func identityKey(provider, subject string) string {
+	return provider + "\x00" + subject
+}
+
+func newId() (string, error) {
+	randomBytes := make([]byte, 12)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	domainEntity "jwtgo/internal/app/entity"
+)
+
+func TestCreateKeepsEmailsUniqueUnderConcurrency(t *testing.T) {
+	userRepository := NewUserRepository()
+
+	var created atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := userRepository.Create(context.Background(), &domainEntity.User{Email: "user@example.com"}); ok && err == nil {
+				created.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := created.Load(); got != 1 {
+		t.Fatalf("%d users were created with the same email, want 1", got)
+	}
+}
+
+func TestConcurrentReadsAndUpdates(t *testing.T) {
+	ctx := context.Background()
+	userRepository := NewUserRepository()
+
+	if _, err := userRepository.Create(ctx, &domainEntity.User{Email: "user@example.com", Roles: []string{"user"}}); err != nil {
+		t.Fatal(err)
+	}
+	user, _ := userRepository.GetByEmail(ctx, "user@example.com")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			userRepository.Update(ctx, user.Id, &domainEntity.User{RefreshToken: fmt.Sprintf("token-%d", i), Roles: []string{"user", "admin"}})
+		}()
+		go func() {
+			defer wg.Done()
+			read, _ := userRepository.GetById(ctx, user.Id)
+			// Reads are copies, so writing to one must not reach the store.
+			read.Roles[0] = "mutated"
+			userRepository.GetAll(ctx)
+		}()
+	}
+	wg.Wait()
+
+	stored, _ := userRepository.GetById(ctx, user.Id)
+	if stored.Roles[0] != "user" {
+		t.Fatalf("roles = %v, a returned copy leaked into the store", stored.Roles)
+	}
+}
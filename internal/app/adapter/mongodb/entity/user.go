@@ -7,11 +7,36 @@ import (
 )
 
 type User struct {
-	Id           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Email        string             `bson:"email" json:"email"`
-	Password     string             `bson:"password" json:"password"`
-	Salt         string             `bson:"salt" json:"salt"`
-	RefreshToken string             `bson:"refresh_token" json:"refresh_token"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	Id                  primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Email               string               `bson:"email" json:"email"`
+	Password            string               `bson:"password" json:"password"`
+	Salt                string               `bson:"salt" json:"salt"`
+	Status              string               `bson:"status,omitempty" json:"status"`
+	Roles               []string             `bson:"roles,omitempty" json:"roles"`
+	RefreshToken        string               `bson:"refresh_token" json:"refresh_token"`
+	RefreshNetwork      string               `bson:"refresh_network,omitempty" json:"refresh_network"`
+	WebAuthnCredentials []WebAuthnCredential `bson:"webauthn_credentials,omitempty" json:"webauthn_credentials"`
+	Identities          []Identity           `bson:"identities,omitempty" json:"identities"`
+	AuthTime            time.Time            `bson:"auth_time,omitempty" json:"auth_time"`
+	CreatedAt           time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt           time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+type WebAuthnCredential struct {
+	Id              []byte    `bson:"id" json:"id"`
+	PublicKey       []byte    `bson:"public_key" json:"public_key"`
+	AttestationType string    `bson:"attestation_type" json:"attestation_type"`
+	Transports      []string  `bson:"transports" json:"transports"`
+	AAGUID          []byte    `bson:"aaguid" json:"aaguid"`
+	SignCount       uint32    `bson:"sign_count" json:"sign_count"`
+	BackupEligible  bool      `bson:"backup_eligible" json:"backup_eligible"`
+	BackupState     bool      `bson:"backup_state" json:"backup_state"`
+	CreatedAt       time.Time `bson:"created_at" json:"created_at"`
+}
+
+type Identity struct {
+	Provider string    `bson:"provider" json:"provider"`
+	Subject  string    `bson:"subject" json:"subject"`
+	Email    string    `bson:"email" json:"email"`
+	LinkedAt time.Time `bson:"linked_at" json:"linked_at"`
 }
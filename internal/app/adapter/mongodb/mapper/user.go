@@ -11,13 +11,19 @@ import (
 
 func MapMongoUserToDomainUser(mongoUser *mongoEntity.User) *domainEntity.User {
 	return &domainEntity.User{
-		Id:           mongoUser.Id.Hex(),
-		Email:        mongoUser.Email,
-		Password:     mongoUser.Password,
-		Salt:         mongoUser.Salt,
-		RefreshToken: mongoUser.RefreshToken,
-		CreatedAt:    mongoUser.CreatedAt,
-		UpdatedAt:    mongoUser.UpdatedAt,
+		Id:                  mongoUser.Id.Hex(),
+		Email:               mongoUser.Email,
+		Password:            mongoUser.Password,
+		Salt:                mongoUser.Salt,
+		Status:              mongoUser.Status,
+		Roles:               mongoUser.Roles,
+		RefreshToken:        mongoUser.RefreshToken,
+		RefreshNetwork:      mongoUser.RefreshNetwork,
+		WebAuthnCredentials: MapMongoCredentialsToDomainCredentials(mongoUser.WebAuthnCredentials),
+		Identities:          MapMongoIdentitiesToDomainIdentities(mongoUser.Identities),
+		AuthTime:            mongoUser.AuthTime,
+		CreatedAt:           mongoUser.CreatedAt,
+		UpdatedAt:           mongoUser.UpdatedAt,
 	}
 }
 
@@ -28,20 +34,26 @@ func MapDomainUserToMongoUser(domainUser *domainEntity.User) (*mongoEntity.User,
 	if domainUser.Id != "" {
 		objID, err = primitive.ObjectIDFromHex(domainUser.Id)
 		if err != nil {
-			return nil, customErr.NewInternalServerError("Invalid user ID format")
+			return nil, customErr.NewInternalServerError("Invalid user ID format", err)
 		}
 	} else {
 		objID = primitive.NewObjectID()
 	}
 
 	return &mongoEntity.User{
-		Id:           objID,
-		Email:        domainUser.Email,
-		Password:     domainUser.Password,
-		Salt:         domainUser.Salt,
-		RefreshToken: domainUser.RefreshToken,
-		CreatedAt:    domainUser.CreatedAt,
-		UpdatedAt:    domainUser.UpdatedAt,
+		Id:                  objID,
+		Email:               domainUser.Email,
+		Password:            domainUser.Password,
+		Salt:                domainUser.Salt,
+		Status:              domainUser.Status,
+		Roles:               domainUser.Roles,
+		RefreshToken:        domainUser.RefreshToken,
+		RefreshNetwork:      domainUser.RefreshNetwork,
+		WebAuthnCredentials: MapDomainCredentialsToMongoCredentials(domainUser.WebAuthnCredentials),
+		Identities:          MapDomainIdentitiesToMongoIdentities(domainUser.Identities),
+		AuthTime:            domainUser.AuthTime,
+		CreatedAt:           domainUser.CreatedAt,
+		UpdatedAt:           domainUser.UpdatedAt,
 	}, nil
 }
 
@@ -65,8 +77,24 @@ func MapDomainUserToBsonUser(domainUser *domainEntity.User) bson.M {
 	if domainUser.Salt != "" {
 		updateFields["salt"] = domainUser.Salt
 	}
+	if domainUser.Status != "" {
+		updateFields["status"] = domainUser.Status
+	}
 	if domainUser.RefreshToken != "" {
 		updateFields["refresh_token"] = domainUser.RefreshToken
+		updateFields["refresh_network"] = domainUser.RefreshNetwork
+	}
+	if domainUser.Roles != nil {
+		updateFields["roles"] = domainUser.Roles
+	}
+	if domainUser.WebAuthnCredentials != nil {
+		updateFields["webauthn_credentials"] = MapDomainCredentialsToMongoCredentials(domainUser.WebAuthnCredentials)
+	}
+	if domainUser.Identities != nil {
+		updateFields["identities"] = MapDomainIdentitiesToMongoIdentities(domainUser.Identities)
+	}
+	if !domainUser.AuthTime.IsZero() {
+		updateFields["auth_time"] = domainUser.AuthTime
 	}
 	if !domainUser.UpdatedAt.IsZero() {
 		updateFields["updated_at"] = domainUser.UpdatedAt
@@ -74,3 +102,51 @@ func MapDomainUserToBsonUser(domainUser *domainEntity.User) bson.M {
 
 	return updateFields
 }
+
+func MapMongoCredentialsToDomainCredentials(mongoCredentials []mongoEntity.WebAuthnCredential) []domainEntity.WebAuthnCredential {
+	if mongoCredentials == nil {
+		return nil
+	}
+
+	domainCredentials := make([]domainEntity.WebAuthnCredential, 0, len(mongoCredentials))
+	for _, mongoCredential := range mongoCredentials {
+		domainCredentials = append(domainCredentials, domainEntity.WebAuthnCredential(mongoCredential))
+	}
+	return domainCredentials
+}
+
+func MapDomainCredentialsToMongoCredentials(domainCredentials []domainEntity.WebAuthnCredential) []mongoEntity.WebAuthnCredential {
+	if domainCredentials == nil {
+		return nil
+	}
+
+	mongoCredentials := make([]mongoEntity.WebAuthnCredential, 0, len(domainCredentials))
+	for _, domainCredential := range domainCredentials {
+		mongoCredentials = append(mongoCredentials, mongoEntity.WebAuthnCredential(domainCredential))
+	}
+	return mongoCredentials
+}
+
+func MapMongoIdentitiesToDomainIdentities(mongoIdentities []mongoEntity.Identity) []domainEntity.Identity {
+	if mongoIdentities == nil {
+		return nil
+	}
+
+	domainIdentities := make([]domainEntity.Identity, 0, len(mongoIdentities))
+	for _, mongoIdentity := range mongoIdentities {
+		domainIdentities = append(domainIdentities, domainEntity.Identity(mongoIdentity))
+	}
+	return domainIdentities
+}
+
+func MapDomainIdentitiesToMongoIdentities(domainIdentities []domainEntity.Identity) []mongoEntity.Identity {
+	if domainIdentities == nil {
+		return nil
+	}
+
+	mongoIdentities := make([]mongoEntity.Identity, 0, len(domainIdentities))
+	for _, domainIdentity := range domainIdentities {
+		mongoIdentities = append(mongoIdentities, mongoEntity.Identity(domainIdentity))
+	}
+	return mongoIdentities
+}
@@ -3,35 +3,73 @@ package repository
 import (
 	"context"
 	"errors"
-	"log"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
 
 	mongoEntity "jwtgo/internal/app/adapter/mongodb/entity"
 	"jwtgo/internal/app/adapter/mongodb/mapper"
 	domainEntity "jwtgo/internal/app/entity"
 	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/metrics"
+	"jwtgo/internal/pkg/tracing"
 	"jwtgo/pkg/logging"
 )
 
 type UserRepository struct {
 	collection *mongo.Collection
+	metrics    *metrics.Metrics
 	logger     *logging.Logger
 }
 
-func NewUserRepository(client *mongo.Client, database, collection string, logger *logging.Logger) *UserRepository {
+func NewUserRepository(client *mongo.Client, database, collection string, metrics *metrics.Metrics, logger *logging.Logger) *UserRepository {
 	return &UserRepository{
 		collection: client.Database(database).Collection(collection),
+		metrics:    metrics,
 		logger:     logger,
 	}
 }
 
-func (ur *UserRepository) GetById(ctx context.Context, id string) (*domainEntity.User, error) {
+func (ur *UserRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := ur.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "identities.provider", Value: 1}, {Key: "identities.subject", Value: 1}},
+		Options: options.Index().
+			SetName("identities_provider_subject").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"identities.subject": bson.M{"$exists": true}}),
+	})
+	if err != nil {
+		return customErr.NewInternalServerError("Failed to create user indexes", err)
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) observe(ctx context.Context, operation string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "repository."+operation,
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.operation.name", operation),
+	)
+
+	return ctx, func(err error) {
+		ur.metrics.ObserveRepository(operation, start)
+		tracing.End(span, err)
+	}
+}
+
+func (ur *UserRepository) GetById(ctx context.Context, id string) (_ *domainEntity.User, err error) {
+	ctx, finish := ur.observe(ctx, "get_by_id")
+	defer func() { finish(err) }()
+
+	// No stored user can have an id that is not an ObjectID.
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, customErr.NewInternalServerError("Invalid user ID format")
+		return nil, nil
 	}
 
 	var user mongoEntity.User
@@ -40,86 +78,120 @@ func (ur *UserRepository) GetById(ctx context.Context, id string) (*domainEntity
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, nil
 		}
-		return nil, customErr.NewInternalServerError("Failed to get user")
+		return nil, customErr.NewInternalServerError("Failed to get user", err)
 	}
 
 	return mapper.MapMongoUserToDomainUser(&user), nil
 }
 
-func (ur *UserRepository) GetByEmail(ctx context.Context, email string) (*domainEntity.User, error) {
+func (ur *UserRepository) GetByEmail(ctx context.Context, email string) (_ *domainEntity.User, err error) {
+	ctx, finish := ur.observe(ctx, "get_by_email")
+	defer func() { finish(err) }()
+
 	var user mongoEntity.User
-	err := ur.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	err = ur.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
 
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, nil
 		}
-		return nil, customErr.NewInternalServerError("Failed to get user")
+		return nil, customErr.NewInternalServerError("Failed to get user", err)
 	}
 
 	return mapper.MapMongoUserToDomainUser(&user), nil
 }
 
-func (ur *UserRepository) GetAll(ctx context.Context) ([]*domainEntity.User, error) {
+func (ur *UserRepository) GetByIdentity(ctx context.Context, provider, subject string) (_ *domainEntity.User, err error) {
+	ctx, finish := ur.observe(ctx, "get_by_identity")
+	defer func() { finish(err) }()
+
+	var user mongoEntity.User
+	err = ur.collection.FindOne(ctx, bson.M{
+		"identities": bson.M{"$elemMatch": bson.M{"provider": provider, "subject": subject}},
+	}).Decode(&user)
+
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, customErr.NewInternalServerError("Failed to get user", err)
+	}
+
+	return mapper.MapMongoUserToDomainUser(&user), nil
+}
+
+func (ur *UserRepository) GetAll(ctx context.Context) (_ []*domainEntity.User, err error) {
+	ctx, finish := ur.observe(ctx, "get_all")
+	defer func() { finish(err) }()
+
 	cursor, err := ur.collection.Find(ctx, bson.M{})
 	if err != nil {
-		return nil, customErr.NewInternalServerError("Failed to get users")
+		return nil, customErr.NewInternalServerError("Failed to get users", err)
 	}
 
 	defer func() {
 		if err := cursor.Close(ctx); err != nil {
-			log.Println("Error closing cursor:", err)
+			ur.logger.ForContext(ctx).Warn("Error closing cursor: ", err)
 		}
 	}()
 
 	var users []*mongoEntity.User
 	if err := cursor.All(ctx, &users); err != nil {
-		return nil, err
+		return nil, customErr.NewInternalServerError("Failed to decode users", err)
 	}
 
 	return mapper.MapMongoUsersToDomainUsers(users), nil
 }
 
-func (ur *UserRepository) Create(ctx context.Context, domainUser *domainEntity.User) (bool, error) {
+func (ur *UserRepository) Create(ctx context.Context, domainUser *domainEntity.User) (_ bool, err error) {
+	ctx, finish := ur.observe(ctx, "create")
+	defer func() { finish(err) }()
+
 	mongoUser, err := mapper.MapDomainUserToMongoUser(domainUser)
 	if err != nil {
-		ur.logger.Error("Error while mapping user: ", err)
+		ur.logger.ForContext(ctx).Error("Error while mapping user: ", err)
 		return false, err
 	}
 
 	_, err = ur.collection.InsertOne(ctx, mongoUser)
 	if err != nil {
-		return false, customErr.NewInternalServerError("Failed to create a user")
+		return false, customErr.NewInternalServerError("Failed to create a user", err)
 	}
 
 	return true, nil
 }
 
-func (ur *UserRepository) Update(ctx context.Context, id string, domainUser *domainEntity.User) (bool, error) {
+func (ur *UserRepository) Update(ctx context.Context, id string, domainUser *domainEntity.User) (_ bool, err error) {
+	ctx, finish := ur.observe(ctx, "update")
+	defer func() { finish(err) }()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return false, customErr.NewInternalServerError("Invalid user ID format")
+		return false, customErr.NewInternalServerError("Invalid user ID format", err)
 	}
 
 	bsonUser := mapper.MapDomainUserToBsonUser(domainUser)
 
 	_, err = ur.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bsonUser})
 	if err != nil {
-		return false, customErr.NewInternalServerError("Failed to update user")
+		return false, customErr.NewInternalServerError("Failed to update user", err)
 	}
 
 	return true, nil
 }
 
-func (ur *UserRepository) Delete(ctx context.Context, id string) (bool, error) {
+func (ur *UserRepository) Delete(ctx context.Context, id string) (_ bool, err error) {
+	ctx, finish := ur.observe(ctx, "delete")
+	defer func() { finish(err) }()
+
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return false, customErr.NewInternalServerError("Invalid user ID format")
+		return false, customErr.NewInternalServerError("Invalid user ID format", err)
 	}
 
 	_, err = ur.collection.DeleteOne(ctx, bson.M{"_id": objID})
 	if err != nil {
-		return false, customErr.NewInternalServerError("Failed to delete user")
+		return false, customErr.NewInternalServerError("Failed to delete user", err)
 	}
 
 	return true, nil
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const benchmarkCredentials = `{"email":"bench@example.com","password":"correct horse battery staple"}`
+
+// stackBenchmarks drive the full HTTP handler stack, middleware included,
+// against in-memory storage.
+var stackBenchmarks = []struct {
+	name      string
+	benchmark func(b *testing.B)
+	// maxLatency and maxAllocs are loose ceilings that only catch large
+	// regressions, so the check stays stable on slow or busy machines.
+	maxLatency time.Duration
+	maxAllocs  int64
+}{
+	{"SignIn", benchmarkSignIn, 100 * time.Millisecond, 2000},
+	{"Refresh", benchmarkRefresh, 20 * time.Millisecond, 2000},
+	{"Me", benchmarkMe, 10 * time.Millisecond, 1000},
+}
+
+func postJSON(handler http.Handler, path, body string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder
+}
+
+func responseCookie(recorder *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range recorder.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+
+	return nil
+}
+
+// newSignedInApplication signs up the benchmark user and returns the
+// application with the cookies of a fresh sign-in.
+func newSignedInApplication(b *testing.B) (*Application, *http.Cookie, *http.Cookie) {
+	b.Helper()
+
+	app := newTestApplication(b, nil)
+	if recorder := postJSON(app.Router, "/auth/signup", benchmarkCredentials); recorder.Code != http.StatusCreated && recorder.Code != http.StatusOK {
+		b.Fatalf("sign-up status = %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder := postJSON(app.Router, "/auth/signin", benchmarkCredentials)
+	if recorder.Code != http.StatusOK {
+		b.Fatalf("sign-in status = %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	return app, responseCookie(recorder, "access_token"), responseCookie(recorder, "refresh_token")
+}
+
+func benchmarkSignIn(b *testing.B) {
+	app, _, _ := newSignedInApplication(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if recorder := postJSON(app.Router, "/auth/signin", benchmarkCredentials); recorder.Code != http.StatusOK {
+			b.Fatalf("status = %d", recorder.Code)
+		}
+	}
+}
+
+func benchmarkRefresh(b *testing.B) {
+	app, _, refreshToken := newSignedInApplication(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		recorder := postJSON(app.Router, "/auth/refresh", "", refreshToken)
+		if recorder.Code != http.StatusOK {
+			b.Fatalf("status = %d", recorder.Code)
+		}
+		refreshToken = responseCookie(recorder, "refresh_token")
+	}
+}
+
+func benchmarkMe(b *testing.B) {
+	app, accessToken, _ := newSignedInApplication(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if recorder := serve(app.Router, http.MethodGet, "/auth/me", accessToken.Value); recorder.Code != http.StatusOK {
+			b.Fatalf("status = %d", recorder.Code)
+		}
+	}
+}
+
+func BenchmarkHandlerStack(b *testing.B) {
+	for _, bm := range stackBenchmarks {
+		b.Run(bm.name, bm.benchmark)
+	}
+}
+
+func TestHandlerStackPerformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs benchmarks")
+	}
+
+	for _, bm := range stackBenchmarks {
+		t.Run(bm.name, func(t *testing.T) {
+			result := testing.Benchmark(bm.benchmark)
+			if result.N == 0 {
+				t.Fatal("benchmark failed")
+			}
+
+			latency := time.Duration(result.NsPerOp())
+			t.Logf("%s, %d allocs/op", latency, result.AllocsPerOp())
+
+			if latency > bm.maxLatency {
+				t.Errorf("latency = %s, want at most %s", latency, bm.maxLatency)
+			}
+			if allocs := result.AllocsPerOp(); allocs > bm.maxAllocs {
+				t.Errorf("allocations = %d/op, want at most %d", allocs, bm.maxAllocs)
+			}
+		})
+	}
+}
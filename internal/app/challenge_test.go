@@ -0,0 +1,99 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"jwtgo/internal/app/config"
+	"jwtgo/internal/app/schema"
+)
+
+func TestBearerChallenge(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.APIKeys.Hashes = []string{keyHash("service-key")}
+		cfg.Verification.Provider = "http"
+		cfg.Verification.URL = "http://127.0.0.1:0/verify"
+		cfg.Verification.Secret = "callback-secret"
+	})
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.Claims{
+		Id:        "user-1",
+		TokenType: schema.TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "expired-token",
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		},
+	}).SignedString([]byte(app.Config.Security.Secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		request   func() *http.Request
+		token     string
+		challenge string
+	}{
+		{
+			name:      "missing token",
+			request:   func() *http.Request { return httptest.NewRequest(http.MethodGet, "/auth/me", nil) },
+			challenge: `Bearer realm="jwtgo", error="invalid_token", error_description="Invalid access token"`,
+		},
+		{
+			name:      "invalid token",
+			request:   func() *http.Request { return httptest.NewRequest(http.MethodGet, "/auth/me", nil) },
+			token:     "not-a-token",
+			challenge: `Bearer realm="jwtgo", error="invalid_token", error_description="Token is invalid"`,
+		},
+		{
+			name:      "expired token",
+			request:   func() *http.Request { return httptest.NewRequest(http.MethodGet, "/auth/me", nil) },
+			token:     expired,
+			challenge: `Bearer realm="jwtgo", error="invalid_token", error_description="Token is expired"`,
+		},
+		{
+			name: "invalid credentials",
+			request: func() *http.Request {
+				return jsonRequest("/auth/signin", `{"email":"nobody@example.com","password":"`+testPassword+`"}`)
+			},
+		},
+		{
+			name: "invalid api key",
+			request: func() *http.Request {
+				req := jsonRequest("/oauth/introspect", `{"token":"anything"}`)
+				req.Header.Set(app.Config.APIKeys.Header, "wrong-key")
+				return req
+			},
+		},
+		{
+			name:    "invalid verification callback",
+			request: func() *http.Request { return jsonRequest("/auth/verification/callback", `{}`) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveRequest(app.Router, tt.request(), tt.token)
+			if recorder.Code != http.StatusUnauthorized {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusUnauthorized)
+			}
+
+			if challenge := recorder.Header().Get("WWW-Authenticate"); challenge != tt.challenge {
+				t.Errorf("WWW-Authenticate = %q, want %q", challenge, tt.challenge)
+			}
+		})
+	}
+}
+
+func jsonRequest(path, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	return req
+}
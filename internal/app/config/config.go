@@ -1,6 +1,10 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
 	"sync"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -10,37 +14,280 @@ import (
 
 type Config struct {
 	App struct {
-		Host  string `yaml:"host" env-required:"true"`
-		Port  string `yaml:"port" env-required:"true"`
+		Host  string `yaml:"host" env:"APP_HOST" env-required:"true"`
+		Port  string `yaml:"port" env:"APP_PORT" env-required:"true"`
 		Debug bool   `yaml:"debug"`
+
+		Storage string `yaml:"storage" env:"APP_STORAGE" env-default:"mongodb"`
+
+		TrustedProxies []string `yaml:"trusted_proxies"`
+
+		RedirectTrailingSlash bool `yaml:"redirect_trailing_slash" env:"APP_REDIRECT_TRAILING_SLASH"`
+		CaseInsensitiveRoutes bool `yaml:"case_insensitive_routes" env:"APP_CASE_INSENSITIVE_ROUTES"`
+
+		MaxInFlight int `yaml:"max_in_flight"`
+		RetryAfter  int `yaml:"retry_after"`
+
+		ShutdownDelay   int `yaml:"shutdown_delay"`
+		ShutdownTimeout int `yaml:"shutdown_timeout" env-default:"30"`
 	} `yaml:"app" env-required:"true"`
 
+	Log struct {
+		Level      string            `yaml:"level" env:"LOG_LEVEL" env-default:"info"`
+		Format     string            `yaml:"format" env-default:"text"`
+		Components map[string]string `yaml:"components"`
+
+		RedactedFields   []string                          `yaml:"redacted_fields" env-default:"password,token,authorization,cookie,secret"`
+		RedactedPatterns []string                          `yaml:"redacted_patterns"`
+		MaskEmails       bool                              `yaml:"mask_emails"`
+		Sampling         map[string]logging.SamplingPolicy `yaml:"sampling"`
+
+		Outputs []string `yaml:"outputs" env-default:"stdout"`
+		File    struct {
+			Path       string `yaml:"path" env-default:"logs/jwtgo.log"`
+			MaxSize    int64  `yaml:"max_size"`
+			MaxBackups int    `yaml:"max_backups"`
+			MaxAge     int    `yaml:"max_age"`
+			Compress   bool   `yaml:"compress"`
+		} `yaml:"file"`
+
+		Webhook struct {
+			URL           string            `yaml:"url"`
+			MinLevel      string            `yaml:"min_level" env-default:"warning"`
+			Fields        map[string]string `yaml:"fields"`
+			QueueSize     int               `yaml:"queue_size" env-default:"1024"`
+			BatchSize     int               `yaml:"batch_size" env-default:"50"`
+			FlushInterval int               `yaml:"flush_interval" env-default:"5"`
+			MaxRetries    int               `yaml:"max_retries"`
+		} `yaml:"webhook"`
+	} `yaml:"log"`
+
 	MongoDB struct {
-		Url      string `yaml:"url" env-required:"true"`
-		Database string `yaml:"database" env-required:"true"`
-	} `yaml:"mongodb" env-required:"true"`
+		Url      string `yaml:"url" env:"MONGODB_URL"`
+		Database string `yaml:"database" env:"MONGODB_DATABASE"`
+
+		Pool struct {
+			MaxSize       uint64 `yaml:"max_size"`
+			MinSize       uint64 `yaml:"min_size"`
+			MaxConnecting uint64 `yaml:"max_connecting" env-default:"2"`
+			MaxIdleTime   int    `yaml:"max_idle_time"`
+			SlowWait      int    `yaml:"slow_wait" env-default:"100"`
+			StatsInterval int    `yaml:"stats_interval"`
+		} `yaml:"pool"`
+	} `yaml:"mongodb"`
 
 	Security struct {
-		Salt            string `yaml:"salt" env-required:"true"`
-		Secret          string `yaml:"secret" env-required:"true"`
-		BcryptCost      int    `yaml:"bcrypt_cost" env-required:"true"`
-		AccessLifetime  int    `yaml:"access_lifetime" env-required:"true"`
-		RefreshLifetime int    `yaml:"refresh_lifetime" env-required:"true"`
+		Salt                 string `yaml:"salt" env:"SECURITY_SALT" env-required:"true"`
+		Secret               string `yaml:"secret" env:"SECURITY_SECRET" env-required:"true"`
+		BcryptCost           int    `yaml:"bcrypt_cost" env:"SECURITY_BCRYPT_COST" env-required:"true"`
+		AccessLifetime       int    `yaml:"access_lifetime" env:"SECURITY_ACCESS_LIFETIME" env-required:"true"`
+		RefreshLifetime      int    `yaml:"refresh_lifetime" env:"SECURITY_REFRESH_LIFETIME" env-required:"true"`
+		ConfirmationLifetime int    `yaml:"confirmation_lifetime" env-default:"5"`
+		DisablePasswordLogin bool   `yaml:"disable_password_login"`
+		TokenFormat          string `yaml:"token_format" env:"SECURITY_TOKEN_FORMAT" env-default:"jws"`
+		EncryptionKey        string `yaml:"encryption_key" env:"SECURITY_ENCRYPTION_KEY"`
+
+		ClearCookiesOnRefreshFailure bool `yaml:"clear_cookies_on_refresh_failure"`
+		IncludeRoles                 bool `yaml:"include_roles"`
+		SingleSession                bool `yaml:"single_session"`
+		RefreshGracePeriod           int  `yaml:"refresh_grace_period"`
+
+		MaxAccessLifetime       int `yaml:"max_access_lifetime" env-default:"1440"`
+		MaxRefreshLifetime      int `yaml:"max_refresh_lifetime" env-default:"129600"`
+		MaxConfirmationLifetime int `yaml:"max_confirmation_lifetime" env-default:"60"`
+
+		BcryptTuning struct {
+			Enabled    bool `yaml:"enabled"`
+			Target     int  `yaml:"target" env-default:"250"`
+			AutoSelect bool `yaml:"auto_select"`
+		} `yaml:"bcrypt_tuning"`
+
+		AllowedEmailDomains  []string `yaml:"allowed_email_domains"`
+		AllowEmailSubdomains bool     `yaml:"allow_email_subdomains"`
+
+		IdentifierOrder []string `yaml:"identifier_order" env-default:"email,id"`
+
+		DisposableEmail struct {
+			Enabled         bool     `yaml:"enabled"`
+			ExtraDomains    []string `yaml:"extra_domains"`
+			ReplaceDefaults bool     `yaml:"replace_defaults"`
+		} `yaml:"disposable_email"`
+
+		PasswordEntropy struct {
+			Enabled bool    `yaml:"enabled"`
+			MinBits float64 `yaml:"min_bits" env-default:"40"`
+		} `yaml:"password_entropy"`
+
+		SignupCooldown struct {
+			Enabled bool `yaml:"enabled"`
+			Limit   int  `yaml:"limit" env-default:"3"`
+			Window  int  `yaml:"window" env-default:"60"`
+		} `yaml:"signup_cooldown"`
+
+		RefreshBinding struct {
+			Enabled    bool `yaml:"enabled"`
+			IPv4Prefix int  `yaml:"ipv4_prefix"`
+			IPv6Prefix int  `yaml:"ipv6_prefix"`
+		} `yaml:"refresh_binding"`
 	} `yaml:"security" env-required:"true"`
+
+	Health struct {
+		CacheTTL int `yaml:"cache_ttl"`
+		Timeout  int `yaml:"timeout" env-default:"2"`
+	} `yaml:"health"`
+
+	Tracing struct {
+		Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+		Insecure    bool    `yaml:"insecure" env:"OTEL_EXPORTER_OTLP_INSECURE"`
+		SampleRatio float64 `yaml:"sample_ratio" env:"OTEL_TRACES_SAMPLER_ARG"`
+		ServiceName string  `yaml:"service_name" env:"OTEL_SERVICE_NAME" env-default:"jwtgo"`
+	} `yaml:"tracing"`
+
+	Metrics struct {
+		Enabled bool   `yaml:"enabled"`
+		Path    string `yaml:"path" env-default:"/metrics"`
+		Listen  string `yaml:"listen"`
+
+		RequestBuckets []float64 `yaml:"request_buckets"`
+	} `yaml:"metrics"`
+
+	GRPC struct {
+		Enabled  bool   `yaml:"enabled"`
+		Listen   string `yaml:"listen" env-default:"127.0.0.1:9090"`
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+	} `yaml:"grpc"`
+
+	APIKeys struct {
+		Header      string   `yaml:"header" env-default:"X-API-Key"`
+		Hashes      []string `yaml:"hashes"`
+		AdminHashes []string `yaml:"admin_hashes"`
+	} `yaml:"api_keys"`
+
+	OpenAPI struct {
+		SwaggerUI bool `yaml:"swagger_ui"`
+	} `yaml:"openapi"`
+
+	Debug struct {
+		Enabled bool   `yaml:"enabled"`
+		Listen  string `yaml:"listen" env-default:"127.0.0.1:6060"`
+	} `yaml:"debug"`
+
+	OAuth struct {
+		AllowAccountLinking bool `yaml:"allow_account_linking"`
+		StateLifetime       int  `yaml:"state_lifetime" env-default:"10"`
+
+		Google struct {
+			Enabled      bool   `yaml:"enabled"`
+			ClientID     string `yaml:"client_id"`
+			ClientSecret string `yaml:"client_secret" env:"OAUTH_GOOGLE_CLIENT_SECRET"`
+			RedirectURL  string `yaml:"redirect_url"`
+		} `yaml:"google"`
+
+		GitHub struct {
+			Enabled      bool   `yaml:"enabled"`
+			ClientID     string `yaml:"client_id"`
+			ClientSecret string `yaml:"client_secret" env:"OAUTH_GITHUB_CLIENT_SECRET"`
+			RedirectURL  string `yaml:"redirect_url"`
+		} `yaml:"github"`
+	} `yaml:"oauth"`
+
+	LDAP struct {
+		Enabled            bool   `yaml:"enabled"`
+		URL                string `yaml:"url"`
+		StartTLS           bool   `yaml:"start_tls"`
+		InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+		BindDN             string `yaml:"bind_dn"`
+		BindPassword       string `yaml:"bind_password" env:"LDAP_BIND_PASSWORD"`
+		BaseDN             string `yaml:"base_dn"`
+		UserAttribute      string `yaml:"user_attribute" env-default:"mail"`
+		EmailAttribute     string `yaml:"email_attribute" env-default:"mail"`
+		Timeout            int    `yaml:"timeout" env-default:"5"`
+		PoolSize           int    `yaml:"pool_size" env-default:"4"`
+	} `yaml:"ldap"`
+
+	SecurityNotifier struct {
+		WebhookURL string `yaml:"webhook_url"`
+		MaxRetries int    `yaml:"max_retries"`
+	} `yaml:"security_notifier"`
+
+	Verification struct {
+		Provider    string `yaml:"provider"`
+		URL         string `yaml:"url"`
+		CallbackURL string `yaml:"callback_url"`
+		Secret      string `yaml:"secret" env:"VERIFICATION_SECRET"`
+		MaxRetries  int    `yaml:"max_retries"`
+		Tolerance   int    `yaml:"tolerance" env-default:"300"`
+	} `yaml:"verification"`
+
+	Audit struct {
+		Enabled         bool   `yaml:"enabled"`
+		Path            string `yaml:"path" env-default:"logs/audit.log"`
+		Key             string `yaml:"key" env:"AUDIT_KEY"`
+		CheckpointEvery int    `yaml:"checkpoint_every"`
+		RecentPerUser   int    `yaml:"recent_per_user"`
+	} `yaml:"audit"`
+
+	WebAuthn struct {
+		Enabled       bool     `yaml:"enabled"`
+		RPDisplayName string   `yaml:"rp_display_name"`
+		RPID          string   `yaml:"rp_id"`
+		RPOrigins     []string `yaml:"rp_origins"`
+	} `yaml:"webauthn"`
 }
 
+const defaultPath = "configs/config.yaml"
+
 var instance *Config
 var once sync.Once
 
 func GetConfig(logger *logging.Logger) *Config {
 	once.Do(func() {
-		instance = &Config{}
-		if err := cleanenv.ReadConfig("configs/config.yaml", instance); err != nil {
-			help, _ := cleanenv.GetDescription(instance, nil)
+		var err error
+		instance, err = Load(Path())
+		if err != nil {
+			help, _ := cleanenv.GetDescription(&Config{}, nil)
 			logger.Info(help)
-			logger.Fatal(err)
+			logger.Fatal("Invalid config: ", err)
 		}
 	})
 
 	return instance
 }
+
+// Path returns the config file named by CONFIG_PATH, or configs/config.yaml.
+func Path() string {
+	if path := os.Getenv("CONFIG_PATH"); path != "" {
+		return path
+	}
+
+	return defaultPath
+}
+
+// Load reads the YAML or JSON file at path, if it exists, and then applies
+// environment variables on top of it, so env always wins over the file.
+func Load(path string) (*Config, error) {
+	cfg := defaultConfig()
+
+	file, err := os.Open(path)
+	switch {
+	case err == nil:
+		defer file.Close()
+		// JSON is valid YAML, so both formats go through the same yaml tags.
+		if err := cleanenv.ParseYAML(file, cfg); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	if err := cleanenv.ReadEnv(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
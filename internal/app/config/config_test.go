@@ -0,0 +1,254 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const securityConfig = `
+security:
+  salt: "test-salt"
+  secret: "test-secret"
+  bcrypt_cost: 4
+  access_lifetime: 10
+  refresh_lifetime: 60
+`
+
+const appConfig = `
+app:
+  host: "127.0.0.1"
+  port: "8080"
+  storage: "memory"
+`
+
+func loadConfig(t *testing.T, content string) *Config {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cfg
+}
+
+func TestLoadAppliesDefaultsForOmittedFields(t *testing.T) {
+	cfg := loadConfig(t, appConfig+securityConfig)
+
+	if !cfg.App.RedirectTrailingSlash {
+		t.Error("app.redirect_trailing_slash defaulted to false, want true")
+	}
+	if cfg.Tracing.SampleRatio != 1 {
+		t.Errorf("tracing.sample_ratio = %v, want 1", cfg.Tracing.SampleRatio)
+	}
+	if cfg.Audit.CheckpointEvery != 100 {
+		t.Errorf("audit.checkpoint_every = %d, want 100", cfg.Audit.CheckpointEvery)
+	}
+	if cfg.Security.RefreshGracePeriod != 5 {
+		t.Errorf("security.refresh_grace_period = %d, want 5", cfg.Security.RefreshGracePeriod)
+	}
+}
+
+func TestLoadKeepsExplicitZeroValues(t *testing.T) {
+	cfg := loadConfig(t, appConfig+`  redirect_trailing_slash: false
+`+securityConfig+`  refresh_grace_period: 0
+
+tracing:
+  sample_ratio: 0
+audit:
+  checkpoint_every: 0
+health:
+  cache_ttl: 0
+verification:
+  max_retries: 0
+`)
+
+	if cfg.App.RedirectTrailingSlash {
+		t.Error("app.redirect_trailing_slash: false was overridden")
+	}
+	if cfg.Tracing.SampleRatio != 0 {
+		t.Errorf("tracing.sample_ratio = %v, want 0", cfg.Tracing.SampleRatio)
+	}
+	if cfg.Audit.CheckpointEvery != 0 {
+		t.Errorf("audit.checkpoint_every = %d, want 0", cfg.Audit.CheckpointEvery)
+	}
+	if cfg.Health.CacheTTL != 0 {
+		t.Errorf("health.cache_ttl = %d, want 0", cfg.Health.CacheTTL)
+	}
+	if cfg.Verification.MaxRetries != 0 {
+		t.Errorf("verification.max_retries = %d, want 0", cfg.Verification.MaxRetries)
+	}
+	if cfg.Security.RefreshGracePeriod != 0 {
+		t.Errorf("security.refresh_grace_period = %d, want 0", cfg.Security.RefreshGracePeriod)
+	}
+}
+
+func TestLoadRouteSwitchesFromEnv(t *testing.T) {
+	t.Setenv("APP_REDIRECT_TRAILING_SLASH", "false")
+	t.Setenv("APP_CASE_INSENSITIVE_ROUTES", "true")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0")
+
+	cfg := loadConfig(t, appConfig+securityConfig)
+
+	if cfg.App.RedirectTrailingSlash {
+		t.Error("APP_REDIRECT_TRAILING_SLASH=false was ignored")
+	}
+	if !cfg.App.CaseInsensitiveRoutes {
+		t.Error("APP_CASE_INSENSITIVE_ROUTES=true was ignored")
+	}
+	if cfg.Tracing.SampleRatio != 0 {
+		t.Errorf("OTEL_TRACES_SAMPLER_ARG=0 gave sample ratio %v, want 0", cfg.Tracing.SampleRatio)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	t.Setenv("SECURITY_ACCESS_LIFETIME", "20")
+
+	cfg := loadConfig(t, appConfig+securityConfig+`
+log:
+  level: "warn"
+`)
+
+	if cfg.Security.AccessLifetime != 20 {
+		t.Errorf("access lifetime = %d, want the env value 20", cfg.Security.AccessLifetime)
+	}
+	if cfg.Security.RefreshLifetime != 60 {
+		t.Errorf("refresh lifetime = %d, want the file value 60", cfg.Security.RefreshLifetime)
+	}
+	if cfg.Log.Level != "warn" {
+		t.Errorf("log level = %q, want the file value warn", cfg.Log.Level)
+	}
+	if cfg.Security.TokenFormat != "jws" {
+		t.Errorf("token format = %q, want the default jws", cfg.Security.TokenFormat)
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	t.Setenv("APP_HOST", "127.0.0.1")
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_STORAGE", "memory")
+	t.Setenv("SECURITY_SALT", "test-salt")
+	t.Setenv("SECURITY_SECRET", "test-secret")
+	t.Setenv("SECURITY_BCRYPT_COST", "4")
+	t.Setenv("SECURITY_ACCESS_LIFETIME", "10")
+	t.Setenv("SECURITY_REFRESH_LIFETIME", "60")
+
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.App.Port != "8080" {
+		t.Fatalf("port = %q, want 8080", cfg.App.Port)
+	}
+}
+
+func TestLoadRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"malformed file", "app: [\n"},
+		{"unknown storage", `
+app:
+  host: "127.0.0.1"
+  port: "8080"
+  storage: "redis"
+` + securityConfig},
+		{"mongodb without url", `
+app:
+  host: "127.0.0.1"
+  port: "8080"
+  storage: "mongodb"
+` + securityConfig},
+		{"refresh not longer than access", appConfig + `
+security:
+  salt: "test-salt"
+  secret: "test-secret"
+  bcrypt_cost: 4
+  access_lifetime: 60
+  refresh_lifetime: 60
+`},
+		{"jwe without key", appConfig + securityConfig + `  token_format: "jwe"
+`},
+		{"negative refresh grace period", appConfig + securityConfig + `  refresh_grace_period: -1
+`},
+		{"api key hash not hex", appConfig + securityConfig + `
+api_keys:
+  hashes: ["not-a-digest"]
+`},
+		{"missing secret", appConfig + `
+security:
+  salt: "test-salt"
+  bcrypt_cost: 4
+  access_lifetime: 10
+  refresh_lifetime: 60
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Load(path); err == nil {
+				t.Fatal("invalid config was accepted")
+			}
+		})
+	}
+}
+
+func TestLoadRejectsExcessiveLifetimes(t *testing.T) {
+	tests := []struct {
+		name     string
+		security string
+		setting  string
+	}{
+		{"ten year access token", "  access_lifetime: 5256000\n  refresh_lifetime: 5256001\n", "security.access_lifetime"},
+		{"refresh above the default ceiling", "  access_lifetime: 10\n  refresh_lifetime: 200000\n", "security.refresh_lifetime"},
+		{"confirmation above the default ceiling", "  access_lifetime: 10\n  refresh_lifetime: 60\n  confirmation_lifetime: 120\n", "security.confirmation_lifetime"},
+		{"lowered ceiling", "  access_lifetime: 60\n  refresh_lifetime: 120\n  max_access_lifetime: 30\n", "security.access_lifetime"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			content := appConfig + "security:\n  salt: \"test-salt\"\n  secret: \"test-secret\"\n  bcrypt_cost: 4\n" + tt.security
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Load(path)
+			if err == nil {
+				t.Fatal("excessive lifetime was accepted")
+			}
+			if !strings.Contains(err.Error(), tt.setting) || !strings.Contains(err.Error(), "exceeds the configured maximum") {
+				t.Fatalf("error = %q, want it to name %s and the ceiling", err, tt.setting)
+			}
+		})
+	}
+}
+
+func TestLoadAcceptsRaisedLifetimeCeiling(t *testing.T) {
+	cfg := loadConfig(t, appConfig+`
+security:
+  salt: "test-salt"
+  secret: "test-secret"
+  bcrypt_cost: 4
+  access_lifetime: 2880
+  refresh_lifetime: 5760
+  max_access_lifetime: 2880
+`)
+
+	if cfg.Security.AccessLifetime != 2880 {
+		t.Fatalf("access lifetime = %d, want 2880", cfg.Security.AccessLifetime)
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+// defaultConfig holds the defaults of settings whose zero value means
+// something, such as false, no retries or a disabled interval. They are set
+// before the file and the environment are read: cleanenv's env-default would
+// also replace a zero that was configured on purpose.
+func defaultConfig() *Config {
+	cfg := &Config{}
+
+	cfg.App.RedirectTrailingSlash = true
+	cfg.App.RetryAfter = 1
+
+	cfg.Log.File.MaxSize = 100
+	cfg.Log.File.MaxBackups = 5
+	cfg.Log.File.MaxAge = 30
+	cfg.Log.Webhook.MaxRetries = 3
+
+	cfg.MongoDB.Pool.MaxSize = 100
+	cfg.MongoDB.Pool.StatsInterval = 15
+
+	cfg.Security.RefreshGracePeriod = 5
+	cfg.Security.RefreshBinding.IPv4Prefix = 24
+	cfg.Security.RefreshBinding.IPv6Prefix = 64
+
+	cfg.Health.CacheTTL = 5
+	cfg.Tracing.SampleRatio = 1
+
+	cfg.SecurityNotifier.MaxRetries = 3
+	cfg.Verification.MaxRetries = 3
+
+	cfg.Audit.CheckpointEvery = 100
+	cfg.Audit.RecentPerUser = 50
+
+	return cfg
+}
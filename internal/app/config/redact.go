@@ -0,0 +1,85 @@
+package config
+
+import (
+	"net/url"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// String renders the config as YAML with every secret replaced, so it is safe
+// to print or log.
+func (c *Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(out)
+}
+
+// Redacted returns a copy of the config with every secret replaced.
+func (c *Config) Redacted() *Config {
+	copied := *c
+
+	for _, secret := range []*string{
+		&copied.Security.Salt,
+		&copied.Security.Secret,
+		&copied.Security.EncryptionKey,
+		&copied.OAuth.Google.ClientSecret,
+		&copied.OAuth.GitHub.ClientSecret,
+		&copied.LDAP.BindPassword,
+		&copied.Verification.Secret,
+		&copied.Audit.Key,
+	} {
+		if *secret != "" {
+			*secret = redacted
+		}
+	}
+
+	copied.MongoDB.Url = redactURL(copied.MongoDB.Url)
+	copied.Log.Webhook.URL = redactWebhookURL(copied.Log.Webhook.URL)
+	copied.SecurityNotifier.WebhookURL = redactWebhookURL(copied.SecurityNotifier.WebhookURL)
+
+	return &copied
+}
+
+// redactURL keeps the host and path of a URL but hides its password and
+// query, which is where DSNs and webhooks carry credentials.
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		if rawURL == "" {
+			return ""
+		}
+		return redacted
+	}
+
+	if parsed.RawQuery != "" {
+		parsed.RawQuery = redacted
+	}
+
+	return parsed.Redacted()
+}
+
+// redactWebhookURL keeps only the scheme and host of a webhook URL. Services
+// such as Slack put the token in the path, so nothing after the host is safe
+// to print.
+func redactWebhookURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return redacted
+	}
+
+	kept := url.URL{Scheme: parsed.Scheme, Host: parsed.Host}
+	if parsed.User == nil && parsed.Path == "" && parsed.RawQuery == "" && parsed.Fragment == "" {
+		return kept.String()
+	}
+
+	return kept.String() + "/" + redacted
+}
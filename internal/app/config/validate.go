@@ -0,0 +1,112 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+func (c *Config) validate() error {
+	switch c.App.Storage {
+	case "mongodb":
+		if c.MongoDB.Url == "" || c.MongoDB.Database == "" {
+			return fmt.Errorf("mongodb.url and mongodb.database are required when app.storage is mongodb")
+		}
+		if c.MongoDB.Pool.MaxSize > 0 && c.MongoDB.Pool.MinSize > c.MongoDB.Pool.MaxSize {
+			return fmt.Errorf("mongodb.pool.min_size must not exceed mongodb.pool.max_size")
+		}
+	case "memory":
+	default:
+		return fmt.Errorf("app.storage must be mongodb or memory, got %q", c.App.Storage)
+	}
+
+	if c.App.ShutdownTimeout <= 0 || c.App.ShutdownDelay < 0 {
+		return fmt.Errorf("app.shutdown_timeout must be positive and app.shutdown_delay must not be negative")
+	}
+
+	lifetimes := []struct {
+		name     string
+		value    int
+		maxValue int
+	}{
+		{"security.access_lifetime", c.Security.AccessLifetime, c.Security.MaxAccessLifetime},
+		{"security.refresh_lifetime", c.Security.RefreshLifetime, c.Security.MaxRefreshLifetime},
+		{"security.confirmation_lifetime", c.Security.ConfirmationLifetime, c.Security.MaxConfirmationLifetime},
+	}
+
+	for _, lifetime := range lifetimes {
+		if lifetime.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %d", lifetime.name, lifetime.value)
+		}
+		if lifetime.value > lifetime.maxValue {
+			return fmt.Errorf("%s is %d minutes, which exceeds the configured maximum of %d minutes", lifetime.name, lifetime.value, lifetime.maxValue)
+		}
+	}
+
+	if c.Security.RefreshGracePeriod < 0 {
+		return fmt.Errorf("security.refresh_grace_period must not be negative, got %d", c.Security.RefreshGracePeriod)
+	}
+
+	if c.Security.RefreshLifetime <= c.Security.AccessLifetime {
+		return fmt.Errorf("security.refresh_lifetime must be longer than security.access_lifetime")
+	}
+
+	switch c.Security.TokenFormat {
+	case "jws":
+	case "jwe":
+		if decoded, err := hex.DecodeString(c.Security.EncryptionKey); err != nil || len(decoded) != 32 {
+			return fmt.Errorf("security.encryption_key must be 32 hex-encoded bytes when security.token_format is jwe")
+		}
+	default:
+		return fmt.Errorf("security.token_format must be jws or jwe, got %q", c.Security.TokenFormat)
+	}
+
+	for _, keyHash := range append(c.APIKeys.Hashes, c.APIKeys.AdminHashes...) {
+		if decoded, err := hex.DecodeString(keyHash); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("api_keys.hashes and api_keys.admin_hashes must contain hex-encoded SHA-256 digests")
+		}
+	}
+
+	if c.LDAP.Enabled && (c.LDAP.URL == "" || c.LDAP.BaseDN == "" || c.LDAP.Timeout <= 0 || c.LDAP.PoolSize <= 0) {
+		return fmt.Errorf("ldap.url and ldap.base_dn are required and ldap.timeout and ldap.pool_size must be positive when ldap is enabled")
+	}
+
+	if c.Security.BcryptTuning.Enabled && c.Security.BcryptTuning.Target <= 0 {
+		return fmt.Errorf("security.bcrypt_tuning.target must be positive, got %d", c.Security.BcryptTuning.Target)
+	}
+
+	if c.Security.SignupCooldown.Enabled && (c.Security.SignupCooldown.Limit <= 0 || c.Security.SignupCooldown.Window <= 0) {
+		return fmt.Errorf("security.signup_cooldown.limit and security.signup_cooldown.window must be positive")
+	}
+
+	seenIdentifiers := make(map[string]bool, len(c.Security.IdentifierOrder))
+	for _, identifier := range c.Security.IdentifierOrder {
+		if identifier != "email" && identifier != "id" {
+			return fmt.Errorf("security.identifier_order may only contain email and id, got %q", identifier)
+		}
+		if seenIdentifiers[identifier] {
+			return fmt.Errorf("security.identifier_order lists %q more than once", identifier)
+		}
+		seenIdentifiers[identifier] = true
+	}
+
+	if c.Security.PasswordEntropy.Enabled && c.Security.PasswordEntropy.MinBits <= 0 {
+		return fmt.Errorf("security.password_entropy.min_bits must be positive, got %g", c.Security.PasswordEntropy.MinBits)
+	}
+
+	switch c.Verification.Provider {
+	case "", "noop":
+	case "http":
+		if c.Verification.URL == "" || c.Verification.Secret == "" || c.Verification.Tolerance <= 0 {
+			return fmt.Errorf("verification.url and verification.secret are required and verification.tolerance must be positive for the http provider")
+		}
+	default:
+		return fmt.Errorf("verification.provider must be empty, noop or http, got %q", c.Verification.Provider)
+	}
+
+	if binding := c.Security.RefreshBinding; binding.Enabled && (binding.IPv4Prefix < 0 || binding.IPv4Prefix > 32 || binding.IPv6Prefix < 0 || binding.IPv6Prefix > 128) {
+		return fmt.Errorf("security.refresh_binding.ipv4_prefix must be within 0-32 and security.refresh_binding.ipv6_prefix within 0-128")
+	}
+
+	return nil
+}
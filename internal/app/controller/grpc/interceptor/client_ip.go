@@ -0,0 +1,25 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"jwtgo/internal/pkg/request"
+)
+
+func ClientIP() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			host, _, err := net.SplitHostPort(p.Addr.String())
+			if err != nil {
+				host = p.Addr.String()
+			}
+			ctx = request.WithClientIP(ctx, host)
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,95 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"jwtgo/internal/pkg/i18n"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+const (
+	errorDomain       = "jwtgo"
+	fallbackErrorCode = "INTERNAL_SERVER_ERROR"
+)
+
+type codedError interface {
+	Code() string
+}
+
+type parameterizedError interface {
+	Params() map[string]string
+}
+
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusBadGateway:          codes.Unavailable,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// Errors converts coded application errors into gRPC statuses carrying the
+// error code as ErrorInfo, mirroring request.ErrorMapper for HTTP.
+func Errors(catalog *i18n.Catalog, statuses request.StatusResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if _, ok := status.FromError(err); ok {
+			return resp, err
+		}
+
+		code := fallbackErrorCode
+		var coded codedError
+		if errors.As(err, &coded) {
+			code = coded.Code()
+		}
+
+		httpStatus, ok := statuses(code)
+		if !ok {
+			code = fallbackErrorCode
+			httpStatus = http.StatusInternalServerError
+		}
+
+		grpcCode, ok := grpcCodes[httpStatus]
+		if !ok {
+			grpcCode = codes.Internal
+		}
+
+		if grpcCode == codes.Internal {
+			logging.FromContext(ctx).Error("RPC failed: ", err)
+		}
+
+		var params map[string]string
+		var parameterized parameterizedError
+		if errors.As(err, &parameterized) {
+			params = parameterized.Params()
+		}
+
+		st := status.New(grpcCode, catalog.Message(i18n.DefaultLocale, code, params))
+		if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+			Reason:   code,
+			Domain:   errorDomain,
+			Metadata: map[string]string{"request_id": requestId(ctx)},
+		}); detailErr == nil {
+			st = detailed
+		}
+
+		return resp, st.Err()
+	}
+}
@@ -0,0 +1,31 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"jwtgo/pkg/logging"
+)
+
+func RequestLogger(logger *logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		requestLogger := logger.WithFields(map[string]interface{}{
+			"request_id": requestId(ctx),
+			"rpc":        info.FullMethod,
+		})
+
+		resp, err := handler(logging.WithContext(ctx, requestLogger), req)
+
+		requestLogger.WithFields(map[string]interface{}{
+			"code":     status.Code(err).String(),
+			"duration": time.Since(start).String(),
+		}).Info("RPC finished")
+
+		return resp, err
+	}
+}
@@ -0,0 +1,22 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"jwtgo/internal/pkg/metrics"
+)
+
+func Metrics(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		m.ObserveRPC(info.FullMethod, status.Code(err).String(), start)
+
+		return resp, err
+	}
+}
@@ -0,0 +1,22 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	customErr "jwtgo/internal/app/error"
+)
+
+func Recovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = customErr.NewInternalServerError("Panic recovered", fmt.Errorf("%v", recovered))
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,38 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"jwtgo/internal/pkg/request"
+)
+
+const requestIdMetadataKey = "x-request-id"
+
+type requestIdContextKey struct{}
+
+func RequestId() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var requestId string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestIdMetadataKey); len(values) > 0 {
+				requestId = values[0]
+			}
+		}
+
+		if !request.IsValidId(requestId) {
+			requestId = request.GenerateId()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIdMetadataKey, requestId))
+
+		return handler(context.WithValue(ctx, requestIdContextKey{}, requestId), req)
+	}
+}
+
+func requestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdContextKey{}).(string)
+	return requestId
+}
@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "jwtgo/api/auth/v1"
+	"jwtgo/internal/app/controller/http/dto"
+	customErr "jwtgo/internal/app/error"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/app/schema"
+)
+
+type AuthServer struct {
+	authv1.UnimplementedAuthServiceServer
+
+	authService      serviceInterface.AuthService
+	jwtService       serviceInterface.JWTService
+	passwordLogin    bool
+	signUp           bool
+	requestValidator *validator.Validate
+}
+
+func NewAuthServer(
+	authService serviceInterface.AuthService,
+	jwtService serviceInterface.JWTService,
+	passwordLogin bool,
+	signUp bool,
+	requestValidator *validator.Validate,
+) *AuthServer {
+	return &AuthServer{
+		authService:      authService,
+		jwtService:       jwtService,
+		passwordLogin:    passwordLogin,
+		signUp:           signUp,
+		requestValidator: requestValidator,
+	}
+}
+
+func (as *AuthServer) Register(server *grpc.Server) {
+	authv1.RegisterAuthServiceServer(server, as)
+}
+
+func (as *AuthServer) SignUp(ctx context.Context, req *authv1.SignUpRequest) (*authv1.SignUpResponse, error) {
+	if !as.passwordLogin || !as.signUp {
+		return nil, status.Error(codes.Unimplemented, "sign-up is disabled")
+	}
+
+	userCredentialsDTO := dto.UserCredentialsDTO{Email: req.GetEmail(), Password: req.GetPassword()}
+	if err := as.requestValidator.Struct(userCredentialsDTO); err != nil {
+		return nil, customErr.NewInvalidRequestError("Invalid request parameters")
+	}
+
+	if _, err := as.authService.SignUp(ctx, &userCredentialsDTO); err != nil {
+		return nil, err
+	}
+
+	return &authv1.SignUpResponse{}, nil
+}
+
+func (as *AuthServer) SignIn(ctx context.Context, req *authv1.SignInRequest) (*authv1.TokenPair, error) {
+	if !as.passwordLogin {
+		return nil, status.Error(codes.Unimplemented, "password login is disabled")
+	}
+
+	userCredentialsDTO := dto.UserCredentialsDTO{Email: req.GetEmail(), Password: req.GetPassword()}
+	if err := as.requestValidator.Struct(userCredentialsDTO); err != nil {
+		return nil, customErr.NewInvalidRequestError("Invalid request parameters")
+	}
+
+	userTokensDTO, err := as.authService.SignIn(ctx, &userCredentialsDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authv1.TokenPair{AccessToken: userTokensDTO.AccessToken, RefreshToken: userTokensDTO.RefreshToken}, nil
+}
+
+func (as *AuthServer) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*authv1.TokenPair, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, customErr.NewInvalidTokenError("Invalid refresh token", "")
+	}
+
+	userTokensDTO, err := as.authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: req.GetRefreshToken()})
+	if err != nil {
+		return nil, err
+	}
+
+	return &authv1.TokenPair{AccessToken: userTokensDTO.AccessToken, RefreshToken: userTokensDTO.RefreshToken}, nil
+}
+
+func (as *AuthServer) ValidateToken(ctx context.Context, req *authv1.ValidateTokenRequest) (*authv1.ValidateTokenResponse, error) {
+	tokenType := req.GetTokenType()
+	switch tokenType {
+	case "":
+		tokenType = schema.TokenTypeAccess
+	case schema.TokenTypeAccess, schema.TokenTypeRefresh, schema.TokenTypeConfirmation:
+	default:
+		return nil, customErr.NewInvalidRequestError("Unknown token type")
+	}
+
+	claims, err := as.jwtService.ValidateToken(req.GetToken(), tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &authv1.ValidateTokenResponse{UserId: claims.Id, TokenType: claims.TokenType}
+	if claims.ExpiresAt != nil {
+		response.ExpiresAt = timestamppb.New(claims.ExpiresAt.Time)
+	}
+	if claims.AuthTime != nil {
+		response.AuthTime = timestamppb.New(claims.AuthTime.Time)
+	}
+
+	return response, nil
+}
+
+// RevokeToken authenticates the caller with their access token and then
+// revokes the given token the same way POST /auth/revoke does.
+func (as *AuthServer) RevokeToken(ctx context.Context, req *authv1.RevokeTokenRequest) (*authv1.RevokeTokenResponse, error) {
+	claims, err := as.jwtService.ValidateToken(req.GetAccessToken(), schema.TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	revokeTokenDTO := dto.RevokeTokenDTO{Token: req.GetToken()}
+	if err := as.requestValidator.Struct(revokeTokenDTO); err != nil {
+		return nil, customErr.NewInvalidRequestError("Invalid request parameters")
+	}
+
+	if err := as.authService.Revoke(ctx, claims.Id, &revokeTokenDTO); err != nil {
+		return nil, err
+	}
+
+	return &authv1.RevokeTokenResponse{}, nil
+}
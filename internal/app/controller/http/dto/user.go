@@ -1,15 +1,114 @@
 package dto
 
+import (
+	"time"
+)
+
 type UserRefreshTokenDTO struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
 type UserTokensDTO struct {
-	AccessToken  string `json:"accessToken"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string   `json:"accessToken"`
+	RefreshToken string   `json:"refresh_token"`
+	Roles        []string `json:"roles"`
 }
 
 type UserCredentialsDTO struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6,max=64"`
 }
+
+type ReauthenticateDTO struct {
+	Password string `json:"password" validate:"required,min=6,max=64"`
+}
+
+type RevokeTokenDTO struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ConfirmationTokenDTO struct {
+	ConfirmationToken string `json:"confirmation_token"`
+}
+
+type WebAuthnLoginDTO struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type IdentityDTO struct {
+	Provider string    `json:"provider"`
+	Email    string    `json:"email"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+type ProfileDTO struct {
+	Id        string    `json:"id"`
+	Email     string    `json:"email"`
+	Roles     []string  `json:"roles,omitempty"`
+	AuthTime  time.Time `json:"auth_time"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SecurityEventDTO struct {
+	Action    string    `json:"action"`
+	Outcome   string    `json:"outcome"`
+	Reason    string    `json:"reason,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+type ImportUsersDTO struct {
+	Users []ImportUserDTO `json:"users" validate:"required,min=1,max=1000"`
+}
+
+// ImportUserDTO carries either a plain temporary password or a password hash
+// and salt exported from a deployment with the same global salt.
+type ImportUserDTO struct {
+	Email        string   `json:"email"`
+	Password     string   `json:"password"`
+	PasswordHash string   `json:"password_hash"`
+	Salt         string   `json:"salt"`
+	Verified     bool     `json:"verified"`
+	Roles        []string `json:"roles"`
+}
+
+// ExportedUserDTO is one line of the admin user export. Its email, verified
+// and roles fields are read back by the import.
+type ExportedUserDTO struct {
+	Id        string    `json:"id"`
+	Email     string    `json:"email"`
+	Status    string    `json:"status"`
+	Verified  bool      `json:"verified"`
+	Roles     []string  `json:"roles"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ImportResultDTO struct {
+	Email  string `json:"email"`
+	Status string `json:"status"`
+	Code   string `json:"code,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type PasskeyDTO struct {
+	AttestationType string    `json:"attestation_type"`
+	Transports      []string  `json:"transports"`
+	BackupEligible  bool      `json:"backup_eligible"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type UserExportDTO struct {
+	Profile    ProfileDTO    `json:"profile"`
+	Identities []IdentityDTO `json:"identities"`
+	Passkeys   []PasskeyDTO  `json:"passkeys"`
+	ExportedAt time.Time     `json:"exported_at"`
+}
+
+// LogLevelDTO sets the log level, or raises it for Duration (a Go duration
+// such as "15m") before it reverts.
+type LogLevelDTO struct {
+	Level    string `json:"level" validate:"required,oneof=panic fatal error warn warning info debug trace"`
+	Duration string `json:"duration"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"jwtgo/internal/app/controller/http/dto"
 	"jwtgo/internal/app/entity"
+	"jwtgo/internal/pkg/audit"
 )
 
 func MapToUserRefreshTokenDTO(refreshToken string) *dto.UserRefreshTokenDTO {
@@ -20,6 +21,12 @@ func MapToUserTokensDTO(accessToken, refreshToken string) *dto.UserTokensDTO {
 	}
 }
 
+func MapToConfirmationTokenDTO(confirmationToken string) *dto.ConfirmationTokenDTO {
+	return &dto.ConfirmationTokenDTO{
+		ConfirmationToken: confirmationToken,
+	}
+}
+
 func MapUserCredentialsDTOToDomainUser(userCredentialsDTO *dto.UserCredentialsDTO) *entity.User {
 	now := time.Now().UTC()
 
@@ -30,3 +37,64 @@ func MapUserCredentialsDTOToDomainUser(userCredentialsDTO *dto.UserCredentialsDT
 		UpdatedAt: now,
 	}
 }
+
+func MapToIdentityDTOs(identities []entity.Identity) []dto.IdentityDTO {
+	identityDTOs := make([]dto.IdentityDTO, 0, len(identities))
+	for _, identity := range identities {
+		identityDTOs = append(identityDTOs, dto.IdentityDTO{
+			Provider: identity.Provider,
+			Email:    identity.Email,
+			LinkedAt: identity.LinkedAt,
+		})
+	}
+	return identityDTOs
+}
+
+func MapToProfileDTO(user *entity.User) *dto.ProfileDTO {
+	return &dto.ProfileDTO{
+		Id:        user.Id,
+		Email:     user.Email,
+		Roles:     user.Roles,
+		AuthTime:  user.AuthTime,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// MapToUserExportDTO leaves out password hashes, salts, refresh tokens and
+// passkey key material.
+func MapToUserExportDTO(user *entity.User, exportedAt time.Time) *dto.UserExportDTO {
+	passkeyDTOs := make([]dto.PasskeyDTO, 0, len(user.WebAuthnCredentials))
+	for _, credential := range user.WebAuthnCredentials {
+		passkeyDTOs = append(passkeyDTOs, dto.PasskeyDTO{
+			AttestationType: credential.AttestationType,
+			Transports:      credential.Transports,
+			BackupEligible:  credential.BackupEligible,
+			CreatedAt:       credential.CreatedAt,
+		})
+	}
+
+	return &dto.UserExportDTO{
+		Profile:    *MapToProfileDTO(user),
+		Identities: MapToIdentityDTOs(user.Identities),
+		Passkeys:   passkeyDTOs,
+		ExportedAt: exportedAt,
+	}
+}
+
+// MapToSecurityEventDTOs keeps only the fields a user may see about their own
+// account; token ids, request ids and network prefixes are left out.
+func MapToSecurityEventDTOs(records []audit.Record) []dto.SecurityEventDTO {
+	securityEventDTOs := make([]dto.SecurityEventDTO, 0, len(records))
+	for _, record := range records {
+		securityEventDTOs = append(securityEventDTOs, dto.SecurityEventDTO{
+			Action:    record.Action,
+			Outcome:   record.Outcome,
+			Reason:    record.Fields["reason"],
+			IP:        record.Fields["ip"],
+			UserAgent: record.Fields["user_agent"],
+			Time:      record.Time,
+		})
+	}
+	return securityEventDTOs
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/request"
+)
+
+// APIKey admits requests whose header carries a key whose SHA-256 digest is in
+// keyHashes. Every stored digest is compared in constant time so the position
+// of a match does not leak through timing.
+func APIKey(header string, keyHashes [][]byte, errorMapper *request.ErrorMapper) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		presented := sha256.Sum256([]byte(c.GetHeader(header)))
+
+		matched := 0
+		for _, keyHash := range keyHashes {
+			matched |= subtle.ConstantTimeCompare(presented[:], keyHash)
+		}
+
+		if c.GetHeader(header) == "" || matched != 1 {
+			errorMapper.RespondError(c, customErr.NewInvalidAPIKeyError("Invalid API key"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/pkg/request"
+)
+
+// ClientIP makes the address resolved by gin, which honours the trusted proxy
+// list, and the user agent available to services through the request context.
+func ClientIP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := request.WithClientIP(c.Request.Context(), c.ClientIP())
+		c.Request = c.Request.WithContext(request.WithUserAgent(ctx, c.Request.UserAgent()))
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/metrics"
+	"jwtgo/internal/pkg/request"
+)
+
+// ConcurrencyLimit sheds requests beyond limit in flight instead of queueing
+// them behind CPU-bound work such as password hashing. The slot is released in
+// a deferred call, so it is returned when the handler panics or finishes early
+// after the client disconnects. Routes in skipPaths, such as the health probes,
+// never take a slot, so a saturated instance is not restarted as unhealthy.
+func ConcurrencyLimit(limit int, retryAfter time.Duration, skipPaths []string, m *metrics.Metrics, errorMapper *request.ErrorMapper) gin.HandlerFunc {
+	slots := make(chan struct{}, limit)
+
+	skip := make(map[string]bool, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if skip[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			m.RequestShed()
+			errorMapper.RespondError(c, customErr.NewServerOverloadedError("Concurrent request limit reached", retryAfter))
+			c.Abort()
+			return
+		}
+		defer func() { <-slots }()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+func RequestId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(request.IdHeader)
+		if !request.IsValidId(requestId) {
+			requestId = request.GenerateId()
+		}
+
+		request.SetId(c, requestId)
+		c.Next()
+	}
+}
+
+func RequestLogger(logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestLogger := logger.WithFields(map[string]interface{}{
+			"request_id": request.Id(c),
+			"route":      c.FullPath(),
+		})
+
+		c.Request = c.Request.WithContext(logging.WithContext(c.Request.Context(), requestLogger))
+		c.Next()
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/request"
+)
+
+func Recovery(errorMapper *request.ErrorMapper) gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		errorMapper.RespondError(c, customErr.NewInternalServerError("Panic recovered", fmt.Errorf("%v", recovered)))
+		c.Abort()
+	})
+}
@@ -1,30 +1,37 @@
 package middleware
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 
+	customErr "jwtgo/internal/app/error"
 	clientInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/app/schema"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
 )
 
-func Authentication(jwtService clientInterface.JWTService) gin.HandlerFunc {
+func Authentication(jwtService clientInterface.JWTService, errorMapper *request.ErrorMapper) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		accessToken, err := c.Request.Cookie("access_token")
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid access token"})
+			errorMapper.RespondError(c, customErr.NewInvalidTokenError("Invalid access token", ""))
 			c.Abort()
 			return
 		}
 
-		claims, err := jwtService.ValidateToken(accessToken.Value)
+		claims, err := jwtService.ValidateToken(accessToken.Value, schema.TokenTypeAccess)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			errorMapper.RespondError(c, err)
 			c.Abort()
 			return
 		}
 
 		c.Set("id", claims.Id)
+		c.Set("claims", claims)
+
+		ctx := c.Request.Context()
+		c.Request = c.Request.WithContext(logging.WithContext(ctx, logging.FromContext(ctx).WithField("user_id", claims.Id)))
+
 		c.Next()
 	}
 }
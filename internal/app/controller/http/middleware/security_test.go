@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/app/schema"
+	"jwtgo/internal/app/service"
+	"jwtgo/internal/pkg/i18n"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/internal/pkg/revocation"
+	"jwtgo/pkg/logging"
+)
+
+func newAuthenticatedRouter(t testing.TB) (*gin.Engine, *service.JWTService) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	logger := logging.GetLogger("panic")
+
+	catalog, err := i18n.NewCatalog(&logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwtService := service.NewJWTService("test-secret", 10, 60, 5, revocation.NewList(), nil, nil)
+	router := gin.New()
+	router.GET("/me", Authentication(jwtService, request.NewErrorMapper(catalog, customErr.Status, &logger)), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("id"))
+	})
+
+	return router, jwtService
+}
+
+func authenticatedRequest(accessToken string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if accessToken != "" {
+		req.AddCookie(&http.Cookie{Name: "access_token", Value: accessToken})
+	}
+
+	return req
+}
+
+func TestAuthentication(t *testing.T) {
+	router, jwtService := newAuthenticatedRouter(t)
+
+	accessToken, refreshToken, err := jwtService.GenerateTokens(schema.Claims{Id: "user-1", Email: "user@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		accessToken string
+		status      int
+	}{
+		{"valid token", accessToken, http.StatusOK},
+		{"no token", "", http.StatusUnauthorized},
+		{"refresh token", refreshToken, http.StatusUnauthorized},
+		{"garbage", "garbage", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, authenticatedRequest(tt.accessToken))
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+			if tt.status == http.StatusOK && recorder.Body.String() != "user-1" {
+				t.Fatalf("user id = %q, want user-1", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func BenchmarkAuthentication(b *testing.B) {
+	router, jwtService := newAuthenticatedRouter(b)
+
+	accessToken, _, err := jwtService.GenerateTokens(schema.Claims{Id: "user-1", Email: "user@example.com"})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, authenticatedRequest(accessToken))
+		if recorder.Code != http.StatusOK {
+			b.Fatalf("status = %d", recorder.Code)
+		}
+	}
+}
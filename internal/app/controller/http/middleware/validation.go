@@ -1,23 +1,24 @@
 package middleware
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/request"
 )
 
-func Validator[T any](validate *validator.Validate) gin.HandlerFunc {
+func Validator[T any](validate *validator.Validate, errorMapper *request.ErrorMapper) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var obj T
 		if err := c.ShouldBindJSON(&obj); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request parameters"})
+			errorMapper.RespondError(c, customErr.NewInvalidRequestError("Invalid request parameters"))
 			c.Abort()
 			return
 		}
 
 		if err := validate.Struct(obj); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request parameters"})
+			errorMapper.RespondError(c, customErr.NewInvalidRequestError("Invalid request parameters"))
 			c.Abort()
 			return
 		}
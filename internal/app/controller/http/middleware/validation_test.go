@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"jwtgo/internal/app/controller/http/dto"
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/i18n"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+func newValidatedRouter(t testing.TB, newValidator func() *validator.Validate) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	logger := logging.GetLogger("panic")
+
+	catalog, err := i18n.NewCatalog(&logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errorMapper := request.NewErrorMapper(catalog, customErr.Status, &logger)
+
+	router := gin.New()
+	router.POST("/sign-in", func(c *gin.Context) {
+		Validator[dto.UserCredentialsDTO](newValidator(), errorMapper)(c)
+	}, func(c *gin.Context) {
+		c.String(http.StatusOK, c.MustGet("validatedBody").(dto.UserCredentialsDTO).Email)
+	})
+
+	return router
+}
+
+func sharedValidator() func() *validator.Validate {
+	validate := validator.New()
+	return func() *validator.Validate { return validate }
+}
+
+func credentialsRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/sign-in", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestValidator(t *testing.T) {
+	router := newValidatedRouter(t, sharedValidator())
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"valid", `{"email":"user@example.com","password":"secret-password"}`, http.StatusOK},
+		{"invalid email", `{"email":"user","password":"secret-password"}`, http.StatusBadRequest},
+		{"short password", `{"email":"user@example.com","password":"abc"}`, http.StatusBadRequest},
+		{"missing field", `{"email":"user@example.com"}`, http.StatusBadRequest},
+		{"not JSON", `email=user@example.com`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, credentialsRequest(tt.body))
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.status, recorder.Body)
+			}
+			if tt.status == http.StatusOK && recorder.Body.String() != "user@example.com" {
+				t.Fatalf("validated body = %q", recorder.Body.String())
+			}
+		})
+	}
+}
+
+// One validator instance serves every request, so validations must be safe to
+// run concurrently once it is built. Run with -race.
+func TestValidatorConcurrentRequests(t *testing.T) {
+	router := newValidatedRouter(t, sharedValidator())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for worker := range 64 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range 50 {
+				email, status := fmt.Sprintf("user%d-%d@example.com", worker, i), http.StatusOK
+				if i%3 == 0 {
+					email, status = fmt.Sprintf("user%d-%d", worker, i), http.StatusBadRequest
+				}
+
+				recorder := httptest.NewRecorder()
+				router.ServeHTTP(recorder, credentialsRequest(`{"email":"`+email+`","password":"secret-password"}`))
+
+				if recorder.Code != status {
+					errs <- fmt.Errorf("%s: status = %d, want %d", email, recorder.Code, status)
+					return
+				}
+				if status == http.StatusOK && recorder.Body.String() != email {
+					errs <- fmt.Errorf("%s: validated body = %q", email, recorder.Body.String())
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// BenchmarkValidator compares the shared instance built at startup with a
+// validator built for every request, which has to parse the DTO tags again.
+func BenchmarkValidator(b *testing.B) {
+	benchmarks := []struct {
+		name         string
+		newValidator func() *validator.Validate
+	}{
+		{"shared", sharedValidator()},
+		{"per_request", func() *validator.Validate { return validator.New() }},
+	}
+
+	body := `{"email":"user@example.com","password":"secret-password"}`
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			router := newValidatedRouter(b, bm.newValidator)
+
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					recorder := httptest.NewRecorder()
+					router.ServeHTTP(recorder, credentialsRequest(body))
+					if recorder.Code != http.StatusOK {
+						b.Fatalf("status = %d", recorder.Code)
+					}
+				}
+			})
+		})
+	}
+}
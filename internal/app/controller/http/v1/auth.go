@@ -14,6 +14,7 @@ import (
 	"jwtgo/internal/app/controller/http/middleware"
 	customErr "jwtgo/internal/app/error"
 	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/openapi"
 	"jwtgo/internal/pkg/request"
 	"jwtgo/internal/pkg/request/schema"
 	"jwtgo/pkg/logging"
@@ -21,46 +22,153 @@ import (
 
 type AuthController struct {
 	authService      serviceInterface.AuthService
+	authentication   gin.HandlerFunc
+	signupCooldown   bool
+	passwordLogin    bool
+	signUp           bool
+	clearOnFailure   bool
+	includeRoles     bool
 	requestValidator *validator.Validate
+	errorMapper      *request.ErrorMapper
 	logger           *logging.Logger
 }
 
+// AuthControllerOptions switches parts of the password flow on and off.
+type AuthControllerOptions struct {
+	// SignupCooldown adds the cooldown error to the documented sign-up errors.
+	SignupCooldown bool
+	PasswordLogin  bool
+	SignUp         bool
+	// ClearCookiesOnRefreshFailure expires the token cookies when a refresh
+	// fails for good.
+	ClearCookiesOnRefreshFailure bool
+	IncludeRoles                 bool
+}
+
 func NewAuthController(
 	authService serviceInterface.AuthService,
+	authentication gin.HandlerFunc,
+	options AuthControllerOptions,
 	requestValidator *validator.Validate,
+	errorMapper *request.ErrorMapper,
 	logger *logging.Logger,
 ) *AuthController {
 	return &AuthController{
 		authService:      authService,
+		authentication:   authentication,
+		signupCooldown:   options.SignupCooldown,
+		passwordLogin:    options.PasswordLogin,
+		signUp:           options.SignUp,
+		clearOnFailure:   options.ClearCookiesOnRefreshFailure,
+		includeRoles:     options.IncludeRoles,
 		requestValidator: requestValidator,
+		errorMapper:      errorMapper,
 		logger:           logger,
 	}
 }
 
-func (ac *AuthController) Register(router *gin.Engine) {
-	router.POST("/auth/signup", middleware.Validator[dto.UserCredentialsDTO](ac.requestValidator), ac.SignUp())
-	router.POST("/auth/signin", middleware.Validator[dto.UserCredentialsDTO](ac.requestValidator), ac.SignIn())
+func (ac *AuthController) Register(router gin.IRouter) {
 	router.POST("/auth/refresh", ac.Refresh())
+	router.POST("/auth/revoke", ac.authentication, middleware.Validator[dto.RevokeTokenDTO](ac.requestValidator, ac.errorMapper), ac.Revoke())
+
+	if !ac.passwordLogin {
+		return
+	}
+
+	if ac.signUp {
+		router.POST("/auth/signup", middleware.Validator[dto.UserCredentialsDTO](ac.requestValidator, ac.errorMapper), ac.SignUp())
+	}
+
+	router.POST("/auth/signin", middleware.Validator[dto.UserCredentialsDTO](ac.requestValidator, ac.errorMapper), ac.SignIn())
+	router.POST("/auth/reauthenticate", ac.authentication, middleware.Validator[dto.ReauthenticateDTO](ac.requestValidator, ac.errorMapper), ac.Reauthenticate())
+}
+
+// Operations describes the routes Register adds, for the OpenAPI document.
+func (ac *AuthController) Operations() []openapi.Operation {
+	tokenErrors := []string{customErr.CodeInvalidToken, customErr.CodeExpiredToken, customErr.CodeWrongTokenType, customErr.CodeUserNotFound}
+
+	operations := []openapi.Operation{
+		{
+			Method:      http.MethodPost,
+			Path:        "/auth/refresh",
+			Id:          "refresh",
+			Summary:     "Rotate the token pair using the refresh token cookie",
+			Tag:         "auth",
+			Security:    []string{openapi.SchemeRefreshCookie},
+			SetsCookies: true,
+			Errors:      append(tokenErrors, customErr.CodeTokenBinding, customErr.CodeInternalServerError),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/auth/revoke",
+			Id:      "revoke",
+			Summary: "Revoke an access or refresh token issued to the signed-in user",
+			Description: "Revocations are kept in the memory of the instance that accepted them. They are not shared " +
+				"with other instances and are lost on restart, so a revoked token stays usable there until it expires.",
+			Tag:      "auth",
+			Security: []string{openapi.SchemeAccessCookie},
+			Request:  dto.RevokeTokenDTO{},
+			Response: openapi.MessageResponse{},
+			Errors:   append(tokenErrors, customErr.CodeInvalidRequest, customErr.CodeInternalServerError),
+		},
+	}
+
+	if !ac.passwordLogin {
+		return operations
+	}
+
+	signupErrors := []string{customErr.CodeInvalidRequest, customErr.CodeAlreadyExists, customErr.CodeDisallowedEmailDomain, customErr.CodeDisposableEmail, customErr.CodeWeakPassword, customErr.CodeInternalServerError}
+	if ac.signupCooldown {
+		signupErrors = append(signupErrors, customErr.CodeSignupCooldown)
+	}
+
+	if ac.signUp {
+		operations = append(operations, openapi.Operation{
+			Method:  http.MethodPost,
+			Path:    "/auth/signup",
+			Id:      "signUp",
+			Summary: "Create an account with email and password",
+			Tag:     "auth",
+			Request: dto.UserCredentialsDTO{},
+			Errors:  signupErrors,
+		})
+	}
+
+	return append(operations,
+		openapi.Operation{
+			Method:      http.MethodPost,
+			Path:        "/auth/signin",
+			Id:          "signIn",
+			Summary:     "Sign in with email and password",
+			Tag:         "auth",
+			Request:     dto.UserCredentialsDTO{},
+			SetsCookies: true,
+			Errors:      []string{customErr.CodeInvalidRequest, customErr.CodeInvalidCredentials, customErr.CodeAccountPending, customErr.CodeAccountRejected, customErr.CodeInternalServerError},
+		},
+		openapi.Operation{
+			Method:   http.MethodPost,
+			Path:     "/auth/reauthenticate",
+			Id:       "reauthenticate",
+			Summary:  "Confirm the password and receive a short-lived confirmation token",
+			Tag:      "auth",
+			Security: []string{openapi.SchemeAccessCookie},
+			Request:  dto.ReauthenticateDTO{},
+			Response: dto.ConfirmationTokenDTO{},
+			Errors:   append(tokenErrors, customErr.CodeInvalidRequest, customErr.CodeInvalidCredentials, customErr.CodeInternalServerError),
+		},
+	)
 }
 
 func (ac *AuthController) SignUp() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
 		userCredentialsDTO := c.MustGet("validatedBody").(dto.UserCredentialsDTO)
 
 		_, err := ac.authService.SignUp(ctx, &userCredentialsDTO)
 		if err != nil {
-			var alreadyExistsErr *customErr.AlreadyExistsError
-
-			if errors.As(err, &alreadyExistsErr) {
-				c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
-			} else {
-				ac.logger.Error("Error while authorizing: ", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
-			}
-
+			ac.errorMapper.RespondError(c, err)
 			return
 		}
 
@@ -70,42 +178,39 @@ func (ac *AuthController) SignUp() gin.HandlerFunc {
 
 func (ac *AuthController) SignIn() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
 		userCredentialsDTO := c.MustGet("validatedBody").(dto.UserCredentialsDTO)
 
 		userTokensDTO, err := ac.authService.SignIn(ctx, &userCredentialsDTO)
 		if err != nil {
-			var invalidCredentialsErr *customErr.InvalidCredentialsError
-
-			if errors.As(err, &invalidCredentialsErr) {
-				c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
-			} else {
-				ac.logger.Error("Error while registering: ", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
-			}
-
+			ac.errorMapper.RespondError(c, err)
 			return
 		}
 
-		request.SetCookies(c, []schema.Cookie{
-			{Name: "access_token", Value: userTokensDTO.AccessToken, Duration: 7 * 24 * time.Hour},
-			{Name: "refresh_token", Value: userTokensDTO.RefreshToken, Duration: 7 * 24 * time.Hour},
-		})
+		setTokenCookies(c, userTokensDTO)
 
-		c.JSON(http.StatusOK, gin.H{"message": "Logged in successfully"})
+		response := gin.H{"message": "Logged in successfully"}
+		if ac.includeRoles {
+			response["roles"] = append([]string{}, userTokensDTO.Roles...)
+		}
+
+		c.JSON(http.StatusOK, response)
 	}
 }
 
 func (ac *AuthController) Refresh() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var ctx, cancel = context.WithTimeout(context.Background(), 100*time.Second)
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
 		refreshToken, err := c.Cookie("refresh_token")
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+			if ac.clearOnFailure {
+				clearTokenCookies(c)
+			}
+			ac.errorMapper.RespondError(c, customErr.NewInvalidTokenError("Invalid refresh token", ""))
 			return
 		}
 
@@ -113,25 +218,70 @@ func (ac *AuthController) Refresh() gin.HandlerFunc {
 
 		userTokensDTO, err := ac.authService.Refresh(ctx, refreshTokenDTO)
 		if err != nil {
-			var invalidTokenError *customErr.InvalidTokenError
-			var expiredTokenError *customErr.ExpiredTokenError
-			var userNotFoundError *customErr.UserNotFoundError
-
-			if errors.As(err, &invalidTokenError) || errors.As(err, &expiredTokenError) || errors.As(err, &userNotFoundError) {
-				c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
-			} else {
-				ac.logger.Error("Error while refreshing: ", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			if ac.clearOnFailure && isTerminalRefreshError(err) {
+				clearTokenCookies(c)
 			}
-
+			ac.errorMapper.RespondError(c, err)
 			return
 		}
 
-		request.SetCookies(c, []schema.Cookie{
-			{Name: "access_token", Value: userTokensDTO.AccessToken, Duration: 7 * 24 * time.Hour},
-			{Name: "refresh_token", Value: userTokensDTO.RefreshToken, Duration: 7 * 24 * time.Hour},
-		})
+		setTokenCookies(c, userTokensDTO)
 
 		c.JSON(http.StatusOK, gin.H{"message": "Tokens updated successfully"})
 	}
 }
+
+func (ac *AuthController) Reauthenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		reauthenticateDTO := c.MustGet("validatedBody").(dto.ReauthenticateDTO)
+
+		confirmationTokenDTO, err := ac.authService.Reauthenticate(ctx, c.GetString("id"), &reauthenticateDTO)
+		if err != nil {
+			ac.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, confirmationTokenDTO)
+	}
+}
+
+func (ac *AuthController) Revoke() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		revokeTokenDTO := c.MustGet("validatedBody").(dto.RevokeTokenDTO)
+
+		err := ac.authService.Revoke(ctx, c.GetString("id"), &revokeTokenDTO)
+		if err != nil {
+			ac.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Token revoked"})
+	}
+}
+
+func setTokenCookies(c *gin.Context, userTokensDTO *dto.UserTokensDTO) {
+	request.SetCookies(c, []schema.Cookie{
+		{Name: "access_token", Value: userTokensDTO.AccessToken, Duration: 7 * 24 * time.Hour},
+		{Name: "refresh_token", Value: userTokensDTO.RefreshToken, Duration: 7 * 24 * time.Hour},
+	})
+}
+
+func clearTokenCookies(c *gin.Context) {
+	request.SetCookies(c, []schema.Cookie{
+		{Name: "access_token", Value: "", Duration: -time.Hour},
+		{Name: "refresh_token", Value: "", Duration: -time.Hour},
+	})
+}
+
+func isTerminalRefreshError(err error) bool {
+	return errors.Is(err, customErr.ErrInvalidToken) ||
+		errors.Is(err, customErr.ErrExpiredToken) ||
+		errors.Is(err, customErr.ErrWrongTokenType) ||
+		errors.Is(err, customErr.ErrUserNotFound)
+}
@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/app/controller/http/dto"
+	customErr "jwtgo/internal/app/error"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/i18n"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+// refreshStub fails every refresh with err.
+type refreshStub struct {
+	serviceInterface.AuthService
+	err error
+}
+
+func (s refreshStub) Refresh(context.Context, *dto.UserRefreshTokenDTO) (*dto.UserTokensDTO, error) {
+	return nil, s.err
+}
+
+func newTestErrorMapper(t *testing.T) *request.ErrorMapper {
+	t.Helper()
+
+	logger := logging.GetLogger("panic")
+	catalog, err := i18n.NewCatalog(&logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return request.NewErrorMapper(catalog, customErr.Status, &logger)
+}
+
+func newRefreshRouter(t *testing.T, err error, clearOnFailure bool) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	logger := logging.GetLogger("panic")
+
+	controller := NewAuthController(refreshStub{err: err}, func(c *gin.Context) { c.Next() }, AuthControllerOptions{
+		PasswordLogin:                true,
+		SignUp:                       true,
+		ClearCookiesOnRefreshFailure: clearOnFailure,
+	}, nil, newTestErrorMapper(t), &logger)
+
+	router := gin.New()
+	router.POST("/auth/refresh", controller.Refresh())
+
+	return router
+}
+
+// clearedCookies returns the names of the cookies the response expires.
+func clearedCookies(recorder *httptest.ResponseRecorder) []string {
+	var names []string
+	for _, cookie := range recorder.Result().Cookies() {
+		if cookie.MaxAge < 0 || (!cookie.Expires.IsZero() && cookie.Expires.Before(time.Now())) {
+			names = append(names, cookie.Name)
+		}
+	}
+	return names
+}
+
+func TestRefreshFailureClearsCookies(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		cleared bool
+	}{
+		{"invalid token", customErr.NewInvalidTokenError("Invalid refresh token", "token-1"), true},
+		{"expired token", customErr.NewExpiredTokenError("Token is expired", "token-1"), true},
+		{"wrong token type", customErr.NewWrongTokenTypeError("Wrong token type", "token-1", "refresh", "access"), true},
+		{"deleted user", customErr.NewUserNotFoundError("User not found", "user-1"), true},
+		{"repository failure", customErr.NewInternalServerError("Failed to load user", errors.New("connection reset")), false},
+		{"overloaded", customErr.NewServerOverloadedError("Too many requests", time.Second), false},
+		{"deadline", context.DeadlineExceeded, false},
+		{"token binding", customErr.NewTokenBindingError("Token issued to a different network", "token-1"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, clearOnFailure := range []bool{true, false} {
+				req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+				req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh"})
+				recorder := httptest.NewRecorder()
+				newRefreshRouter(t, tt.err, clearOnFailure).ServeHTTP(recorder, req)
+
+				want := 0
+				if clearOnFailure && tt.cleared {
+					want = 2
+				}
+				if cleared := clearedCookies(recorder); len(cleared) != want {
+					t.Errorf("clearing enabled %t: cleared %v, want %d cookies cleared", clearOnFailure, cleared, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshWithoutCookieClearsCookies(t *testing.T) {
+	for _, clearOnFailure := range []bool{true, false} {
+		recorder := httptest.NewRecorder()
+		newRefreshRouter(t, nil, clearOnFailure).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/auth/refresh", nil))
+
+		if recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", recorder.Code, http.StatusUnauthorized)
+		}
+		if cleared := clearedCookies(recorder); (len(cleared) == 2) != clearOnFailure {
+			t.Errorf("clearing enabled %t: cleared %v", clearOnFailure, cleared)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Controller interface {
+	Register(router gin.IRouter)
+}
+
+func RegisterAll(router gin.IRouter, controllers ...Controller) {
+	for _, controller := range controllers {
+		controller.Register(router)
+	}
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/request"
+)
+
+var tokenTimeClaims = []string{"exp", "iat", "nbf", "auth_time"}
+
+type TokenDebugController struct {
+	errorMapper *request.ErrorMapper
+}
+
+func NewTokenDebugController(errorMapper *request.ErrorMapper) *TokenDebugController {
+	return &TokenDebugController{
+		errorMapper: errorMapper,
+	}
+}
+
+// Register only adds the route while gin runs in debug mode, so the endpoint
+// cannot be exposed by a release build regardless of configuration.
+func (tc *TokenDebugController) Register(router gin.IRouter) {
+	if !gin.IsDebugging() {
+		return
+	}
+
+	router.GET("/debug/token", tc.Inspect())
+}
+
+// Inspect decodes the access token cookie, or the refresh token cookie when
+// type=refresh, without verifying its signature.
+func (tc *TokenDebugController) Inspect() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cookieName := "access_token"
+		if c.Query("type") == "refresh" {
+			cookieName = "refresh_token"
+		}
+
+		rawToken, err := c.Cookie(cookieName)
+		if err != nil {
+			tc.errorMapper.RespondError(c, customErr.NewInvalidTokenError("Token cookie is missing", ""))
+			return
+		}
+
+		claims := jwt.MapClaims{}
+		token, _, err := jwt.NewParser().ParseUnverified(rawToken, claims)
+		if err != nil {
+			tc.errorMapper.RespondError(c, customErr.NewInvalidTokenError("Token cannot be decoded", ""))
+			return
+		}
+
+		times := gin.H{}
+		for _, name := range tokenTimeClaims {
+			if seconds, ok := claims[name].(float64); ok {
+				times[name] = time.Unix(int64(seconds), 0).UTC().Format(time.RFC3339)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"cookie":   cookieName,
+			"header":   token.Header,
+			"claims":   claims,
+			"times":    times,
+			"verified": false,
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestTokenDebugRouteOnlyInDebugMode(t *testing.T) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-1", "token_type": "access"}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writer := gin.DefaultWriter
+	gin.DefaultWriter = io.Discard
+	t.Cleanup(func() {
+		gin.DefaultWriter = writer
+		gin.SetMode(gin.TestMode)
+	})
+
+	tests := []struct {
+		mode   string
+		status int
+	}{
+		{gin.ReleaseMode, http.StatusNotFound},
+		{gin.TestMode, http.StatusNotFound},
+		{gin.DebugMode, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			gin.SetMode(tt.mode)
+			router := gin.New()
+			NewTokenDebugController(newTestErrorMapper(t)).Register(router)
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/token", nil)
+			req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status in %s mode = %d, want %d", tt.mode, recorder.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Claims   map[string]interface{} `json:"claims"`
+				Verified bool                   `json:"verified"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Claims["sub"] != "user-1" || body.Verified {
+				t.Fatalf("body = %s", recorder.Body)
+			}
+		})
+	}
+}
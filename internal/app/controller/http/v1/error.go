@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+)
+
+type ErrorController struct {
+	definitions []customErr.Definition
+}
+
+func NewErrorController(definitions []customErr.Definition) *ErrorController {
+	return &ErrorController{
+		definitions: definitions,
+	}
+}
+
+func (ec *ErrorController) Register(router gin.IRouter) {
+	router.GET("/api/v1/errors", ec.List())
+}
+
+func (ec *ErrorController) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"errors": ec.definitions})
+	}
+}
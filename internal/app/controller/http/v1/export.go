@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+type ExportController struct {
+	exportService  serviceInterface.ExportService
+	authentication gin.HandlerFunc
+	errorMapper    *request.ErrorMapper
+	logger         *logging.Logger
+}
+
+func NewExportController(
+	exportService serviceInterface.ExportService,
+	authentication gin.HandlerFunc,
+	errorMapper *request.ErrorMapper,
+	logger *logging.Logger,
+) *ExportController {
+	return &ExportController{
+		exportService:  exportService,
+		authentication: authentication,
+		errorMapper:    errorMapper,
+		logger:         logger,
+	}
+}
+
+func (ec *ExportController) Register(router gin.IRouter) {
+	router.GET("/auth/me/export", ec.authentication, ec.Export())
+}
+
+func (ec *ExportController) Export() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		userExportDTO, err := ec.exportService.Export(ctx, c.GetString("id"))
+		if err != nil {
+			ec.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.Header("Cache-Control", "no-store")
+		c.Header("Content-Disposition", `attachment; filename="jwtgo-export.json"`)
+		c.JSON(http.StatusOK, userExportDTO)
+	}
+}
@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/request"
+)
+
+type FallbackController struct {
+	errorMapper *request.ErrorMapper
+}
+
+func NewFallbackController(errorMapper *request.ErrorMapper) *FallbackController {
+	return &FallbackController{
+		errorMapper: errorMapper,
+	}
+}
+
+func (fc *FallbackController) Register(router *gin.Engine) {
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(fc.NotFound())
+	router.NoMethod(fc.MethodNotAllowed())
+}
+
+func (fc *FallbackController) NotFound() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fc.errorMapper.RespondError(c, customErr.NewNotFoundError("Route not found"))
+	}
+}
+
+func (fc *FallbackController) MethodNotAllowed() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fc.errorMapper.RespondError(c, customErr.NewMethodNotAllowedError("Method not allowed"))
+	}
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/pkg/health"
+)
+
+type HealthController struct {
+	checker *health.Checker
+}
+
+func NewHealthController(checker *health.Checker) *HealthController {
+	return &HealthController{
+		checker: checker,
+	}
+}
+
+func (hc *HealthController) Register(router gin.IRouter) {
+	router.GET("/healthz", hc.Liveness())
+	router.GET("/readyz", hc.Readiness())
+}
+
+func (hc *HealthController) Liveness() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": health.StatusOk})
+	}
+}
+
+func (hc *HealthController) Readiness() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := hc.checker.Check(c.Request.Context())
+		if !report.Ready() {
+			c.JSON(http.StatusServiceUnavailable, report)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+type IdentityController struct {
+	identityService serviceInterface.IdentityService
+	authentication  gin.HandlerFunc
+	errorMapper     *request.ErrorMapper
+	logger          *logging.Logger
+}
+
+func NewIdentityController(
+	identityService serviceInterface.IdentityService,
+	authentication gin.HandlerFunc,
+	errorMapper *request.ErrorMapper,
+	logger *logging.Logger,
+) *IdentityController {
+	return &IdentityController{
+		identityService: identityService,
+		authentication:  authentication,
+		errorMapper:     errorMapper,
+		logger:          logger,
+	}
+}
+
+func (ic *IdentityController) Register(router gin.IRouter) {
+	router.GET("/auth/identities", ic.authentication, ic.List())
+	router.DELETE("/auth/identities/:provider", ic.authentication, ic.Unlink())
+}
+
+func (ic *IdentityController) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		identityDTOs, err := ic.identityService.List(ctx, c.GetString("id"))
+		if err != nil {
+			ic.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"identities": identityDTOs})
+	}
+}
+
+func (ic *IdentityController) Unlink() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		err := ic.identityService.Unlink(ctx, c.GetString("id"), c.Param("provider"))
+		if err != nil {
+			ic.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Identity successfully unlinked"})
+	}
+}
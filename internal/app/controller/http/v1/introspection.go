@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/app/schema"
+)
+
+type IntrospectionController struct {
+	jwtService serviceInterface.JWTService
+	apiKey     gin.HandlerFunc
+}
+
+func NewIntrospectionController(jwtService serviceInterface.JWTService, apiKey gin.HandlerFunc) *IntrospectionController {
+	return &IntrospectionController{
+		jwtService: jwtService,
+		apiKey:     apiKey,
+	}
+}
+
+func (ic *IntrospectionController) Register(router gin.IRouter) {
+	router.POST("/oauth/introspect", ic.apiKey, ic.Introspect())
+}
+
+// Introspect follows RFC 7662: any token that is not a valid access token is
+// reported as inactive rather than as an error.
+func (ic *IntrospectionController) Introspect() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+
+		claims, err := ic.jwtService.ValidateToken(c.PostForm("token"), schema.TokenTypeAccess)
+		if err != nil {
+			c.JSON(http.StatusOK, gin.H{"active": false})
+			return
+		}
+
+		response := gin.H{
+			"active":     true,
+			"sub":        claims.Id,
+			"token_type": claims.TokenType,
+		}
+		if claims.Email != "" {
+			response["email"] = claims.Email
+		}
+		if len(claims.Roles) > 0 {
+			response["roles"] = claims.Roles
+		}
+		if claims.ExpiresAt != nil {
+			response["exp"] = claims.ExpiresAt.Unix()
+		}
+		if claims.IssuedAt != nil {
+			response["iat"] = claims.IssuedAt.Unix()
+		}
+		if claims.AuthTime != nil {
+			response["auth_time"] = claims.AuthTime.Unix()
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
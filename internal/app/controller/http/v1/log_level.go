@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/controller/http/middleware"
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+type LogLevelController struct {
+	logger           *logging.Logger
+	apiKey           gin.HandlerFunc
+	requestValidator *validator.Validate
+	errorMapper      *request.ErrorMapper
+}
+
+func NewLogLevelController(
+	logger *logging.Logger,
+	apiKey gin.HandlerFunc,
+	requestValidator *validator.Validate,
+	errorMapper *request.ErrorMapper,
+) *LogLevelController {
+	return &LogLevelController{
+		logger:           logger,
+		apiKey:           apiKey,
+		requestValidator: requestValidator,
+		errorMapper:      errorMapper,
+	}
+}
+
+func (lc *LogLevelController) Register(router gin.IRouter) {
+	router.PUT("/admin/log-level", lc.apiKey, middleware.Validator[dto.LogLevelDTO](lc.requestValidator, lc.errorMapper), lc.SetLevel())
+}
+
+// SetLevel changes the log level for good, or only for the duration when one
+// is given, after which the previous level comes back.
+func (lc *LogLevelController) SetLevel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logLevelDTO := c.MustGet("validatedBody").(dto.LogLevelDTO)
+
+		if logLevelDTO.Duration == "" {
+			if err := lc.logger.SetLevel(logLevelDTO.Level); err != nil {
+				lc.errorMapper.RespondError(c, customErr.NewInvalidRequestError(err.Error()))
+				return
+			}
+
+			lc.logger.Warn("Log level set to ", logLevelDTO.Level)
+			c.JSON(http.StatusOK, gin.H{"level": logLevelDTO.Level})
+			return
+		}
+
+		duration, err := time.ParseDuration(logLevelDTO.Duration)
+		if err != nil || duration <= 0 {
+			lc.errorMapper.RespondError(c, customErr.NewInvalidRequestError("Duration must be a positive duration such as 15m"))
+			return
+		}
+
+		if err := lc.logger.RaiseLevel(logLevelDTO.Level, duration); err != nil {
+			lc.errorMapper.RespondError(c, customErr.NewInvalidRequestError(err.Error()))
+			return
+		}
+
+		revertsAt := time.Now().Add(duration)
+		lc.logger.Warnf("Log level set to %s until %s", logLevelDTO.Level, revertsAt.Format(time.RFC3339))
+		c.JSON(http.StatusOK, gin.H{"level": logLevelDTO.Level, "reverts_at": revertsAt})
+	}
+}
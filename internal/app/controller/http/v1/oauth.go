@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/internal/pkg/request/schema"
+	"jwtgo/pkg/logging"
+)
+
+const oauthStateCookie = "oauth_state"
+
+type OAuthController struct {
+	oauthService serviceInterface.OAuthService
+	stateTTL     time.Duration
+	errorMapper  *request.ErrorMapper
+	logger       *logging.Logger
+}
+
+func NewOAuthController(
+	oauthService serviceInterface.OAuthService,
+	stateTTL time.Duration,
+	errorMapper *request.ErrorMapper,
+	logger *logging.Logger,
+) *OAuthController {
+	return &OAuthController{
+		oauthService: oauthService,
+		stateTTL:     stateTTL,
+		errorMapper:  errorMapper,
+		logger:       logger,
+	}
+}
+
+func (oc *OAuthController) Register(router gin.IRouter) {
+	router.GET("/auth/oauth/:provider/login", oc.Login())
+	router.GET("/auth/oauth/:provider/callback", oc.Callback())
+}
+
+func (oc *OAuthController) Login() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		url, stateValue, err := oc.oauthService.LoginURL(c.Param("provider"))
+		if err != nil {
+			oc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		request.SetCookies(c, []schema.Cookie{
+			{Name: oauthStateCookie, Value: stateValue, Duration: oc.stateTTL, SameSite: http.SameSiteLaxMode},
+		})
+		c.Redirect(http.StatusFound, url)
+	}
+}
+
+func (oc *OAuthController) Callback() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		provider := c.Param("provider")
+
+		stateValue := c.Query("state")
+		stateCookie, err := c.Cookie(oauthStateCookie)
+		if err != nil || stateValue == "" || stateCookie != stateValue {
+			oc.errorMapper.RespondError(c, customErr.NewInvalidOAuthStateError("OAuth state does not match"))
+			return
+		}
+
+		request.SetCookies(c, []schema.Cookie{
+			{Name: oauthStateCookie, Value: "", Duration: -time.Hour, SameSite: http.SameSiteLaxMode},
+		})
+
+		if providerError := c.Query("error"); providerError != "" {
+			oc.errorMapper.RespondError(c, customErr.NewOAuthProviderError("Provider denied the request: "+providerError, provider, nil))
+			return
+		}
+
+		userTokensDTO, err := oc.oauthService.Callback(ctx, provider, c.Query("code"), stateValue)
+		if err != nil {
+			oc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		setTokenCookies(c, userTokensDTO)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Logged in successfully"})
+	}
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/pkg/openapi"
+)
+
+type OpenAPIController struct {
+	document  *openapi.Document
+	swaggerUI bool
+}
+
+func NewOpenAPIController(document *openapi.Document, swaggerUI bool) *OpenAPIController {
+	return &OpenAPIController{
+		document:  document,
+		swaggerUI: swaggerUI,
+	}
+}
+
+func (oc *OpenAPIController) Register(router gin.IRouter) {
+	router.GET("/api/v1/openapi.json", oc.Document())
+
+	if oc.swaggerUI {
+		router.GET("/api/v1/docs", oc.SwaggerUI())
+		router.StaticFS("/api/v1/docs/assets", openapi.SwaggerUIAssets())
+	}
+}
+
+func (oc *OpenAPIController) Document() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, oc.document)
+	}
+}
+
+func (oc *OpenAPIController) SwaggerUI() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.SwaggerUIPage())
+	}
+}
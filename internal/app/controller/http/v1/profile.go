@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/request"
+)
+
+type ProfileController struct {
+	profileService serviceInterface.ProfileService
+	authentication gin.HandlerFunc
+	includeRoles   bool
+	errorMapper    *request.ErrorMapper
+}
+
+func NewProfileController(
+	profileService serviceInterface.ProfileService,
+	authentication gin.HandlerFunc,
+	includeRoles bool,
+	errorMapper *request.ErrorMapper,
+) *ProfileController {
+	return &ProfileController{
+		profileService: profileService,
+		authentication: authentication,
+		includeRoles:   includeRoles,
+		errorMapper:    errorMapper,
+	}
+}
+
+func (pc *ProfileController) Register(router gin.IRouter) {
+	router.GET("/auth/me", pc.authentication, pc.Get())
+}
+
+func (pc *ProfileController) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		profileDTO, err := pc.profileService.Get(ctx, c.GetString("id"))
+		if err != nil {
+			pc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		if !pc.includeRoles {
+			profileDTO.Roles = nil
+		}
+
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, profileDTO)
+	}
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/request"
+)
+
+const defaultSecurityEventLimit = 20
+
+type SecurityEventController struct {
+	securityEventService serviceInterface.SecurityEventService
+	authentication       gin.HandlerFunc
+	errorMapper          *request.ErrorMapper
+}
+
+func NewSecurityEventController(
+	securityEventService serviceInterface.SecurityEventService,
+	authentication gin.HandlerFunc,
+	errorMapper *request.ErrorMapper,
+) *SecurityEventController {
+	return &SecurityEventController{
+		securityEventService: securityEventService,
+		authentication:       authentication,
+		errorMapper:          errorMapper,
+	}
+}
+
+func (sc *SecurityEventController) Register(router gin.IRouter) {
+	router.GET("/auth/me/security-events", sc.authentication, sc.List())
+}
+
+func (sc *SecurityEventController) List() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSecurityEventLimit)))
+		if err != nil || limit <= 0 {
+			sc.errorMapper.RespondError(c, customErr.NewInvalidRequestError("Invalid limit"))
+			return
+		}
+
+		securityEventDTOs, err := sc.securityEventService.List(ctx, c.GetString("id"), limit)
+		if err != nil {
+			sc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, gin.H{"events": securityEventDTOs})
+	}
+}
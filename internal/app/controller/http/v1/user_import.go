@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/controller/http/middleware"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+type UserImportController struct {
+	userImportService serviceInterface.UserImportService
+	apiKey            gin.HandlerFunc
+	requestValidator  *validator.Validate
+	errorMapper       *request.ErrorMapper
+	logger            *logging.Logger
+}
+
+func NewUserImportController(
+	userImportService serviceInterface.UserImportService,
+	apiKey gin.HandlerFunc,
+	requestValidator *validator.Validate,
+	errorMapper *request.ErrorMapper,
+	logger *logging.Logger,
+) *UserImportController {
+	return &UserImportController{
+		userImportService: userImportService,
+		apiKey:            apiKey,
+		requestValidator:  requestValidator,
+		errorMapper:       errorMapper,
+		logger:            logger,
+	}
+}
+
+func (uc *UserImportController) Register(router gin.IRouter) {
+	router.POST("/admin/users/import", uc.apiKey, middleware.Validator[dto.ImportUsersDTO](uc.requestValidator, uc.errorMapper), uc.Import())
+	router.GET("/admin/users/export", uc.apiKey, uc.Export())
+}
+
+func (uc *UserImportController) Import() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		importUsersDTO := c.MustGet("validatedBody").(dto.ImportUsersDTO)
+
+		importResultDTOs, err := uc.userImportService.Import(ctx, &importUsersDTO)
+		if err != nil {
+			uc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"results": importResultDTOs})
+	}
+}
+
+// Export writes one JSON object per line (NDJSON), so large exports can be
+// processed line by line.
+func (uc *UserImportController) Export() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		exportedUserDTOs, err := uc.userImportService.Export(ctx)
+		if err != nil {
+			uc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.Header("Cache-Control", "no-store")
+		c.Header("Content-Disposition", `attachment; filename="jwtgo-users.ndjson"`)
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+
+		encoder := json.NewEncoder(c.Writer)
+		for i := range exportedUserDTOs {
+			if err := encoder.Encode(&exportedUserDTOs[i]); err != nil {
+				uc.logger.ForContext(ctx).Warn("Failed to write user export: ", err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	customErr "jwtgo/internal/app/error"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/internal/pkg/verification"
+)
+
+const maxCallbackSize = 64 << 10
+
+type VerificationController struct {
+	verificationService serviceInterface.VerificationService
+	callbackVerifier    *verification.CallbackVerifier
+	errorMapper         *request.ErrorMapper
+}
+
+func NewVerificationController(
+	verificationService serviceInterface.VerificationService,
+	callbackVerifier *verification.CallbackVerifier,
+	errorMapper *request.ErrorMapper,
+) *VerificationController {
+	return &VerificationController{
+		verificationService: verificationService,
+		callbackVerifier:    callbackVerifier,
+		errorMapper:         errorMapper,
+	}
+}
+
+func (vc *VerificationController) Register(router gin.IRouter) {
+	router.POST("/auth/verification/callback", vc.Callback())
+}
+
+func (vc *VerificationController) Callback() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxCallbackSize))
+		if err != nil {
+			vc.errorMapper.RespondError(c, customErr.NewInvalidRequestError("Invalid verification callback"))
+			return
+		}
+
+		callback, err := vc.callbackVerifier.Verify(body, c.GetHeader(verification.SignatureHeader))
+		if err != nil {
+			vc.errorMapper.RespondError(c, customErr.NewInvalidCallbackError("Verification callback rejected", err))
+			return
+		}
+
+		err = vc.verificationService.Complete(ctx, callback.UserId, callback.Decision == verification.DecisionApproved)
+		if err != nil {
+			vc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Verification result recorded"})
+	}
+}
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/pkg/buildinfo"
+)
+
+type VersionController struct {
+	info buildinfo.Info
+}
+
+func NewVersionController(info buildinfo.Info) *VersionController {
+	return &VersionController{
+		info: info,
+	}
+}
+
+func (vc *VersionController) Register(router gin.IRouter) {
+	router.GET("/version", vc.Get())
+}
+
+func (vc *VersionController) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, vc.info)
+	}
+}
@@ -0,0 +1,148 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-webauthn/webauthn/protocol"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/controller/http/middleware"
+	customErr "jwtgo/internal/app/error"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/internal/pkg/request/schema"
+	"jwtgo/pkg/logging"
+)
+
+const webAuthnSessionCookie = "webauthn_session"
+
+type WebAuthnController struct {
+	webAuthnService  serviceInterface.WebAuthnService
+	authentication   gin.HandlerFunc
+	requestValidator *validator.Validate
+	errorMapper      *request.ErrorMapper
+	logger           *logging.Logger
+}
+
+func NewWebAuthnController(
+	webAuthnService serviceInterface.WebAuthnService,
+	authentication gin.HandlerFunc,
+	requestValidator *validator.Validate,
+	errorMapper *request.ErrorMapper,
+	logger *logging.Logger,
+) *WebAuthnController {
+	return &WebAuthnController{
+		webAuthnService:  webAuthnService,
+		authentication:   authentication,
+		requestValidator: requestValidator,
+		errorMapper:      errorMapper,
+		logger:           logger,
+	}
+}
+
+func (wc *WebAuthnController) Register(router gin.IRouter) {
+	router.POST("/auth/webauthn/register/begin", wc.authentication, wc.BeginRegistration())
+	router.POST("/auth/webauthn/register/finish", wc.authentication, wc.FinishRegistration())
+	router.POST("/auth/webauthn/login/begin", middleware.Validator[dto.WebAuthnLoginDTO](wc.requestValidator, wc.errorMapper), wc.BeginLogin())
+	router.POST("/auth/webauthn/login/finish", wc.FinishLogin())
+}
+
+func (wc *WebAuthnController) BeginRegistration() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		creation, sessionId, err := wc.webAuthnService.BeginRegistration(ctx, c.GetString("id"))
+		if err != nil {
+			wc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		setWebAuthnSessionCookie(c, sessionId)
+		c.JSON(http.StatusOK, creation)
+	}
+}
+
+func (wc *WebAuthnController) FinishRegistration() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		sessionId, err := c.Cookie(webAuthnSessionCookie)
+		if err != nil {
+			wc.errorMapper.RespondError(c, customErr.NewInvalidRequestError("Passkey registration session is missing"))
+			return
+		}
+
+		response, err := protocol.ParseCredentialCreationResponseBody(c.Request.Body)
+		if err != nil {
+			wc.errorMapper.RespondError(c, customErr.NewInvalidRequestError("Invalid passkey registration response"))
+			return
+		}
+
+		_, err = wc.webAuthnService.FinishRegistration(ctx, c.GetString("id"), sessionId, response)
+		if err != nil {
+			wc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Passkey successfully registered"})
+	}
+}
+
+func (wc *WebAuthnController) BeginLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		webAuthnLoginDTO := c.MustGet("validatedBody").(dto.WebAuthnLoginDTO)
+
+		assertion, sessionId, err := wc.webAuthnService.BeginLogin(ctx, &webAuthnLoginDTO)
+		if err != nil {
+			wc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		setWebAuthnSessionCookie(c, sessionId)
+		c.JSON(http.StatusOK, assertion)
+	}
+}
+
+func (wc *WebAuthnController) FinishLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctx, cancel = context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		sessionId, err := c.Cookie(webAuthnSessionCookie)
+		if err != nil {
+			wc.errorMapper.RespondError(c, customErr.NewInvalidRequestError("Passkey login session is missing"))
+			return
+		}
+
+		response, err := protocol.ParseCredentialRequestResponseBody(c.Request.Body)
+		if err != nil {
+			wc.errorMapper.RespondError(c, customErr.NewInvalidRequestError("Invalid passkey login response"))
+			return
+		}
+
+		userTokensDTO, err := wc.webAuthnService.FinishLogin(ctx, sessionId, response)
+		if err != nil {
+			wc.errorMapper.RespondError(c, err)
+			return
+		}
+
+		setTokenCookies(c, userTokensDTO)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Logged in successfully"})
+	}
+}
+
+func setWebAuthnSessionCookie(c *gin.Context, sessionId string) {
+	request.SetCookies(c, []schema.Cookie{
+		{Name: webAuthnSessionCookie, Value: sessionId, Duration: 5 * time.Minute},
+	})
+}
@@ -0,0 +1,7 @@
+package entity
+
+type OAuthUserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
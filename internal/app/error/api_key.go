@@ -0,0 +1,27 @@
+package error
+
+import (
+	"errors"
+)
+
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+type InvalidAPIKeyError struct {
+	message string
+}
+
+func NewInvalidAPIKeyError(message string) error {
+	return &InvalidAPIKeyError{message: message}
+}
+
+func (e *InvalidAPIKeyError) Error() string {
+	return e.message
+}
+
+func (e *InvalidAPIKeyError) Code() string {
+	return CodeInvalidAPIKey
+}
+
+func (e *InvalidAPIKeyError) Is(target error) bool {
+	return target == ErrInvalidAPIKey
+}
@@ -1,37 +1,173 @@
 package error
 
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrAlreadyExists         = errors.New("already exists")
+	ErrInvalidCredentials    = errors.New("invalid credentials")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrDisallowedEmailDomain = errors.New("disallowed email domain")
+	ErrDisposableEmail       = errors.New("disposable email")
+	ErrSignupCooldown        = errors.New("signup cooldown")
+	ErrAmbiguousIdentifier   = errors.New("ambiguous identifier")
+)
+
 type AlreadyExistsError struct {
 	message string
+	Email   string
 }
 
-func NewAlreadyExistsError(message string) error {
-	return &AlreadyExistsError{message: message}
+func NewAlreadyExistsError(message, email string) error {
+	return &AlreadyExistsError{message: message, Email: email}
 }
 
 func (e *AlreadyExistsError) Error() string {
 	return e.message
 }
 
+func (e *AlreadyExistsError) Code() string {
+	return CodeAlreadyExists
+}
+
+func (e *AlreadyExistsError) Is(target error) bool {
+	return target == ErrAlreadyExists
+}
+
 type InvalidCredentialsError struct {
 	message string
+	Email   string
 }
 
-func NewInvalidCredentialsError(message string) error {
-	return &InvalidCredentialsError{message: message}
+func NewInvalidCredentialsError(message, email string) error {
+	return &InvalidCredentialsError{message: message, Email: email}
 }
 
 func (e *InvalidCredentialsError) Error() string {
 	return e.message
 }
 
+func (e *InvalidCredentialsError) Code() string {
+	return CodeInvalidCredentials
+}
+
+func (e *InvalidCredentialsError) Is(target error) bool {
+	return target == ErrInvalidCredentials
+}
+
 type UserNotFoundError struct {
 	message string
+	UserId  string
 }
 
-func NewUserNotFoundError(message string) error {
-	return &UserNotFoundError{message: message}
+func NewUserNotFoundError(message, userId string) error {
+	return &UserNotFoundError{message: message, UserId: userId}
 }
 
 func (e *UserNotFoundError) Error() string {
 	return e.message
 }
+
+func (e *UserNotFoundError) Code() string {
+	return CodeUserNotFound
+}
+
+func (e *UserNotFoundError) Is(target error) bool {
+	return target == ErrUserNotFound
+}
+
+type DisallowedEmailDomainError struct {
+	message string
+	Email   string
+}
+
+func NewDisallowedEmailDomainError(message, email string) error {
+	return &DisallowedEmailDomainError{message: message, Email: email}
+}
+
+func (e *DisallowedEmailDomainError) Error() string {
+	return e.message
+}
+
+func (e *DisallowedEmailDomainError) Code() string {
+	return CodeDisallowedEmailDomain
+}
+
+func (e *DisallowedEmailDomainError) Is(target error) bool {
+	return target == ErrDisallowedEmailDomain
+}
+
+type DisposableEmailError struct {
+	message string
+	Email   string
+}
+
+func NewDisposableEmailError(message, email string) error {
+	return &DisposableEmailError{message: message, Email: email}
+}
+
+func (e *DisposableEmailError) Error() string {
+	return e.message
+}
+
+func (e *DisposableEmailError) Code() string {
+	return CodeDisposableEmail
+}
+
+func (e *DisposableEmailError) Is(target error) bool {
+	return target == ErrDisposableEmail
+}
+
+type SignupCooldownError struct {
+	message    string
+	retryAfter time.Duration
+}
+
+func NewSignupCooldownError(message string, retryAfter time.Duration) error {
+	return &SignupCooldownError{message: message, retryAfter: retryAfter}
+}
+
+func (e *SignupCooldownError) Error() string {
+	return e.message
+}
+
+func (e *SignupCooldownError) Code() string {
+	return CodeSignupCooldown
+}
+
+func (e *SignupCooldownError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+func (e *SignupCooldownError) Params() map[string]string {
+	return map[string]string{"minutes": strconv.Itoa(int(e.retryAfter.Minutes()) + 1)}
+}
+
+func (e *SignupCooldownError) Is(target error) bool {
+	return target == ErrSignupCooldown
+}
+
+type AmbiguousIdentifierError struct {
+	message    string
+	Identifier string
+	Kinds      []string
+}
+
+func NewAmbiguousIdentifierError(message, identifier string, kinds []string) error {
+	return &AmbiguousIdentifierError{message: message, Identifier: identifier, Kinds: kinds}
+}
+
+func (e *AmbiguousIdentifierError) Error() string {
+	return e.message
+}
+
+func (e *AmbiguousIdentifierError) Code() string {
+	return CodeAmbiguousIdentifier
+}
+
+func (e *AmbiguousIdentifierError) Is(target error) bool {
+	return target == ErrAmbiguousIdentifier
+}
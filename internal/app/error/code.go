@@ -0,0 +1,35 @@
+package error
+
+import (
+	"net/http"
+)
+
+var (
+	CodeAlreadyExists         = register("ALREADY_EXISTS", http.StatusConflict, "A user with this email already exists")
+	CodeInvalidCredentials    = register("INVALID_CREDENTIALS", http.StatusUnauthorized, "The login, password or passkey is incorrect")
+	CodeUserNotFound          = register("USER_NOT_FOUND", http.StatusUnauthorized, "The user referenced by the token no longer exists")
+	CodeInvalidToken          = register("INVALID_TOKEN", http.StatusUnauthorized, "The token is missing, malformed or has been superseded")
+	CodeExpiredToken          = register("EXPIRED_TOKEN", http.StatusUnauthorized, "The token has expired")
+	CodeWrongTokenType        = register("WRONG_TOKEN_TYPE", http.StatusUnauthorized, "The token is valid but of the wrong type for this endpoint")
+	CodeInternalServerError   = register("INTERNAL_SERVER_ERROR", http.StatusInternalServerError, "An unexpected server error occurred")
+	CodeInvalidRequest        = register("INVALID_REQUEST", http.StatusBadRequest, "The request parameters are invalid")
+	CodeDisallowedEmailDomain = register("DISALLOWED_EMAIL_DOMAIN", http.StatusForbidden, "Sign-up is not allowed for this email domain")
+	CodeDisposableEmail       = register("DISPOSABLE_EMAIL", http.StatusUnprocessableEntity, "Disposable email addresses cannot be used to sign up")
+	CodeInvalidOAuthState     = register("INVALID_OAUTH_STATE", http.StatusBadRequest, "The OAuth state parameter is missing, invalid or expired")
+	CodeOAuthProviderError    = register("OAUTH_PROVIDER_ERROR", http.StatusBadGateway, "The identity provider could not complete the sign-in")
+	CodeUnverifiedEmail       = register("UNVERIFIED_EMAIL", http.StatusForbidden, "The identity provider has not verified this email address")
+	CodeIdentityNotFound      = register("IDENTITY_NOT_FOUND", http.StatusNotFound, "No external identity from this provider is linked to the user")
+	CodeLastSignInMethod      = register("LAST_SIGN_IN_METHOD", http.StatusConflict, "The only remaining sign-in method of a user cannot be removed")
+	CodeSignupCooldown        = register("SIGNUP_COOLDOWN", http.StatusTooManyRequests, "Too many accounts were created from this address recently")
+	CodeNotFound              = register("NOT_FOUND", http.StatusNotFound, "No route matches the requested path")
+	CodeMethodNotAllowed      = register("METHOD_NOT_ALLOWED", http.StatusMethodNotAllowed, "The route does not accept this HTTP method")
+	CodeServerOverloaded      = register("SERVER_OVERLOADED", http.StatusServiceUnavailable, "The server is at its concurrent request limit")
+	CodeInvalidAPIKey         = register("INVALID_API_KEY", http.StatusUnauthorized, "The API key is missing or not recognised")
+	CodeTokenBinding          = register("TOKEN_BINDING", http.StatusUnauthorized, "The refresh token was issued to a different network")
+	CodeAccountPending        = register("ACCOUNT_PENDING_VERIFICATION", http.StatusForbidden, "The account is waiting for identity verification")
+	CodeAccountRejected       = register("ACCOUNT_REJECTED", http.StatusForbidden, "The account failed identity verification")
+	CodeInvalidCallback       = register("INVALID_VERIFICATION_CALLBACK", http.StatusUnauthorized, "The verification callback signature, timestamp or nonce is invalid")
+	CodeWeakPassword          = register("WEAK_PASSWORD", http.StatusUnprocessableEntity, "The password is too easy to guess")
+	CodeAmbiguousIdentifier   = register("AMBIGUOUS_IDENTIFIER", http.StatusConflict, "The identifier matches more than one user")
+	CodeMissingProviderEmail  = register("MISSING_PROVIDER_EMAIL", http.StatusForbidden, "The identity provider did not share a verified email address")
+)
@@ -0,0 +1,125 @@
+package error
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// asField runs the errors.As check that callers wrote before the sentinels
+// existed and returns the context field it exposes.
+func asField[T error](err error, field func(T) string) (string, bool) {
+	var target T
+	if !errors.As(err, &target) {
+		return "", false
+	}
+	return field(target), true
+}
+
+// TestErrorsAsStillWorks keeps the pointer-type errors.As checks of callers
+// outside this package working next to the errors.Is sentinels, for the
+// error itself and when wrapped with %w.
+func TestErrorsAsStillWorks(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+		as       func(err error) (string, bool)
+		field    string
+	}{
+		{
+			"already exists", NewAlreadyExistsError("Email already exists", "jane@example.com"), ErrAlreadyExists,
+			func(err error) (string, bool) {
+				return asField(err, func(e *AlreadyExistsError) string { return e.Email })
+			},
+			"jane@example.com",
+		},
+		{
+			"invalid credentials", NewInvalidCredentialsError("Invalid email or password", "jane@example.com"), ErrInvalidCredentials,
+			func(err error) (string, bool) {
+				return asField(err, func(e *InvalidCredentialsError) string { return e.Email })
+			},
+			"jane@example.com",
+		},
+		{
+			"user not found", NewUserNotFoundError("User not found", "user-1"), ErrUserNotFound,
+			func(err error) (string, bool) {
+				return asField(err, func(e *UserNotFoundError) string { return e.UserId })
+			},
+			"user-1",
+		},
+		{
+			"invalid token", NewInvalidTokenError("Invalid token", "token-1"), ErrInvalidToken,
+			func(err error) (string, bool) {
+				return asField(err, func(e *InvalidTokenError) string { return e.TokenId })
+			},
+			"token-1",
+		},
+		{
+			"expired token", NewExpiredTokenError("Token is expired", "token-1"), ErrExpiredToken,
+			func(err error) (string, bool) {
+				return asField(err, func(e *ExpiredTokenError) string { return e.TokenId })
+			},
+			"token-1",
+		},
+		{
+			"wrong token type", NewWrongTokenTypeError("Wrong token type", "token-1", "refresh", "access"), ErrWrongTokenType,
+			func(err error) (string, bool) {
+				return asField(err, func(e *WrongTokenTypeError) string { return e.TokenId })
+			},
+			"token-1",
+		},
+		{
+			"signup cooldown", NewSignupCooldownError("Too many sign-ups", time.Minute), ErrSignupCooldown,
+			func(err error) (string, bool) {
+				return asField(err, func(e *SignupCooldownError) string { return e.RetryAfter().String() })
+			},
+			"1m0s",
+		},
+		{
+			"internal server error", NewInternalServerError("Failed to get user", context.DeadlineExceeded), ErrInternalServer,
+			func(err error) (string, bool) {
+				return asField(err, func(e *InternalServerError) string { return e.Code() })
+			},
+			CodeInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, err := range []error{tt.err, fmt.Errorf("signing in: %w", tt.err)} {
+				if !errors.Is(err, tt.sentinel) {
+					t.Errorf("errors.Is(%q, %v) = false", err, tt.sentinel)
+				}
+				if field, ok := tt.as(err); !ok || field != tt.field {
+					t.Errorf("errors.As(%q) = %q, %t, want %q", err, field, ok, tt.field)
+				}
+			}
+		})
+	}
+}
+
+func TestSentinelsDoNotCrossMatch(t *testing.T) {
+	err := NewInvalidCredentialsError("Invalid email or password", "jane@example.com")
+
+	for _, sentinel := range []error{ErrUserNotFound, ErrAlreadyExists, ErrInvalidToken, ErrInternalServer} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("errors.Is(invalid credentials, %v) = true", sentinel)
+		}
+	}
+
+	var notFound *UserNotFoundError
+	if errors.As(err, &notFound) {
+		t.Error("errors.As matched an invalid credentials error as a UserNotFoundError")
+	}
+}
+
+func TestInternalServerErrorUnwrapsCause(t *testing.T) {
+	err := NewInternalServerError("Failed to get user", context.DeadlineExceeded)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("the cause is not reachable through errors.Is")
+	}
+}
@@ -0,0 +1,56 @@
+package error
+
+import (
+	"errors"
+)
+
+var (
+	ErrIdentityNotFound = errors.New("identity not found")
+	ErrLastSignInMethod = errors.New("last sign-in method")
+)
+
+type IdentityNotFoundError struct {
+	message  string
+	Provider string
+}
+
+func NewIdentityNotFoundError(message, provider string) error {
+	return &IdentityNotFoundError{message: message, Provider: provider}
+}
+
+func (e *IdentityNotFoundError) Error() string {
+	return e.message
+}
+
+func (e *IdentityNotFoundError) Code() string {
+	return CodeIdentityNotFound
+}
+
+func (e *IdentityNotFoundError) Params() map[string]string {
+	return map[string]string{"provider": e.Provider}
+}
+
+func (e *IdentityNotFoundError) Is(target error) bool {
+	return target == ErrIdentityNotFound
+}
+
+type LastSignInMethodError struct {
+	message string
+	UserId  string
+}
+
+func NewLastSignInMethodError(message, userId string) error {
+	return &LastSignInMethodError{message: message, UserId: userId}
+}
+
+func (e *LastSignInMethodError) Error() string {
+	return e.message
+}
+
+func (e *LastSignInMethodError) Code() string {
+	return CodeLastSignInMethod
+}
+
+func (e *LastSignInMethodError) Is(target error) bool {
+	return target == ErrLastSignInMethod
+}
@@ -1,25 +1,122 @@
 package error
 
+import (
+	"errors"
+)
+
+// bearerInvalidToken is the RFC 6750 error code for tokens that are expired,
+// revoked, malformed or otherwise invalid.
+const bearerInvalidToken = "invalid_token"
+
+var (
+	ErrInvalidToken   = errors.New("invalid token")
+	ErrExpiredToken   = errors.New("expired token")
+	ErrWrongTokenType = errors.New("wrong token type")
+	ErrTokenBinding   = errors.New("token binding mismatch")
+)
+
 type InvalidTokenError struct {
 	message string
+	TokenId string
 }
 
-func NewInvalidTokenError(message string) error {
-	return &InvalidTokenError{message: message}
+func NewInvalidTokenError(message, tokenId string) error {
+	return &InvalidTokenError{message: message, TokenId: tokenId}
 }
 
 func (e *InvalidTokenError) Error() string {
 	return e.message
 }
 
+func (e *InvalidTokenError) Code() string {
+	return CodeInvalidToken
+}
+
+func (e *InvalidTokenError) BearerError() string {
+	return bearerInvalidToken
+}
+
+func (e *InvalidTokenError) Is(target error) bool {
+	return target == ErrInvalidToken
+}
+
 type ExpiredTokenError struct {
 	message string
+	TokenId string
 }
 
-func NewExpiredTokenError(message string) error {
-	return &ExpiredTokenError{message: message}
+func NewExpiredTokenError(message, tokenId string) error {
+	return &ExpiredTokenError{message: message, TokenId: tokenId}
 }
 
 func (e *ExpiredTokenError) Error() string {
 	return e.message
 }
+
+func (e *ExpiredTokenError) Code() string {
+	return CodeExpiredToken
+}
+
+func (e *ExpiredTokenError) BearerError() string {
+	return bearerInvalidToken
+}
+
+func (e *ExpiredTokenError) Is(target error) bool {
+	return target == ErrExpiredToken
+}
+
+type WrongTokenTypeError struct {
+	message  string
+	TokenId  string
+	Expected string
+	Actual   string
+}
+
+func NewWrongTokenTypeError(message, tokenId, expected, actual string) error {
+	return &WrongTokenTypeError{message: message, TokenId: tokenId, Expected: expected, Actual: actual}
+}
+
+func (e *WrongTokenTypeError) Error() string {
+	return e.message
+}
+
+func (e *WrongTokenTypeError) Code() string {
+	return CodeWrongTokenType
+}
+
+func (e *WrongTokenTypeError) Params() map[string]string {
+	return map[string]string{"expected": e.Expected}
+}
+
+func (e *WrongTokenTypeError) BearerError() string {
+	return bearerInvalidToken
+}
+
+func (e *WrongTokenTypeError) Is(target error) bool {
+	return target == ErrWrongTokenType
+}
+
+type TokenBindingError struct {
+	message string
+	TokenId string
+}
+
+func NewTokenBindingError(message, tokenId string) error {
+	return &TokenBindingError{message: message, TokenId: tokenId}
+}
+
+func (e *TokenBindingError) Error() string {
+	return e.message
+}
+
+func (e *TokenBindingError) Code() string {
+	return CodeTokenBinding
+}
+
+func (e *TokenBindingError) BearerError() string {
+	return bearerInvalidToken
+}
+
+func (e *TokenBindingError) Is(target error) bool {
+	return target == ErrTokenBinding
+}
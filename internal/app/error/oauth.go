@@ -0,0 +1,107 @@
+package error
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidOAuthState = errors.New("invalid oauth state")
+	ErrOAuthProvider     = errors.New("oauth provider error")
+	ErrUnverifiedEmail   = errors.New("unverified email")
+	ErrMissingEmail      = errors.New("missing provider email")
+)
+
+type InvalidOAuthStateError struct {
+	message string
+}
+
+func NewInvalidOAuthStateError(message string) error {
+	return &InvalidOAuthStateError{message: message}
+}
+
+func (e *InvalidOAuthStateError) Error() string {
+	return e.message
+}
+
+func (e *InvalidOAuthStateError) Code() string {
+	return CodeInvalidOAuthState
+}
+
+func (e *InvalidOAuthStateError) Is(target error) bool {
+	return target == ErrInvalidOAuthState
+}
+
+type OAuthProviderError struct {
+	message  string
+	cause    error
+	Provider string
+}
+
+func NewOAuthProviderError(message, provider string, cause error) error {
+	return &OAuthProviderError{message: message, cause: cause, Provider: provider}
+}
+
+func (e *OAuthProviderError) Error() string {
+	if e.cause == nil {
+		return e.message
+	}
+	return e.message + ": " + e.cause.Error()
+}
+
+func (e *OAuthProviderError) Unwrap() error {
+	return e.cause
+}
+
+func (e *OAuthProviderError) Code() string {
+	return CodeOAuthProviderError
+}
+
+func (e *OAuthProviderError) Is(target error) bool {
+	return target == ErrOAuthProvider
+}
+
+type UnverifiedEmailError struct {
+	message string
+	Email   string
+}
+
+func NewUnverifiedEmailError(message, email string) error {
+	return &UnverifiedEmailError{message: message, Email: email}
+}
+
+func (e *UnverifiedEmailError) Error() string {
+	return e.message
+}
+
+func (e *UnverifiedEmailError) Code() string {
+	return CodeUnverifiedEmail
+}
+
+func (e *UnverifiedEmailError) Is(target error) bool {
+	return target == ErrUnverifiedEmail
+}
+
+type MissingProviderEmailError struct {
+	message  string
+	Provider string
+}
+
+func NewMissingProviderEmailError(message, provider string) error {
+	return &MissingProviderEmailError{message: message, Provider: provider}
+}
+
+func (e *MissingProviderEmailError) Error() string {
+	return e.message
+}
+
+func (e *MissingProviderEmailError) Code() string {
+	return CodeMissingProviderEmail
+}
+
+func (e *MissingProviderEmailError) Params() map[string]string {
+	return map[string]string{"provider": e.Provider}
+}
+
+func (e *MissingProviderEmailError) Is(target error) bool {
+	return target == ErrMissingEmail
+}
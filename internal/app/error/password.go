@@ -0,0 +1,53 @@
+package error
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	ErrWeakPassword = errors.New("weak password")
+)
+
+type WeakPasswordError struct {
+	message     string
+	Bits        float64
+	MinBits     float64
+	Suggestions []string
+}
+
+func NewWeakPasswordError(message string, bits, minBits float64, suggestions []string) error {
+	return &WeakPasswordError{message: message, Bits: bits, MinBits: minBits, Suggestions: suggestions}
+}
+
+func (e *WeakPasswordError) Error() string {
+	return e.message
+}
+
+func (e *WeakPasswordError) Code() string {
+	return CodeWeakPassword
+}
+
+func (e *WeakPasswordError) Params() map[string]string {
+	return map[string]string{
+		"bits":     strconv.Itoa(int(e.Bits)),
+		"min_bits": strconv.Itoa(int(e.MinBits)),
+	}
+}
+
+func (e *WeakPasswordError) Details() map[string]interface{} {
+	suggestions := e.Suggestions
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+
+	return map[string]interface{}{
+		"estimated_bits": int(e.Bits),
+		"min_bits":       int(e.MinBits),
+		"suggestions":    suggestions,
+	}
+}
+
+func (e *WeakPasswordError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
@@ -0,0 +1,45 @@
+package error
+
+import (
+	"sort"
+)
+
+type Definition struct {
+	Code        string `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+var registry = map[string]Definition{}
+
+func register(code string, status int, description string) string {
+	if _, exists := registry[code]; exists {
+		panic("duplicate error code registered: " + code)
+	}
+
+	registry[code] = Definition{Code: code, Status: status, Description: description}
+	return code
+}
+
+func Lookup(code string) (Definition, bool) {
+	definition, ok := registry[code]
+	return definition, ok
+}
+
+func Status(code string) (int, bool) {
+	definition, ok := registry[code]
+	return definition.Status, ok
+}
+
+func Definitions() []Definition {
+	definitions := make([]Definition, 0, len(registry))
+	for _, definition := range registry {
+		definitions = append(definitions, definition)
+	}
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Code < definitions[j].Code
+	})
+
+	return definitions
+}
@@ -0,0 +1,32 @@
+package error
+
+import (
+	"testing"
+
+	"jwtgo/internal/pkg/i18n"
+	"jwtgo/pkg/logging"
+)
+
+// TestCatalogCoversRegisteredCodes keeps the translations in step with the
+// registry. Startup only insists on the default locale; a missing ru or de
+// message would quietly fall back to English.
+func TestCatalogCoversRegisteredCodes(t *testing.T) {
+	logger := logging.GetLogger("panic")
+	catalog, err := i18n.NewCatalog(&logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	definitions := Definitions()
+	if len(definitions) == 0 {
+		t.Fatal("no error codes are registered")
+	}
+
+	for _, locale := range []string{i18n.DefaultLocale, "ru", "de"} {
+		for _, definition := range definitions {
+			if !catalog.HasMessage(locale, definition.Code) {
+				t.Errorf("%s has no %s message", definition.Code, locale)
+			}
+		}
+	}
+}
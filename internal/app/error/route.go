@@ -0,0 +1,50 @@
+package error
+
+import (
+	"errors"
+)
+
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+type NotFoundError struct {
+	message string
+}
+
+func NewNotFoundError(message string) error {
+	return &NotFoundError{message: message}
+}
+
+func (e *NotFoundError) Error() string {
+	return e.message
+}
+
+func (e *NotFoundError) Code() string {
+	return CodeNotFound
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+type MethodNotAllowedError struct {
+	message string
+}
+
+func NewMethodNotAllowedError(message string) error {
+	return &MethodNotAllowedError{message: message}
+}
+
+func (e *MethodNotAllowedError) Error() string {
+	return e.message
+}
+
+func (e *MethodNotAllowedError) Code() string {
+	return CodeMethodNotAllowed
+}
+
+func (e *MethodNotAllowedError) Is(target error) bool {
+	return target == ErrMethodNotAllowed
+}
@@ -1,13 +1,85 @@
 package error
 
+import (
+	"errors"
+	"time"
+)
+
+var ErrInternalServer = errors.New("internal server error")
+
 type InternalServerError struct {
 	message string
+	cause   error
 }
 
-func NewInternalServerError(message string) error {
-	return &InternalServerError{message: message}
+func NewInternalServerError(message string, cause error) error {
+	return &InternalServerError{message: message, cause: cause}
 }
 
 func (e *InternalServerError) Error() string {
+	if e.cause == nil {
+		return e.message
+	}
+	return e.message + ": " + e.cause.Error()
+}
+
+func (e *InternalServerError) Unwrap() error {
+	return e.cause
+}
+
+func (e *InternalServerError) Code() string {
+	return CodeInternalServerError
+}
+
+func (e *InternalServerError) Is(target error) bool {
+	return target == ErrInternalServer
+}
+
+var ErrInvalidRequest = errors.New("invalid request")
+
+type InvalidRequestError struct {
+	message string
+}
+
+func NewInvalidRequestError(message string) error {
+	return &InvalidRequestError{message: message}
+}
+
+func (e *InvalidRequestError) Error() string {
+	return e.message
+}
+
+func (e *InvalidRequestError) Code() string {
+	return CodeInvalidRequest
+}
+
+func (e *InvalidRequestError) Is(target error) bool {
+	return target == ErrInvalidRequest
+}
+
+var ErrServerOverloaded = errors.New("server overloaded")
+
+type ServerOverloadedError struct {
+	message    string
+	retryAfter time.Duration
+}
+
+func NewServerOverloadedError(message string, retryAfter time.Duration) error {
+	return &ServerOverloadedError{message: message, retryAfter: retryAfter}
+}
+
+func (e *ServerOverloadedError) Error() string {
 	return e.message
 }
+
+func (e *ServerOverloadedError) Code() string {
+	return CodeServerOverloaded
+}
+
+func (e *ServerOverloadedError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+func (e *ServerOverloadedError) Is(target error) bool {
+	return target == ErrServerOverloaded
+}
@@ -0,0 +1,78 @@
+package error
+
+import (
+	"errors"
+)
+
+var (
+	ErrAccountPending  = errors.New("account pending verification")
+	ErrAccountRejected = errors.New("account rejected")
+	ErrInvalidCallback = errors.New("invalid verification callback")
+)
+
+type AccountPendingError struct {
+	message string
+	UserId  string
+}
+
+func NewAccountPendingError(message, userId string) error {
+	return &AccountPendingError{message: message, UserId: userId}
+}
+
+func (e *AccountPendingError) Error() string {
+	return e.message
+}
+
+func (e *AccountPendingError) Code() string {
+	return CodeAccountPending
+}
+
+func (e *AccountPendingError) Is(target error) bool {
+	return target == ErrAccountPending
+}
+
+type AccountRejectedError struct {
+	message string
+	UserId  string
+}
+
+func NewAccountRejectedError(message, userId string) error {
+	return &AccountRejectedError{message: message, UserId: userId}
+}
+
+func (e *AccountRejectedError) Error() string {
+	return e.message
+}
+
+func (e *AccountRejectedError) Code() string {
+	return CodeAccountRejected
+}
+
+func (e *AccountRejectedError) Is(target error) bool {
+	return target == ErrAccountRejected
+}
+
+type InvalidCallbackError struct {
+	message string
+	Err     error
+}
+
+func NewInvalidCallbackError(message string, err error) error {
+	return &InvalidCallbackError{message: message, Err: err}
+}
+
+func (e *InvalidCallbackError) Error() string {
+	return e.message
+}
+
+func (e *InvalidCallbackError) Code() string {
+	return CodeInvalidCallback
+}
+
+func (e *InvalidCallbackError) Unwrap() error {
+	return e.Err
+}
+
+func (e *InvalidCallbackError) Is(target error) bool {
+	return target == ErrInvalidCallback
+}
@@ -9,6 +9,7 @@ import (
 type UserRepository interface {
 	GetById(ctx context.Context, id string) (*domainEntity.User, error)
 	GetByEmail(ctx context.Context, email string) (*domainEntity.User, error)
+	GetByIdentity(ctx context.Context, provider, subject string) (*domainEntity.User, error)
 	GetAll(ctx context.Context) ([]*domainEntity.User, error)
 	Create(ctx context.Context, domainUser *domainEntity.User) (bool, error)
 	Update(ctx context.Context, id string, domainUser *domainEntity.User) (bool, error)
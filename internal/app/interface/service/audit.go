@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/pkg/audit"
+)
+
+type AuditLogger interface {
+	Record(ctx context.Context, event audit.Event)
+}
+
+type AuditReader interface {
+	Recent(userId string, limit int) []audit.Record
+}
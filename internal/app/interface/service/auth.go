@@ -4,10 +4,14 @@ import (
 	"context"
 
 	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/entity"
 )
 
 type AuthService interface {
 	SignUp(ctx context.Context, userCredentialsDTO *dto.UserCredentialsDTO) (bool, error)
 	SignIn(ctx context.Context, userCredentialsDTO *dto.UserCredentialsDTO) (*dto.UserTokensDTO, error)
 	Refresh(ctx context.Context, refreshTokenDTO *dto.UserRefreshTokenDTO) (*dto.UserTokensDTO, error)
+	Reauthenticate(ctx context.Context, userId string, reauthenticateDTO *dto.ReauthenticateDTO) (*dto.ConfirmationTokenDTO, error)
+	Revoke(ctx context.Context, userId string, revokeTokenDTO *dto.RevokeTokenDTO) error
+	IssueTokens(ctx context.Context, user *entity.User) (*dto.UserTokensDTO, error)
 }
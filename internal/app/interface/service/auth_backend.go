@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/entity"
+)
+
+// AuthBackend verifies a password sign-in and returns the local user record
+// the tokens are issued for.
+type AuthBackend interface {
+	Authenticate(ctx context.Context, email, password string) (*entity.User, error)
+}
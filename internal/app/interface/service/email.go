@@ -0,0 +1,17 @@
+package service
+
+import (
+	"time"
+)
+
+type EmailDomainPolicy interface {
+	IsAllowed(email string) bool
+}
+
+type DisposableEmailChecker interface {
+	IsDisposable(email string) bool
+}
+
+type SignupLimiter interface {
+	Reserve(key string) (func(), bool, time.Duration)
+}
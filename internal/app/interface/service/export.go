@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/controller/http/dto"
+)
+
+type ExportService interface {
+	Export(ctx context.Context, userId string) (*dto.UserExportDTO, error)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/controller/http/dto"
+)
+
+type IdentityService interface {
+	List(ctx context.Context, userId string) ([]dto.IdentityDTO, error)
+	Unlink(ctx context.Context, userId, provider string) error
+}
@@ -1,10 +1,20 @@
 package service
 
 import (
+	"time"
+
 	"jwtgo/internal/app/schema"
 )
 
 type JWTService interface {
-	GenerateTokens(id string) (string, string, error)
-	ValidateToken(signedToken string) (*schema.Claims, error)
+	GenerateTokens(subject schema.Claims) (string, string, error)
+	GenerateConfirmationToken(id string, authTime time.Time) (string, error)
+	ValidateToken(signedToken, tokenType string) (*schema.Claims, error)
+	ParseToken(signedToken string) (*schema.Claims, error)
+	RevokeToken(claims *schema.Claims)
+	RevokeSubject(subject string)
+}
+
+type NetworkBinder interface {
+	Network(ip string) string
 }
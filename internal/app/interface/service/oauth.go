@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/entity"
+)
+
+type OAuthProvider interface {
+	Name() string
+	AuthCodeURL(state string) string
+	Exchange(ctx context.Context, code string) (*oauth2.Token, error)
+	FetchUserInfo(ctx context.Context, token *oauth2.Token) (*entity.OAuthUserInfo, error)
+}
+
+type OAuthService interface {
+	LoginURL(provider string) (string, string, error)
+	Callback(ctx context.Context, provider, code, state string) (*dto.UserTokensDTO, error)
+}
@@ -4,4 +4,13 @@ type PasswordService interface {
 	GenerateSalt(length int) (string, error)
 	HashPassword(password, localSalt string) (string, error)
 	VerifyPassword(plainPassword, hashedPassword, localSalt string) bool
+	IsHash(hashedPassword string) bool
+}
+
+type PasswordStrengthEstimator interface {
+	Estimate(password string, userInputs []string) (bits float64, suggestions []string)
+}
+
+type PasswordStrengthPolicy interface {
+	Check(password string, userInputs ...string) error
 }
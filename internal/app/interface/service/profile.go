@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/controller/http/dto"
+)
+
+type ProfileService interface {
+	Get(ctx context.Context, userId string) (*dto.ProfileDTO, error)
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/pkg/security"
+)
+
+type SecurityNotifier interface {
+	Notify(ctx context.Context, event security.Event) error
+}
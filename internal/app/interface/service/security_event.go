@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/controller/http/dto"
+)
+
+type SecurityEventService interface {
+	List(ctx context.Context, userId string, limit int) ([]dto.SecurityEventDTO, error)
+}
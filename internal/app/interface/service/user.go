@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/entity"
+)
+
+type UserResolver interface {
+	FindUserByIdentifier(ctx context.Context, identifier string) (*entity.User, error)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/controller/http/dto"
+)
+
+type UserImportService interface {
+	Import(ctx context.Context, importUsersDTO *dto.ImportUsersDTO) ([]dto.ImportResultDTO, error)
+	Export(ctx context.Context) ([]dto.ExportedUserDTO, error)
+}
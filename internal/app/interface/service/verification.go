@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+)
+
+type IdentityVerifier interface {
+	// StartVerification reports whether the account is approved right away;
+	// otherwise the decision arrives later through the callback endpoint.
+	StartVerification(ctx context.Context, userId, email string) (bool, error)
+}
+
+type VerificationService interface {
+	Complete(ctx context.Context, userId string, approved bool) error
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-webauthn/webauthn/protocol"
+
+	"jwtgo/internal/app/controller/http/dto"
+)
+
+type WebAuthnService interface {
+	BeginRegistration(ctx context.Context, userId string) (*protocol.CredentialCreation, string, error)
+	FinishRegistration(ctx context.Context, userId, sessionId string, response *protocol.ParsedCredentialCreationData) (bool, error)
+	BeginLogin(ctx context.Context, webAuthnLoginDTO *dto.WebAuthnLoginDTO) (*protocol.CredentialAssertion, string, error)
+	FinishLogin(ctx context.Context, sessionId string, response *protocol.ParsedCredentialAssertionData) (*dto.UserTokensDTO, error)
+}
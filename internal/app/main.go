@@ -1,42 +1,194 @@
 package app
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	memoryRepository "jwtgo/internal/app/adapter/memory/repository"
 	"jwtgo/internal/app/adapter/mongodb/repository"
 	"jwtgo/internal/app/config"
+	"jwtgo/internal/app/controller/grpc/interceptor"
+	grpcV1 "jwtgo/internal/app/controller/grpc/v1"
 	"jwtgo/internal/app/controller/http/middleware"
 	"jwtgo/internal/app/controller/http/v1"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
 	serviceInterface "jwtgo/internal/app/interface/service"
 	"jwtgo/internal/app/service"
+	"jwtgo/internal/pkg/audit"
+	"jwtgo/internal/pkg/buildinfo"
+	"jwtgo/internal/pkg/debug"
+	"jwtgo/internal/pkg/health"
+	"jwtgo/internal/pkg/i18n"
+	"jwtgo/internal/pkg/limiter"
+	"jwtgo/internal/pkg/metrics"
+	"jwtgo/internal/pkg/openapi"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/internal/pkg/revocation"
+	"jwtgo/internal/pkg/security"
+	"jwtgo/internal/pkg/state"
+	"jwtgo/internal/pkg/tracing"
+	"jwtgo/internal/pkg/verification"
 	"jwtgo/pkg/client"
 	"jwtgo/pkg/logging"
 )
 
+// probePaths are served to orchestrators and skip access logging and load
+// shedding.
+var probePaths = []string{"/healthz", "/readyz"}
+
 type Application struct {
+	Context         context.Context
+	Cancel          context.CancelFunc
 	Config          *config.Config
 	Logger          *logging.Logger
+	LogFile         *logging.FileSink
+	LogWebhook      *logging.AsyncHook
+	AuditWriter     *audit.Writer
+	Metrics         *metrics.Metrics
+	TracingShutdown func(context.Context) error
+	HealthChecker   *health.Checker
 	Router          *gin.Engine
 	Validator       *validator.Validate
+	Catalog         *i18n.Catalog
+	ErrorMapper     *request.ErrorMapper
 	MongoClient     *mongo.Client
+	GRPCServer      *grpc.Server
 	JWTService      serviceInterface.JWTService
 	PasswordService serviceInterface.PasswordService
 	AuthService     serviceInterface.AuthService
+	WebAuthnService serviceInterface.WebAuthnService
+	OAuthService    serviceInterface.OAuthService
+	IdentityService serviceInterface.IdentityService
+	ExportService   serviceInterface.ExportService
+
+	VerificationService  serviceInterface.VerificationService
+	ProfileService       serviceInterface.ProfileService
+	SecurityEventService serviceInterface.SecurityEventService
+	UserImportService    serviceInterface.UserImportService
 }
 
 func NewApplication() *Application {
 	logger := logging.GetLogger("info")
+	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Application{
-		Logger: &logger,
+		Context: ctx,
+		Cancel:  cancel,
+		Logger:  &logger,
 	}
 }
 
 func (app *Application) InitializeConfig() {
 	app.Logger.Info("Reading application config...")
 	app.Config = config.GetConfig(app.Logger)
+
+	if err := app.Logger.SetFormat(app.Config.Log.Format); err != nil {
+		app.Logger.Fatal("Failed to configure logger: ", err)
+	}
+	if err := app.Logger.SetLevel(app.Config.Log.Level); err != nil {
+		app.Logger.Fatal("Failed to configure logger: ", err)
+	}
+	app.Logger.SetRedactedFields(app.Config.Log.RedactedFields)
+	if err := app.Logger.SetRedactedPatterns(app.Config.Log.RedactedPatterns); err != nil {
+		app.Logger.Fatal("Failed to configure logger: ", err)
+	}
+	app.Logger.SetEmailMasking(app.Config.Log.MaskEmails)
+	if err := app.Logger.SetComponentLevels(app.Config.Log.Components); err != nil {
+		app.Logger.Fatal("Failed to configure logger: ", err)
+	}
+	app.Logger.SetSampling(app.Config.Log.Sampling)
+	app.InitializeLogOutputs()
+	app.InitializeLogHooks()
+	app.Logger.Debug("Loaded config:\n", app.Config)
+
+	app.HandleLogSignals()
+}
+
+func (app *Application) InitializeLogOutputs() {
+	outputs := make([]io.Writer, 0, len(app.Config.Log.Outputs))
+
+	for _, output := range app.Config.Log.Outputs {
+		switch output {
+		case "stdout":
+			outputs = append(outputs, os.Stdout)
+		case "file":
+			fileConfig := app.Config.Log.File
+			sink, err := logging.NewFileSink(logging.FileSinkOptions{
+				Path:       fileConfig.Path,
+				MaxSize:    fileConfig.MaxSize * 1024 * 1024,
+				MaxBackups: fileConfig.MaxBackups,
+				MaxAge:     time.Duration(fileConfig.MaxAge) * 24 * time.Hour,
+				Compress:   fileConfig.Compress,
+			})
+			if err != nil {
+				app.Logger.Fatal("Failed to open log file: ", err)
+			}
+
+			app.LogFile = sink
+			outputs = append(outputs, sink)
+		default:
+			app.Logger.Fatalf("Unknown log output %q", output)
+		}
+	}
+
+	if len(outputs) > 0 {
+		app.Logger.SetOutputs(outputs...)
+	}
+}
+
+func (app *Application) InitializeLogHooks() {
+	webhookConfig := app.Config.Log.Webhook
+	if webhookConfig.URL == "" {
+		return
+	}
+
+	webhook, err := logging.NewWebhookHook(logging.WebhookOptions{
+		URL:           webhookConfig.URL,
+		BatchSize:     webhookConfig.BatchSize,
+		FlushInterval: time.Duration(webhookConfig.FlushInterval) * time.Second,
+		MaxRetries:    webhookConfig.MaxRetries,
+	})
+	if err != nil {
+		app.Logger.Fatal("Failed to configure log webhook: ", err)
+	}
+
+	app.LogWebhook, err = app.Logger.AddHook(webhook, logging.HookOptions{
+		MinLevel:  webhookConfig.MinLevel,
+		Fields:    webhookConfig.Fields,
+		QueueSize: webhookConfig.QueueSize,
+	})
+	if err != nil {
+		app.Logger.Fatal("Failed to configure log webhook: ", err)
+	}
+}
+
+func (app *Application) InitializeTracing() {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint:    app.Config.Tracing.Endpoint,
+		Insecure:    app.Config.Tracing.Insecure,
+		SampleRatio: app.Config.Tracing.SampleRatio,
+		ServiceName: app.Config.Tracing.ServiceName,
+	})
+	if err != nil {
+		app.Logger.Fatal("Failed to configure tracing: ", err)
+	}
+
+	app.TracingShutdown = shutdown
 }
 
 func (app *Application) SetGinMode() {
@@ -50,44 +202,583 @@ func (app *Application) SetGinMode() {
 func (app *Application) InitializeRouter() {
 	app.Logger.Info("Application initialization...")
 	app.Router = gin.New()
+	app.Router.RedirectTrailingSlash = app.Config.App.RedirectTrailingSlash
+	// Also cleans up paths such as //auth/../auth/signin before the
+	// case-insensitive lookup, and redirects to the registered route.
+	app.Router.RedirectFixedPath = app.Config.App.CaseInsensitiveRoutes
+
+	if err := app.Router.SetTrustedProxies(app.Config.App.TrustedProxies); err != nil {
+		app.Logger.Fatal("Failed to configure trusted proxies: ", err)
+	}
 
-	app.Router.Use(gin.Logger())
+	app.Router.Use(gin.LoggerWithConfig(gin.LoggerConfig{SkipPaths: probePaths}))
 }
 
 func (app *Application) InitializeClients() {
 	app.Validator = validator.New()
-	app.MongoClient = client.NewMongodbClient(app.Config.MongoDB.Url, app.Logger).Connect()
+
+	catalog, err := i18n.NewCatalog(app.Logger.Named("i18n"))
+	if err != nil {
+		app.Logger.Fatal("Failed to load error message catalog: ", err)
+	}
+	app.Catalog = catalog
+
+	for _, definition := range customErr.Definitions() {
+		if !app.Catalog.HasMessage(i18n.DefaultLocale, definition.Code) {
+			app.Logger.Fatal("Error code has no default message: ", definition.Code)
+		}
+	}
+
+	app.ErrorMapper = request.NewErrorMapper(app.Catalog, customErr.Status, app.Logger.Named("http"))
+
+	app.Metrics = metrics.New(app.Config.Metrics.RequestBuckets)
+
+	var checks []health.Check
+	if app.Config.App.Storage == "mongodb" {
+		poolConfig := app.Config.MongoDB.Pool
+		poolMonitor := client.NewMongodbPoolMonitor(time.Duration(poolConfig.SlowWait)*time.Millisecond, app.Logger.Named("mongo"))
+
+		app.MongoClient = client.NewMongodbClient(app.Config.MongoDB.Url, app.Logger.Named("mongo")).WithPool(client.MongodbPoolOptions{
+			MaxSize:       poolConfig.MaxSize,
+			MinSize:       poolConfig.MinSize,
+			MaxConnecting: poolConfig.MaxConnecting,
+			MaxIdleTime:   time.Duration(poolConfig.MaxIdleTime) * time.Second,
+			Monitor:       poolMonitor.PoolMonitor(),
+		}).Connect()
+
+		if poolConfig.StatsInterval > 0 {
+			go app.ReportPoolStats(poolMonitor, time.Duration(poolConfig.StatsInterval)*time.Second)
+		}
+
+		checks = append(checks, health.Check{
+			Name:     "mongodb",
+			Critical: true,
+			Timeout:  time.Duration(app.Config.Health.Timeout) * time.Second,
+			Probe: func(ctx context.Context) error {
+				return app.MongoClient.Ping(ctx, nil)
+			},
+		})
+	}
+
+	app.HealthChecker = health.NewChecker(
+		time.Duration(app.Config.Health.CacheTTL)*time.Second,
+		app.Logger.Named("health"),
+		checks...,
+	)
+}
+
+func (app *Application) InitializeUserRepository() repositoryInterface.UserRepository {
+	if app.Config.App.Storage == "memory" {
+		app.Logger.Warn("Using in-memory storage, all users are lost on restart")
+		return memoryRepository.NewUserRepository()
+	}
+
+	userRepository := repository.NewUserRepository(app.MongoClient, app.Config.MongoDB.Database, "users", app.Metrics, app.Logger.Named("repo"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := userRepository.EnsureIndexes(ctx); err != nil {
+		app.Logger.Fatal("Failed to ensure user indexes: ", err)
+	}
+
+	return userRepository
+}
+
+// InitializeBcryptCost returns the configured cost, or the measured one when
+// auto-selection is enabled. Auto-selection never goes below bcrypt's default
+// cost, so a slow host cannot silently weaken password storage.
+func (app *Application) InitializeBcryptCost() int {
+	cost := app.Config.Security.BcryptCost
+	tuning := app.Config.Security.BcryptTuning
+	if !tuning.Enabled {
+		return cost
+	}
+
+	target := time.Duration(tuning.Target) * time.Millisecond
+	recommended, measured, err := service.MeasureBcryptCost(cost, target)
+	if err != nil {
+		app.Logger.Fatal("Failed to measure bcrypt cost: ", err)
+	}
+
+	fields := map[string]interface{}{
+		"configured_cost":  cost,
+		"measured_ms":      measured.Milliseconds(),
+		"target_ms":        tuning.Target,
+		"recommended_cost": recommended,
+	}
+
+	if recommended == cost {
+		app.Logger.WithFields(fields).Info("Bcrypt cost matches the target duration")
+		return cost
+	}
+
+	if !tuning.AutoSelect {
+		app.Logger.WithFields(fields).Warn("Bcrypt cost is far from the target duration, consider changing security.bcrypt_cost")
+		return cost
+	}
+
+	selected := max(recommended, bcrypt.DefaultCost)
+	app.Logger.WithFields(fields).Info("Auto-selected bcrypt cost ", selected)
+
+	return selected
+}
+
+func (app *Application) ReportPoolStats(monitor *client.MongodbPoolMonitor, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			stats := monitor.Stats()
+			app.Metrics.SetDatabasePool(stats.InUse, stats.Idle, stats.Waiting, stats.WaitCount, stats.WaitDuration)
+		case <-app.Context.Done():
+			return
+		}
+	}
+}
+
+func (app *Application) InitializeTokenEncryption() *service.TokenEncryption {
+	if app.Config.Security.TokenFormat != "jwe" {
+		return nil
+	}
+
+	key, _ := hex.DecodeString(app.Config.Security.EncryptionKey)
+	encryption, err := service.NewTokenEncryption(key)
+	if err != nil {
+		app.Logger.Fatal("Failed to configure token encryption: ", err)
+	}
+
+	return encryption
 }
 
 func (app *Application) InitializeServices() {
-	app.JWTService = service.NewJWTService(app.Config.Security.Secret, app.Config.Security.AccessLifetime, app.Config.Security.RefreshLifetime)
-	app.PasswordService = service.NewPasswordService(app.Config.Security.BcryptCost, app.Config.Security.Salt)
+	app.JWTService = service.NewJWTService(
+		app.Config.Security.Secret,
+		app.Config.Security.AccessLifetime,
+		app.Config.Security.RefreshLifetime,
+		app.Config.Security.ConfirmationLifetime,
+		revocation.NewList(),
+		app.InitializeTokenEncryption(),
+		app.Metrics,
+	)
+	app.PasswordService = service.NewInstrumentedPasswordService(
+		service.NewPasswordService(app.InitializeBcryptCost(), app.Config.Security.Salt),
+		app.Metrics,
+	)
+
+	userRepository := app.InitializeUserRepository()
+
+	userResolver := service.NewUserResolver(userRepository, app.Config.Security.IdentifierOrder)
+
+	var authBackend serviceInterface.AuthBackend = service.NewLocalAuthBackend(userResolver, app.PasswordService)
+	if ldapConfig := app.Config.LDAP; ldapConfig.Enabled {
+		authBackend = service.NewLDAPAuthBackend(service.LDAPOptions{
+			URL:                ldapConfig.URL,
+			StartTLS:           ldapConfig.StartTLS,
+			InsecureSkipVerify: ldapConfig.InsecureSkipVerify,
+			BindDN:             ldapConfig.BindDN,
+			BindPassword:       ldapConfig.BindPassword,
+			BaseDN:             ldapConfig.BaseDN,
+			UserAttribute:      ldapConfig.UserAttribute,
+			EmailAttribute:     ldapConfig.EmailAttribute,
+			Timeout:            time.Duration(ldapConfig.Timeout) * time.Second,
+			PoolSize:           ldapConfig.PoolSize,
+		}, userRepository, app.Logger.Named("ldap"))
+	}
+
+	emailDomainPolicy := service.NewEmailDomainPolicy(app.Config.Security.AllowedEmailDomains, app.Config.Security.AllowEmailSubdomains)
+
+	var auditLogger serviceInterface.AuditLogger
+	if app.Config.Audit.Enabled {
+		if app.Config.Audit.Key == "" {
+			app.Logger.Fatal("Audit log is enabled but no key is configured")
+		}
+
+		auditWriter, err := audit.OpenFile(app.Config.Audit.Path, []byte(app.Config.Audit.Key), app.Config.Audit.CheckpointEvery)
+		if err != nil {
+			app.Logger.Fatal("Failed to open audit log: ", err)
+		}
+
+		recentEvents := audit.NewRecent(app.Config.Audit.RecentPerUser)
+		if auditFile, err := os.Open(app.Config.Audit.Path); err == nil {
+			if err := recentEvents.Load(auditFile); err != nil {
+				app.Logger.Warn("Failed to load recent audit events: ", err)
+			}
+			auditFile.Close()
+		}
+
+		auditService := service.NewAuditService(auditWriter, recentEvents, app.Logger.Named("audit"))
+
+		app.AuditWriter = auditWriter
+		app.SecurityEventService = service.NewSecurityEventService(auditService)
+		auditLogger = auditService
+	}
+
+	var disposableChecker serviceInterface.DisposableEmailChecker
+	if app.Config.Security.DisposableEmail.Enabled {
+		disposableChecker = service.NewDisposableEmailChecker(
+			app.Config.Security.DisposableEmail.ExtraDomains,
+			app.Config.Security.DisposableEmail.ReplaceDefaults,
+		)
+	}
 
-	userRepository := repository.NewUserRepository(app.MongoClient, app.Config.MongoDB.Database, "users", app.Logger)
-	app.AuthService = service.NewAuthService(userRepository, app.JWTService, app.PasswordService, app.Logger)
+	var passwordStrength serviceInterface.PasswordStrengthPolicy
+	if app.Config.Security.PasswordEntropy.Enabled {
+		passwordStrength = service.NewPasswordStrengthPolicy(service.NewEntropyEstimator(), app.Config.Security.PasswordEntropy.MinBits)
+	}
+
+	var signupLimiter serviceInterface.SignupLimiter
+	if cooldownConfig := app.Config.Security.SignupCooldown; cooldownConfig.Enabled {
+		signupLimiter = limiter.NewWindowLimiter(cooldownConfig.Limit, time.Duration(cooldownConfig.Window)*time.Minute)
+	}
+
+	var refreshBinder serviceInterface.NetworkBinder
+	if bindingConfig := app.Config.Security.RefreshBinding; bindingConfig.Enabled {
+		refreshBinder = service.NewSubnetBinder(bindingConfig.IPv4Prefix, bindingConfig.IPv6Prefix)
+	}
+
+	var identityVerifier serviceInterface.IdentityVerifier
+	switch verificationConfig := app.Config.Verification; verificationConfig.Provider {
+	case "noop":
+		identityVerifier = verification.Noop{}
+	case "http":
+		provider, err := verification.NewHTTPProvider(verification.HTTPProviderOptions{
+			URL:         verificationConfig.URL,
+			CallbackURL: verificationConfig.CallbackURL,
+			Secret:      verificationConfig.Secret,
+			MaxRetries:  verificationConfig.MaxRetries,
+		})
+		if err != nil {
+			app.Logger.Fatal("Failed to configure identity verification: ", err)
+		}
+		identityVerifier = provider
+	}
+
+	app.IdentityService = service.NewIdentityService(userRepository, !app.Config.Security.DisablePasswordLogin, auditLogger, app.Logger.Named("identity"))
+	app.ExportService = service.NewExportService(userRepository, auditLogger, app.Logger.Named("export"))
+	app.UserImportService = service.NewUserImportService(userRepository, app.PasswordService, identityVerifier, auditLogger, app.Logger.Named("import"))
+	app.ProfileService = service.NewProfileService(userRepository, app.Logger.Named("profile"))
+	app.VerificationService = service.NewVerificationService(userRepository, auditLogger, app.Logger.Named("verification"))
+
+	var securityNotifier serviceInterface.SecurityNotifier
+	if app.Config.SecurityNotifier.WebhookURL != "" {
+		webhookNotifier, err := security.NewWebhookNotifier(security.WebhookOptions{
+			URL:        app.Config.SecurityNotifier.WebhookURL,
+			MaxRetries: app.Config.SecurityNotifier.MaxRetries,
+		}, app.Logger.Named("security"))
+		if err != nil {
+			app.Logger.Fatal("Failed to configure security notifier: ", err)
+		}
+		securityNotifier = webhookNotifier
+	}
+
+	app.AuthService = service.NewAuthService(
+		userRepository,
+		app.JWTService,
+		app.PasswordService,
+		authBackend,
+		emailDomainPolicy,
+		service.AuthServiceOptions{
+			DisposableChecker:  disposableChecker,
+			PasswordStrength:   passwordStrength,
+			SignupLimiter:      signupLimiter,
+			RefreshBinder:      refreshBinder,
+			IdentityVerifier:   identityVerifier,
+			AuditLogger:        auditLogger,
+			SecurityNotifier:   securityNotifier,
+			SingleSession:      app.Config.Security.SingleSession,
+			RefreshGracePeriod: time.Duration(app.Config.Security.RefreshGracePeriod) * time.Second,
+		},
+		app.Metrics,
+		app.Logger.Named("auth"),
+	)
+
+	if app.Config.WebAuthn.Enabled {
+		webAuthn, err := webauthn.New(&webauthn.Config{
+			RPDisplayName: app.Config.WebAuthn.RPDisplayName,
+			RPID:          app.Config.WebAuthn.RPID,
+			RPOrigins:     app.Config.WebAuthn.RPOrigins,
+		})
+		if err != nil {
+			app.Logger.Fatal("Failed to configure WebAuthn: ", err)
+		}
+
+		app.WebAuthnService = service.NewWebAuthnService(webAuthn, userRepository, app.AuthService, app.Logger.Named("webauthn"))
+	}
+
+	var oauthProviders []serviceInterface.OAuthProvider
+	if app.Config.OAuth.Google.Enabled {
+		oauthProviders = append(oauthProviders, service.NewGoogleProvider(
+			app.Config.OAuth.Google.ClientID,
+			app.Config.OAuth.Google.ClientSecret,
+			app.Config.OAuth.Google.RedirectURL,
+		))
+	}
+	if app.Config.OAuth.GitHub.Enabled {
+		oauthProviders = append(oauthProviders, service.NewGitHubProvider(
+			app.Config.OAuth.GitHub.ClientID,
+			app.Config.OAuth.GitHub.ClientSecret,
+			app.Config.OAuth.GitHub.RedirectURL,
+		))
+	}
+
+	if len(oauthProviders) > 0 {
+		app.OAuthService = service.NewOAuthService(
+			oauthProviders,
+			state.NewSigner(
+				app.Config.Security.Secret,
+				state.WithTTL(time.Duration(app.Config.OAuth.StateLifetime)*time.Minute),
+				state.WithSingleUse(),
+			),
+			userRepository,
+			app.AuthService,
+			emailDomainPolicy,
+			app.Config.OAuth.AllowAccountLinking,
+			app.Logger.Named("oauth"),
+		)
+	}
+
+	if app.Config.Security.DisablePasswordLogin && app.OAuthService == nil {
+		app.Logger.Warn("Password login is disabled and no OAuth provider is enabled, new users will not be able to sign in")
+	}
 }
 
 func (app *Application) InitializeControllers() {
-	authController := v1.NewAuthController(app.AuthService, app.Validator, app.Logger)
-	authController.Register(app.Router)
+	httpLogger := app.Logger.Named("http")
+
+	app.Router.Use(middleware.RequestId())
+	app.Router.Use(middleware.ClientIP())
+	app.Router.Use(middleware.RequestLogger(httpLogger))
+	app.Router.Use(middleware.Tracing())
+	app.Router.Use(middleware.Metrics(app.Metrics))
+	if app.Config.App.MaxInFlight > 0 {
+		app.Router.Use(middleware.ConcurrencyLimit(app.Config.App.MaxInFlight, time.Duration(app.Config.App.RetryAfter)*time.Second, probePaths, app.Metrics, app.ErrorMapper))
+	}
+	app.Router.Use(middleware.Recovery(app.ErrorMapper))
 
-	app.Router.Use(middleware.Authentication(app.JWTService))
+	authentication := middleware.Authentication(app.JWTService, app.ErrorMapper)
+
+	authController := v1.NewAuthController(app.AuthService, authentication, v1.AuthControllerOptions{
+		SignupCooldown:               app.Config.Security.SignupCooldown.Enabled,
+		PasswordLogin:                !app.Config.Security.DisablePasswordLogin,
+		SignUp:                       !app.Config.LDAP.Enabled,
+		ClearCookiesOnRefreshFailure: app.Config.Security.ClearCookiesOnRefreshFailure,
+		IncludeRoles:                 app.Config.Security.IncludeRoles,
+	}, app.Validator, app.ErrorMapper, httpLogger)
+	openAPIDocument := openapi.Build(openapi.Info{Title: "jwtgo", Version: buildinfo.Get().Version}, authController.Operations(), customErr.Status)
+
+	controllers := []v1.Controller{
+		authController,
+		v1.NewIdentityController(app.IdentityService, authentication, app.ErrorMapper, httpLogger),
+		v1.NewExportController(app.ExportService, authentication, app.ErrorMapper, httpLogger),
+		v1.NewProfileController(app.ProfileService, authentication, app.Config.Security.IncludeRoles, app.ErrorMapper),
+		v1.NewVersionController(buildinfo.Get()),
+		v1.NewOpenAPIController(openAPIDocument, app.Config.OpenAPI.SwaggerUI),
+		v1.NewTokenDebugController(app.ErrorMapper),
+		v1.NewErrorController(customErr.Definitions()),
+		v1.NewHealthController(app.HealthChecker),
+	}
+
+	if app.SecurityEventService != nil {
+		controllers = append(controllers, v1.NewSecurityEventController(app.SecurityEventService, authentication, app.ErrorMapper))
+	}
+
+	if len(app.Config.APIKeys.Hashes) > 0 {
+		apiKey := middleware.APIKey(app.Config.APIKeys.Header, decodeKeyHashes(app.Config.APIKeys.Hashes), app.ErrorMapper)
+
+		controllers = append(controllers, v1.NewIntrospectionController(app.JWTService, apiKey))
+	}
+
+	// Admin keys are a separate set, so a service key used for introspection
+	// cannot export or create users or change the log level.
+	if len(app.Config.APIKeys.AdminHashes) > 0 {
+		adminKey := middleware.APIKey(app.Config.APIKeys.Header, decodeKeyHashes(app.Config.APIKeys.AdminHashes), app.ErrorMapper)
+
+		controllers = append(controllers, v1.NewUserImportController(app.UserImportService, adminKey, app.Validator, app.ErrorMapper, httpLogger))
+		controllers = append(controllers, v1.NewLogLevelController(app.Logger, adminKey, app.Validator, app.ErrorMapper))
+	}
+
+	if app.Config.Verification.Provider == "http" {
+		callbackVerifier := verification.NewCallbackVerifier(app.Config.Verification.Secret, time.Duration(app.Config.Verification.Tolerance)*time.Second)
+
+		controllers = append(controllers, v1.NewVerificationController(app.VerificationService, callbackVerifier, app.ErrorMapper))
+	}
+
+	if app.OAuthService != nil {
+		controllers = append(controllers, v1.NewOAuthController(
+			app.OAuthService,
+			time.Duration(app.Config.OAuth.StateLifetime)*time.Minute,
+			app.ErrorMapper,
+			httpLogger,
+		))
+	}
+
+	if app.WebAuthnService != nil {
+		controllers = append(controllers, v1.NewWebAuthnController(
+			app.WebAuthnService,
+			authentication,
+			app.Validator,
+			app.ErrorMapper,
+			httpLogger,
+		))
+	}
+
+	v1.RegisterAll(app.Router, controllers...)
+
+	fallbackController := v1.NewFallbackController(app.ErrorMapper)
+	fallbackController.Register(app.Router)
+
+	app.InitializeMetrics()
+	app.InitializeDebug()
 }
 
-func (app *Application) Run() {
-	app.Logger.Info("Application is running on http://" + app.Config.App.Host + ":" + app.Config.App.Port)
-	err := app.Router.Run(app.Config.App.Host + ":" + app.Config.App.Port)
+func decodeKeyHashes(hashes []string) [][]byte {
+	keyHashes := make([][]byte, 0, len(hashes))
+	for _, keyHash := range hashes {
+		decoded, _ := hex.DecodeString(keyHash)
+		keyHashes = append(keyHashes, decoded)
+	}
+
+	return keyHashes
+}
+
+func (app *Application) InitializeMetrics() {
+	if !app.Config.Metrics.Enabled {
+		return
+	}
+
+	if app.Config.Metrics.Listen == "" {
+		app.Router.GET(app.Config.Metrics.Path, gin.WrapH(app.Metrics.Handler()))
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(app.Config.Metrics.Path, app.Metrics.Handler())
+
+	go func() {
+		app.Logger.Info("Metrics are served on http://" + app.Config.Metrics.Listen + app.Config.Metrics.Path)
+		if err := http.ListenAndServe(app.Config.Metrics.Listen, mux); err != nil {
+			app.Logger.Error("Metrics listener stopped: ", err)
+		}
+	}()
+}
+
+func (app *Application) InitializeGRPC() {
+	if !app.Config.GRPC.Enabled {
+		return
+	}
+
+	app.GRPCServer = app.newGRPCServer()
+
+	listener, err := net.Listen("tcp", app.Config.GRPC.Listen)
 	if err != nil {
+		app.Logger.Fatal("Failed to listen for gRPC: ", err)
+	}
+
+	go func() {
+		app.Logger.Info("gRPC is served on " + app.Config.GRPC.Listen)
+		if err := app.GRPCServer.Serve(listener); err != nil {
+			app.Logger.Error("gRPC server stopped: ", err)
+		}
+	}()
+}
+
+func (app *Application) newGRPCServer() *grpc.Server {
+	var serverOptions []grpc.ServerOption
+	if app.Config.GRPC.CertFile != "" || app.Config.GRPC.KeyFile != "" {
+		transportCredentials, err := credentials.NewServerTLSFromFile(app.Config.GRPC.CertFile, app.Config.GRPC.KeyFile)
+		if err != nil {
+			app.Logger.Fatal("Failed to load gRPC TLS certificate: ", err)
+		}
+		serverOptions = append(serverOptions, grpc.Creds(transportCredentials))
+	}
+
+	serverOptions = append(serverOptions, grpc.ChainUnaryInterceptor(
+		interceptor.RequestId(),
+		interceptor.ClientIP(),
+		interceptor.RequestLogger(app.Logger.Named("grpc")),
+		interceptor.Metrics(app.Metrics),
+		interceptor.Errors(app.Catalog, customErr.Status),
+		interceptor.Recovery(),
+	))
+
+	server := grpc.NewServer(serverOptions...)
+
+	authServer := grpcV1.NewAuthServer(app.AuthService, app.JWTService, !app.Config.Security.DisablePasswordLogin, !app.Config.LDAP.Enabled, app.Validator)
+	authServer.Register(server)
+
+	return server
+
+}
+
+func (app *Application) InitializeDebug() {
+	if !app.Config.Debug.Enabled {
+		return
+	}
+
+	if err := checkLoopback(app.Config.Debug.Listen); err != nil {
+		app.Logger.Fatal("Debug endpoints can only be served on a loopback address: ", err)
+	}
+
+	go func() {
+		app.Logger.Warn("Debug endpoints are served on http://" + app.Config.Debug.Listen + "/debug/")
+		if err := http.ListenAndServe(app.Config.Debug.Listen, debug.NewHandler()); err != nil {
+			app.Logger.Error("Debug listener stopped: ", err)
+		}
+	}()
+}
+
+func checkLoopback(listen string) error {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return fmt.Errorf("%s is not a loopback address", listen)
+	}
+
+	return nil
+}
+
+func (app *Application) Run() {
+	info := buildinfo.Get()
+	app.Logger.WithFields(map[string]interface{}{
+		"version":    info.Version,
+		"commit":     info.Commit,
+		"build_date": info.BuildDate,
+		"go_version": info.GoVersion,
+	}).Info("Application is running on http://" + app.Config.App.Host + ":" + app.Config.App.Port)
+
+	server := &http.Server{
+		Addr:    app.Config.App.Host + ":" + app.Config.App.Port,
+		Handler: app.Router,
+	}
+
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErrors:
 		app.Logger.Fatal("Failed to start the application", err)
+	case sig := <-app.ShutdownSignals():
+		app.Logger.Info("Received ", sig, ", shutting down")
 	}
+
+	app.Shutdown(server)
 }
 
 func (app *Application) Initialize() {
 	app.InitializeConfig()
+	app.InitializeTracing()
 	app.SetGinMode()
 
 	app.InitializeRouter()
 	app.InitializeClients()
 	app.InitializeServices()
 	app.InitializeControllers()
+	app.InitializeGRPC()
 }
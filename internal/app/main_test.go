@@ -0,0 +1,616 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	authv1 "jwtgo/api/auth/v1"
+	"jwtgo/internal/app/config"
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/app/schema"
+	"jwtgo/internal/app/service"
+	"jwtgo/pkg/logging"
+)
+
+const testConfig = `
+app:
+  host: "127.0.0.1"
+  port: "0"
+  storage: "memory"
+security:
+  salt: "test-salt"
+  secret: "test-secret"
+  bcrypt_cost: 4
+  access_lifetime: 10
+  refresh_lifetime: 60
+`
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+
+	os.Exit(m.Run())
+}
+
+// newTestApplication wires the application against in-memory storage, the
+// same way Initialize does, after letting configure adjust the config.
+func newTestApplication(t testing.TB, configure func(cfg *config.Config)) *Application {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configure != nil {
+		configure(cfg)
+	}
+
+	logger := logging.GetLogger("panic")
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	app := &Application{Context: ctx, Cancel: cancel, Config: cfg, Logger: &logger}
+	app.InitializeRouter()
+	app.InitializeClients()
+	app.InitializeServices()
+	app.InitializeControllers()
+
+	return app
+}
+
+func (app *Application) accessToken(t *testing.T) string {
+	t.Helper()
+
+	accessToken, _, err := app.JWTService.GenerateTokens(schema.Claims{Id: "user-1", Email: "user@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return accessToken
+}
+
+func serve(handler http.Handler, method, path, accessToken string) *httptest.ResponseRecorder {
+	return serveRequest(handler, httptest.NewRequest(method, path, nil), accessToken)
+}
+
+func serveRequest(handler http.Handler, req *http.Request, accessToken string) *httptest.ResponseRecorder {
+	if accessToken != "" {
+		req.AddCookie(&http.Cookie{Name: "access_token", Value: accessToken})
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder
+}
+
+func keyHash(key string) string {
+	digest := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(digest[:])
+}
+
+func errorCode(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %q", recorder.Body.String())
+	}
+
+	return body.Code
+}
+
+func TestFallbackRoutes(t *testing.T) {
+	app := newTestApplication(t, nil)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		code   string
+	}{
+		{"unknown path", http.MethodGet, "/bogus", http.StatusNotFound, "NOT_FOUND"},
+		{"wrong method", http.MethodDelete, "/auth/signin", http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED"},
+	}
+
+	for _, tt := range tests {
+		for _, withToken := range []bool{false, true} {
+			name := tt.name + " without token"
+			accessToken := ""
+			if withToken {
+				name = tt.name + " with token"
+				accessToken = app.accessToken(t)
+			}
+
+			t.Run(name, func(t *testing.T) {
+				recorder := serve(app.Router, tt.method, tt.path, accessToken)
+
+				if recorder.Code != tt.status {
+					t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+				}
+				if code := errorCode(t, recorder); code != tt.code {
+					t.Fatalf("code = %q, want %q", code, tt.code)
+				}
+			})
+		}
+	}
+}
+
+func TestProtectedRouteRequiresToken(t *testing.T) {
+	app := newTestApplication(t, nil)
+
+	if recorder := serve(app.Router, http.MethodGet, "/auth/me/export", ""); recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("status without token = %d, want %d", recorder.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestProbesBypassConcurrencyLimit(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.App.MaxInFlight = 1
+	})
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	app.Router.GET("/slow", func(c *gin.Context) {
+		close(entered)
+		<-release
+		c.Status(http.StatusOK)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		serve(app.Router, http.MethodGet, "/slow", "")
+		close(done)
+	}()
+	<-entered
+	defer func() {
+		close(release)
+		<-done
+	}()
+
+	for _, path := range probePaths {
+		if recorder := serve(app.Router, http.MethodGet, path, ""); recorder.Code != http.StatusOK {
+			t.Errorf("%s status = %d while saturated, want %d", path, recorder.Code, http.StatusOK)
+		}
+	}
+
+	if recorder := serve(app.Router, http.MethodGet, "/version", ""); recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("/version status = %d while saturated, want %d", recorder.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestUserImportRequiresAdminKey(t *testing.T) {
+	const body = `{"users":[{"email":"imported@example.com","password":"temporary","verified":true}]}`
+
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.APIKeys.Hashes = []string{keyHash("service-key")}
+		cfg.APIKeys.AdminHashes = []string{keyHash("admin-key")}
+	})
+
+	tests := []struct {
+		name   string
+		key    string
+		status int
+	}{
+		{"no key", "", http.StatusUnauthorized},
+		{"service key", "service-key", http.StatusUnauthorized},
+		{"admin key", "admin-key", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/import", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set(app.Config.APIKeys.Header, tt.key)
+			}
+
+			if recorder := serveRequest(app.Router, req, ""); recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestUserExportRequiresAdminKey(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.APIKeys.Hashes = []string{keyHash("service-key")}
+		cfg.APIKeys.AdminHashes = []string{keyHash("admin-key")}
+	})
+	signInCookies(t, app, "exported@example.com")
+
+	export := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin/users/export", nil)
+		if key != "" {
+			req.Header.Set(app.Config.APIKeys.Header, key)
+		}
+		return serveRequest(app.Router, req, "")
+	}
+
+	for _, key := range []string{"", "service-key"} {
+		if recorder := export(key); recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("key %q: status = %d, want %d", key, recorder.Code, http.StatusUnauthorized)
+		}
+	}
+
+	recorder := export("admin-key")
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("status = %d, content type %q", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+
+	lines := strings.Split(strings.TrimSuffix(recorder.Body.String(), "\n"), "\n")
+	var user struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if len(lines) != 1 || json.Unmarshal([]byte(lines[0]), &user) != nil || user.Email != "exported@example.com" || user.Password != "" {
+		t.Fatalf("export = %q", recorder.Body.String())
+	}
+}
+
+func TestUserImportIsAbsentWithoutAdminKeys(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.APIKeys.Hashes = []string{keyHash("service-key")}
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/import", strings.NewReader(`{"users":[]}`))
+	req.Header.Set(app.Config.APIKeys.Header, "service-key")
+
+	if recorder := serveRequest(app.Router, req, ""); recorder.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestLogLevelRequiresAdminKey(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.APIKeys.Hashes = []string{keyHash("service-key")}
+		cfg.APIKeys.AdminHashes = []string{keyHash("admin-key")}
+	})
+	previous := app.Logger.Logger.GetLevel()
+	t.Cleanup(func() { app.Logger.SetLevel(previous.String()) })
+
+	setLevel := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(app.Config.APIKeys.Header, key)
+		}
+		return serveRequest(app.Router, req, "")
+	}
+
+	for _, key := range []string{"", "service-key"} {
+		if recorder := setLevel(key, `{"level":"debug"}`); recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("key %q: status = %d, want %d", key, recorder.Code, http.StatusUnauthorized)
+		}
+	}
+	if level := app.Logger.Logger.GetLevel(); level != previous {
+		t.Fatalf("level = %s after rejected requests, want %s", level, previous)
+	}
+
+	if recorder := setLevel("admin-key", `{"level":"debug"}`); recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+	if level := app.Logger.Logger.GetLevel(); level != logrus.DebugLevel {
+		t.Fatalf("level = %s, want debug", level)
+	}
+
+	if recorder := setLevel("admin-key", `{"level":"trace","duration":"50ms"}`); recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+	if level := app.Logger.Logger.GetLevel(); level != logrus.TraceLevel {
+		t.Fatalf("level = %s, want trace", level)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for app.Logger.Logger.GetLevel() != logrus.DebugLevel {
+		if time.Now().After(deadline) {
+			t.Fatalf("level = %s, want the raise to revert to debug", app.Logger.Logger.GetLevel())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	for _, body := range []string{`{"level":"loud"}`, `{"level":"debug","duration":"soon"}`, `{"level":"debug","duration":"-1m"}`} {
+		if recorder := setLevel("admin-key", body); recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", body, recorder.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestRouteRedirects(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(cfg *config.Config)
+		path      string
+		redirect  bool
+	}{
+		{"trailing slash by default", nil, "/version/", true},
+		{"trailing slash disabled", func(cfg *config.Config) { cfg.App.RedirectTrailingSlash = false }, "/version/", false},
+		{"mixed case by default", nil, "/VERSION", false},
+		{"mixed case enabled", func(cfg *config.Config) { cfg.App.CaseInsensitiveRoutes = true }, "/VERSION", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication(t, tt.configure)
+			recorder := serve(app.Router, http.MethodGet, tt.path, "")
+
+			if tt.redirect {
+				if recorder.Code != http.StatusMovedPermanently || recorder.Header().Get("Location") != "/version" {
+					t.Fatalf("status = %d, location = %q, want a redirect to /version", recorder.Code, recorder.Header().Get("Location"))
+				}
+				return
+			}
+			if recorder.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusNotFound)
+			}
+		})
+	}
+}
+
+// grpcClient serves the application's gRPC server, interceptors included, over
+// an in-memory listener.
+func (app *Application) grpcClient(t *testing.T) authv1.AuthServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := app.newGRPCServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return authv1.NewAuthServiceClient(conn)
+}
+
+func grpcErrorCode(err error) (codes.Code, string) {
+	st := status.Convert(err)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return st.Code(), info.GetReason()
+		}
+	}
+
+	return st.Code(), ""
+}
+
+func TestGRPCAuthService(t *testing.T) {
+	const email, password = "grpc@example.com", "correct horse battery staple"
+
+	app := newTestApplication(t, nil)
+	client := app.grpcClient(t)
+	ctx := context.Background()
+
+	if _, err := client.SignUp(ctx, &authv1.SignUpRequest{Email: email, Password: password}); err != nil {
+		t.Fatalf("SignUp: %v", err)
+	}
+	if _, err := client.SignUp(ctx, &authv1.SignUpRequest{Email: email, Password: password}); err == nil {
+		t.Fatal("SignUp accepted an existing email")
+	}
+
+	tokens, err := client.SignIn(ctx, &authv1.SignInRequest{Email: email, Password: password})
+	if err != nil {
+		t.Fatalf("SignIn: %v", err)
+	}
+
+	validated, err := client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: tokens.GetAccessToken()})
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if validated.GetUserId() == "" || validated.GetTokenType() != schema.TokenTypeAccess || validated.GetExpiresAt() == nil {
+		t.Fatalf("ValidateToken = %+v, want an access token with a user and an expiry", validated)
+	}
+
+	refreshed, err := client.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: tokens.GetRefreshToken()})
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if _, err := client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: refreshed.GetRefreshToken(), TokenType: schema.TokenTypeRefresh}); err != nil {
+		t.Fatalf("ValidateToken for the refreshed pair: %v", err)
+	}
+
+	if _, err := client.RevokeToken(ctx, &authv1.RevokeTokenRequest{AccessToken: refreshed.GetAccessToken(), Token: refreshed.GetRefreshToken()}); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	if _, err := client.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: refreshed.GetRefreshToken()}); err == nil {
+		t.Fatal("Refresh accepted a revoked refresh token")
+	}
+}
+
+func TestGRPCErrors(t *testing.T) {
+	app := newTestApplication(t, nil)
+	client := app.grpcClient(t)
+	ctx := context.Background()
+
+	accessToken := app.accessToken(t)
+	_, foreignToken, err := app.JWTService.GenerateTokens(schema.Claims{Id: "user-2", Email: "other@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		call      func() error
+		grpcCode  codes.Code
+		errorCode string
+	}{
+		{"sign-up with invalid email", func() error {
+			_, err := client.SignUp(ctx, &authv1.SignUpRequest{Email: "not an email", Password: "correct horse battery staple"})
+			return err
+		}, codes.InvalidArgument, "INVALID_REQUEST"},
+		{"sign-in with unknown user", func() error {
+			_, err := client.SignIn(ctx, &authv1.SignInRequest{Email: "nobody@example.com", Password: "correct horse battery staple"})
+			return err
+		}, codes.Unauthenticated, "INVALID_CREDENTIALS"},
+		{"refresh without token", func() error {
+			_, err := client.Refresh(ctx, &authv1.RefreshRequest{})
+			return err
+		}, codes.Unauthenticated, "INVALID_TOKEN"},
+		{"validate garbage", func() error {
+			_, err := client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: "garbage"})
+			return err
+		}, codes.Unauthenticated, "INVALID_TOKEN"},
+		{"validate unknown token type", func() error {
+			_, err := client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: "garbage", TokenType: "bogus"})
+			return err
+		}, codes.InvalidArgument, "INVALID_REQUEST"},
+		{"revoke without access token", func() error {
+			_, err := client.RevokeToken(ctx, &authv1.RevokeTokenRequest{Token: "garbage"})
+			return err
+		}, codes.Unauthenticated, "INVALID_TOKEN"},
+		{"revoke without token", func() error {
+			_, err := client.RevokeToken(ctx, &authv1.RevokeTokenRequest{AccessToken: accessToken})
+			return err
+		}, codes.InvalidArgument, "INVALID_REQUEST"},
+		{"revoke a foreign token", func() error {
+			_, err := client.RevokeToken(ctx, &authv1.RevokeTokenRequest{AccessToken: accessToken, Token: foreignToken})
+			return err
+		}, codes.InvalidArgument, "INVALID_REQUEST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grpcCode, errorCode := grpcErrorCode(tt.call())
+			if grpcCode != tt.grpcCode || errorCode != tt.errorCode {
+				t.Fatalf("error = %s %q, want %s %q", grpcCode, errorCode, tt.grpcCode, tt.errorCode)
+			}
+		})
+	}
+}
+
+func TestGRPCSignUpDisabledWithLDAP(t *testing.T) {
+	app := newTestApplication(t, nil)
+	app.Config.LDAP.Enabled = true
+	client := app.grpcClient(t)
+
+	_, err := client.SignUp(context.Background(), &authv1.SignUpRequest{Email: "user@example.com", Password: "correct horse battery staple"})
+	if code, _ := grpcErrorCode(err); code != codes.Unimplemented {
+		t.Fatalf("SignUp error = %v, want Unimplemented", err)
+	}
+}
+
+func TestDebugRoutesAreNotOnTheMainRouter(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		app := newTestApplication(t, func(cfg *config.Config) {
+			cfg.Debug.Enabled = enabled
+		})
+
+		for _, path := range []string{"/debug/pprof/", "/debug/runtime"} {
+			if recorder := serve(app.Router, http.MethodGet, path, ""); recorder.Code != http.StatusNotFound {
+				t.Errorf("debug enabled %t: %s status = %d, want %d", enabled, path, recorder.Code, http.StatusNotFound)
+			}
+		}
+	}
+}
+
+func TestCheckLoopback(t *testing.T) {
+	tests := []struct {
+		listen string
+		valid  bool
+	}{
+		{"127.0.0.1:6060", true},
+		{"[::1]:6060", true},
+		{"localhost:6060", true},
+		{"0.0.0.0:6060", false},
+		{":6060", false},
+		{"10.0.0.5:6060", false},
+		{"debug.example.com:6060", false},
+		{"127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if err := checkLoopback(tt.listen); (err == nil) != tt.valid {
+			t.Errorf("checkLoopback(%q) = %v, want valid %t", tt.listen, err, tt.valid)
+		}
+	}
+}
+
+func TestWeakPasswordResponse(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.Security.PasswordEntropy.Enabled = true
+		cfg.Security.PasswordEntropy.MinBits = 40
+	})
+
+	recorder := postJSON(app.Router, "/auth/signup", `{"email":"john.smith@example.com","password":"johnsmith2024"}`)
+	if recorder.Code != http.StatusUnprocessableEntity || errorCode(t, recorder) != customErr.CodeWeakPassword {
+		t.Fatalf("POST /auth/signup = %d %s, want 422 WEAK_PASSWORD", recorder.Code, recorder.Body)
+	}
+
+	var body struct {
+		Details struct {
+			EstimatedBits int      `json:"estimated_bits"`
+			MinBits       int      `json:"min_bits"`
+			Suggestions   []string `json:"suggestions"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Details.EstimatedBits >= 40 || body.Details.MinBits != 40 || !slices.Contains(body.Details.Suggestions, service.SuggestionAvoidPersonal) {
+		t.Fatalf("details = %+v", body.Details)
+	}
+}
+
+func TestPasswordLoginDisabled(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.Security.DisablePasswordLogin = true
+	})
+
+	credentials := `{"email":"user@example.com","password":"` + testPassword + `"}`
+	for _, path := range []string{"/auth/signup", "/auth/signin"} {
+		recorder := postJSON(app.Router, path, credentials)
+		if recorder.Code != http.StatusNotFound || errorCode(t, recorder) != "NOT_FOUND" {
+			t.Errorf("POST %s = %d %s, want 404 NOT_FOUND", path, recorder.Code, recorder.Body)
+		}
+	}
+
+	// Token rotation is not a password flow and stays routed.
+	if recorder := postJSON(app.Router, "/auth/refresh", ""); recorder.Code == http.StatusNotFound {
+		t.Errorf("POST /auth/refresh = %d, want it routed", recorder.Code)
+	}
+
+	client := app.grpcClient(t)
+	if _, err := client.SignUp(context.Background(), &authv1.SignUpRequest{Email: "user@example.com", Password: testPassword}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("gRPC SignUp error = %v, want Unimplemented", err)
+	}
+	if _, err := client.SignIn(context.Background(), &authv1.SignInRequest{Email: "user@example.com", Password: testPassword}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("gRPC SignIn error = %v, want Unimplemented", err)
+	}
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"jwtgo/internal/app/config"
+	"jwtgo/pkg/client"
+)
+
+func newMetricsApplication(t *testing.T) *Application {
+	t.Helper()
+
+	return newTestApplication(t, func(cfg *config.Config) {
+		cfg.Metrics.Enabled = true
+		cfg.Metrics.Path = "/metrics"
+		cfg.Metrics.Listen = ""
+	})
+}
+
+func scrape(t *testing.T, app *Application) string {
+	t.Helper()
+
+	recorder := serve(app.Router, http.MethodGet, "/metrics", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /metrics = %d", recorder.Code)
+	}
+
+	return recorder.Body.String()
+}
+
+func TestPoolStatsAreExported(t *testing.T) {
+	app := newMetricsApplication(t)
+	monitor := client.NewMongodbPoolMonitor(0, app.Logger)
+	handle := monitor.PoolMonitor().Event
+
+	for range 4 {
+		handle(&event.PoolEvent{Type: event.ConnectionCreated})
+	}
+	for range 3 {
+		handle(&event.PoolEvent{Type: event.GetStarted})
+		handle(&event.PoolEvent{Type: event.GetSucceeded, Duration: 500 * time.Millisecond})
+	}
+	handle(&event.PoolEvent{Type: event.GetStarted})
+
+	go app.ReportPoolStats(monitor, 5*time.Millisecond)
+
+	want := []string{
+		`jwtgo_db_pool_connections{state="in_use"} 3`,
+		`jwtgo_db_pool_connections{state="idle"} 1`,
+		`jwtgo_db_pool_connections{state="waiting"} 1`,
+		`jwtgo_db_pool_wait_count 3`,
+		`jwtgo_db_pool_wait_duration_seconds 1.5`,
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		body := scrape(t, app)
+
+		var missing []string
+		for _, line := range want {
+			if !strings.Contains(body, line+"\n") {
+				missing = append(missing, line)
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pool stats were not exported, missing %q", missing)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestMetricNames pins the metric names dashboards and alerts rely on.
+// Renaming one is a breaking change and should show up here.
+func TestMetricNames(t *testing.T) {
+	app := newMetricsApplication(t)
+
+	_, refreshToken := signInCookies(t, app, "metrics@example.com")
+	postJSON(app.Router, "/auth/signin", `{"email":"metrics@example.com","password":"wrong password"}`)
+	if recorder := postJSON(app.Router, "/auth/refresh", "", refreshToken); recorder.Code != http.StatusOK {
+		t.Fatalf("refresh status = %d: %s", recorder.Code, recorder.Body)
+	}
+	postJSON(app.Router, "/auth/refresh", "", refreshToken)
+	serve(app.Router, http.MethodGet, "/auth/me", "not-a-token")
+	// Only the MongoDB repository is timed, and these tests run in memory.
+	app.Metrics.ObserveRepository("get_by_id", time.Now())
+
+	body := scrape(t, app)
+
+	names := []struct {
+		name   string
+		metric string
+	}{
+		{"jwtgo_build_info", "gauge"},
+		{"jwtgo_signups_total", "counter"},
+		{"jwtgo_signin_attempts_total", "counter"},
+		{"jwtgo_refreshes_total", "counter"},
+		{"jwtgo_refresh_token_reuse_total", "counter"},
+		{"jwtgo_password_hash_duration_seconds", "histogram"},
+		{"jwtgo_token_issue_duration_seconds", "histogram"},
+		{"jwtgo_repository_duration_seconds", "histogram"},
+		{"jwtgo_http_request_duration_seconds", "histogram"},
+		{"jwtgo_token_sign_duration_seconds", "histogram"},
+		{"jwtgo_token_verify_duration_seconds", "histogram"},
+		{"jwtgo_token_verification_failures_total", "counter"},
+		{"jwtgo_http_requests_shed_total", "counter"},
+		{"jwtgo_db_pool_wait_count", "gauge"},
+		{"jwtgo_db_pool_wait_duration_seconds", "gauge"},
+	}
+
+	for _, tt := range names {
+		if want := "# TYPE " + tt.name + " " + tt.metric + "\n"; !strings.Contains(body, want) {
+			t.Errorf("scrape is missing %q", strings.TrimSpace(want))
+		}
+	}
+
+	for _, line := range []string{
+		`jwtgo_signups_total{outcome="success"} 1`,
+		`jwtgo_signin_attempts_total{client="web",outcome="success"} 1`,
+		`jwtgo_refreshes_total{outcome="grace_period"} 1`,
+		`jwtgo_refresh_token_reuse_total 0`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("scrape is missing %q", line)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"testing"
+)
+
+const openAPIGolden = "../../api/openapi.json"
+
+var updateOpenAPI = flag.Bool("update-openapi", false, "rewrite api/openapi.json from the served document")
+
+// TestOpenAPIDocumentIsCurrent regenerates the OpenAPI document and diffs it
+// against the committed api/openapi.json. After changing a route or DTO, run
+//
+//	go test ./internal/app -run TestOpenAPIDocumentIsCurrent -update-openapi
+func TestOpenAPIDocumentIsCurrent(t *testing.T) {
+	app := newTestApplication(t, nil)
+
+	recorder := serve(app.Router, http.MethodGet, "/api/v1/openapi.json", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated = append(generated, '\n')
+
+	if *updateOpenAPI {
+		if err := os.WriteFile(openAPIGolden, generated, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	committed, err := os.ReadFile(openAPIGolden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(committed, generated) {
+		t.Fatalf("api/openapi.json is out of date; regenerate it with -update-openapi.\nGenerated document:\n%s", generated)
+	}
+}
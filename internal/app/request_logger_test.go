@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"jwtgo/internal/app/entity"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	"jwtgo/internal/app/service"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+// unavailableUserRepository fails every lookup by id.
+type unavailableUserRepository struct {
+	repositoryInterface.UserRepository
+}
+
+func (unavailableUserRepository) GetById(context.Context, string) (*entity.User, error) {
+	return nil, errors.New("connection refused")
+}
+
+// TestServiceLogCarriesRequestFields checks that a line logged by a service,
+// below the request logger, authentication and the controller, carries the
+// fields those layers put on the request context.
+func TestServiceLogCarriesRequestFields(t *testing.T) {
+	app := newTestApplication(t, nil)
+
+	logger, hook := test.NewNullLogger()
+	serviceLogger := &logging.Logger{Entry: logrus.NewEntry(logger)}
+	app.ExportService = service.NewExportService(unavailableUserRepository{}, nil, serviceLogger.Named("export"))
+
+	// Rebuild the routes so the controller picks up the replaced service.
+	app.InitializeRouter()
+	app.InitializeControllers()
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/me/export", nil)
+	req.Header.Set(request.IdHeader, "test-request-1")
+	if recorder := serveRequest(app.Router, req, app.accessToken(t)); recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusInternalServerError)
+	}
+
+	entries := hook.AllEntries()
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+
+	want := map[string]interface{}{
+		"request_id": "test-request-1",
+		"user_id":    "user-1",
+		"route":      "/auth/me/export",
+		"component":  "export",
+	}
+	for key, value := range want {
+		if entries[0].Data[key] != value {
+			t.Errorf("%s = %v, want %v", key, entries[0].Data[key], value)
+		}
+	}
+}
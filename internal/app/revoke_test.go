@@ -0,0 +1,107 @@
+package app
+
+import (
+	"net/http"
+	"testing"
+)
+
+// signInCookies signs email up and in and returns the token cookies.
+func signInCookies(t *testing.T, app *Application, email string) (accessToken, refreshToken *http.Cookie) {
+	t.Helper()
+
+	credentials := `{"email":"` + email + `","password":"` + testPassword + `"}`
+	postJSON(app.Router, "/auth/signup", credentials)
+
+	recorder := postJSON(app.Router, "/auth/signin", credentials)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("sign-in status = %d: %s", recorder.Code, recorder.Body)
+	}
+
+	return responseCookie(recorder, "access_token"), responseCookie(recorder, "refresh_token")
+}
+
+func revoke(app *Application, accessToken *http.Cookie, token string) int {
+	var cookies []*http.Cookie
+	if accessToken != nil {
+		cookies = append(cookies, accessToken)
+	}
+
+	return postJSON(app.Router, "/auth/revoke", `{"token":"`+token+`"}`, cookies...).Code
+}
+
+func TestRevokeThenUse(t *testing.T) {
+	t.Run("refresh token", func(t *testing.T) {
+		app := newTestApplication(t, nil)
+		accessToken, refreshToken := signInCookies(t, app, "user@example.com")
+
+		if status := revoke(app, accessToken, refreshToken.Value); status != http.StatusOK {
+			t.Fatalf("revoke status = %d, want 200", status)
+		}
+
+		if recorder := postJSON(app.Router, "/auth/refresh", "", refreshToken); recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("refresh with a revoked token = %d, want 401", recorder.Code)
+		}
+		if recorder := serve(app.Router, http.MethodGet, "/auth/me", accessToken.Value); recorder.Code != http.StatusOK {
+			t.Fatalf("GET /auth/me = %d, the access token should stay valid", recorder.Code)
+		}
+	})
+
+	t.Run("access token", func(t *testing.T) {
+		app := newTestApplication(t, nil)
+		accessToken, refreshToken := signInCookies(t, app, "user@example.com")
+
+		if status := revoke(app, accessToken, accessToken.Value); status != http.StatusOK {
+			t.Fatalf("revoke status = %d, want 200", status)
+		}
+
+		if recorder := serve(app.Router, http.MethodGet, "/auth/me", accessToken.Value); recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("GET /auth/me with a revoked token = %d, want 401", recorder.Code)
+		}
+		if status := revoke(app, accessToken, refreshToken.Value); status != http.StatusUnauthorized {
+			t.Fatalf("revoking with a revoked access token = %d, want 401", status)
+		}
+		if recorder := postJSON(app.Router, "/auth/refresh", "", refreshToken); recorder.Code != http.StatusOK {
+			t.Fatalf("refresh = %d, the refresh token should stay valid", recorder.Code)
+		}
+	})
+}
+
+func TestRevokeRejects(t *testing.T) {
+	app := newTestApplication(t, nil)
+	accessToken, refreshToken := signInCookies(t, app, "user@example.com")
+	_, otherRefreshToken := signInCookies(t, app, "other@example.com")
+
+	if status := revoke(app, nil, refreshToken.Value); status != http.StatusUnauthorized {
+		t.Fatalf("revoking without signing in = %d, want 401", status)
+	}
+	if status := revoke(app, accessToken, otherRefreshToken.Value); status != http.StatusBadRequest {
+		t.Fatalf("revoking another user's token = %d, want 400", status)
+	}
+	if recorder := postJSON(app.Router, "/auth/refresh", "", otherRefreshToken); recorder.Code != http.StatusOK {
+		t.Fatalf("the other user's refresh = %d, a rejected revocation must not revoke", recorder.Code)
+	}
+
+	// Tokens that are already unusable are accepted without effect, as in RFC 7009.
+	if status := revoke(app, accessToken, "garbage"); status != http.StatusOK {
+		t.Fatalf("revoking garbage = %d, want 200", status)
+	}
+}
+
+// TestRevocationIsPerInstance pins down the limitation documented on
+// /auth/revoke: another instance sharing the secret keeps accepting the token.
+func TestRevocationIsPerInstance(t *testing.T) {
+	app := newTestApplication(t, nil)
+	other := newTestApplication(t, nil)
+	accessToken, _ := signInCookies(t, app, "user@example.com")
+
+	if status := revoke(app, accessToken, accessToken.Value); status != http.StatusOK {
+		t.Fatalf("revoke status = %d, want 200", status)
+	}
+
+	if _, err := app.JWTService.ParseToken(accessToken.Value); err == nil {
+		t.Fatal("the revoking instance still accepts the token")
+	}
+	if _, err := other.JWTService.ParseToken(accessToken.Value); err != nil {
+		t.Fatalf("the other instance rejects the token: %v", err)
+	}
+}
@@ -1,10 +1,33 @@
 package schema
 
 import (
+	"slices"
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	TokenTypeAccess       = "access"
+	TokenTypeRefresh      = "refresh"
+	TokenTypeConfirmation = "confirmation"
+)
+
 type Claims struct {
-	Id string `json:"sub"`
+	Id         string                 `json:"sub"`
+	TokenType  string                 `json:"token_type"`
+	Email      string                 `json:"email,omitempty"`
+	Roles      []string               `json:"roles,omitempty"`
+	AuthTime   *jwt.NumericDate       `json:"auth_time,omitempty"`
+	Extensions map[string]interface{} `json:"ext,omitempty"`
+	Family     string                 `json:"fid,omitempty"`
 	jwt.RegisteredClaims
 }
+
+func (c *Claims) IsExpired(now time.Time) bool {
+	return c.ExpiresAt != nil && !now.Before(c.ExpiresAt.Time)
+}
+
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jwtgo/internal/app/config"
+	"jwtgo/internal/app/controller/http/dto"
+)
+
+const testPassword = "correct horse battery staple"
+
+func newAuditedApplication(t *testing.T) *Application {
+	t.Helper()
+
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.Audit.Enabled = true
+		cfg.Audit.Path = filepath.Join(t.TempDir(), "audit.log")
+		cfg.Audit.Key = "test-audit-key"
+	})
+	t.Cleanup(func() { app.AuditWriter.Close() })
+
+	return app
+}
+
+// signInAs signs email up if needed and signs in from userAgent, returning the
+// access token cookie.
+func signInAs(t *testing.T, app *Application, email, password, userAgent string) *http.Cookie {
+	t.Helper()
+
+	body := `{"email":"` + email + `","password":"` + password + `"}`
+	postJSON(app.Router, "/auth/signup", `{"email":"`+email+`","password":"`+testPassword+`"}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/signin", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	recorder := httptest.NewRecorder()
+	app.Router.ServeHTTP(recorder, req)
+
+	return responseCookie(recorder, "access_token")
+}
+
+func securityEvents(t *testing.T, app *Application, accessToken *http.Cookie, query string) []dto.SecurityEventDTO {
+	t.Helper()
+
+	recorder := serve(app.Router, http.MethodGet, "/auth/me/security-events"+query, accessToken.Value)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /auth/me/security-events%s = %d %s", query, recorder.Code, recorder.Body)
+	}
+	if recorder.Header().Get("Cache-Control") != "no-store" {
+		t.Fatal("security events may be cached")
+	}
+
+	var body struct {
+		Events []dto.SecurityEventDTO `json:"events"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	return body.Events
+}
+
+func TestSecurityEvents(t *testing.T) {
+	app := newAuditedApplication(t)
+
+	signInAs(t, app, "alice@example.com", "wrong password", "alice-laptop")
+	signInAs(t, app, "alice@example.com", testPassword, "alice-laptop")
+	signInAs(t, app, "bob@example.com", "wrong password", "bob-phone")
+	accessToken := signInAs(t, app, "alice@example.com", testPassword, "alice-phone")
+
+	events := securityEvents(t, app, accessToken, "")
+
+	want := []struct{ outcome, reason, userAgent string }{
+		{"success", "", "alice-phone"},
+		{"success", "", "alice-laptop"},
+		{"failure", "invalid_password", "alice-laptop"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v, want Alice's %d sign-ins only", events, len(want))
+	}
+	for i, event := range events {
+		if event.Action != "signin" || event.Outcome != want[i].outcome || event.Reason != want[i].reason || event.UserAgent != want[i].userAgent {
+			t.Errorf("event %d = %+v, want %+v", i, event, want[i])
+		}
+		if event.IP == "" || event.Time.IsZero() {
+			t.Errorf("event %d has no IP or time: %+v", i, event)
+		}
+		if i > 0 && event.Time.After(events[i-1].Time) {
+			t.Errorf("event %d is newer than event %d", i, i-1)
+		}
+	}
+
+	if limited := securityEvents(t, app, accessToken, "?limit=1"); len(limited) != 1 || limited[0] != events[0] {
+		t.Fatalf("limit=1 returned %+v, want the newest event", limited)
+	}
+}
+
+func TestSecurityEventsOmitInternalFields(t *testing.T) {
+	app := newAuditedApplication(t)
+	accessToken := signInAs(t, app, "alice@example.com", testPassword, "alice-laptop")
+
+	recorder := serve(app.Router, http.MethodGet, "/auth/me/security-events", accessToken.Value)
+
+	var body struct {
+		Events []map[string]interface{} `json:"events"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || len(body.Events) == 0 {
+		t.Fatalf("response = %s", recorder.Body)
+	}
+	for key := range body.Events[0] {
+		switch key {
+		case "action", "outcome", "reason", "ip", "user_agent", "time":
+		default:
+			t.Errorf("event exposes %q", key)
+		}
+	}
+}
+
+func TestSecurityEventsRejects(t *testing.T) {
+	app := newAuditedApplication(t)
+	accessToken := signInAs(t, app, "alice@example.com", testPassword, "alice-laptop")
+
+	tests := []struct {
+		name        string
+		query       string
+		accessToken string
+		status      int
+	}{
+		{"no token", "", "", http.StatusUnauthorized},
+		{"zero limit", "?limit=0", accessToken.Value, http.StatusBadRequest},
+		{"negative limit", "?limit=-1", accessToken.Value, http.StatusBadRequest},
+		{"non-numeric limit", "?limit=all", accessToken.Value, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if recorder := serve(app.Router, http.MethodGet, "/auth/me/security-events"+tt.query, tt.accessToken); recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestSecurityEventsNeedAuditLog(t *testing.T) {
+	app := newTestApplication(t, nil)
+
+	if recorder := serve(app.Router, http.MethodGet, "/auth/me/security-events", app.accessToken(t)); recorder.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404 without an audit log", recorder.Code)
+	}
+}
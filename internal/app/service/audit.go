@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"jwtgo/internal/pkg/audit"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/pkg/logging"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+type AuditService struct {
+	writer *audit.Writer
+	recent *audit.Recent
+	logger *logging.Logger
+}
+
+func NewAuditService(writer *audit.Writer, recent *audit.Recent, logger *logging.Logger) *AuditService {
+	writer.SetRecent(recent)
+
+	return &AuditService{
+		writer: writer,
+		recent: recent,
+		logger: logger,
+	}
+}
+
+func (s *AuditService) Record(ctx context.Context, event audit.Event) {
+	fields := make(map[string]string, len(event.Fields)+3)
+	for key, value := range event.Fields {
+		fields[key] = value
+	}
+	if requestId, ok := logging.FromContext(ctx).Data["request_id"]; ok {
+		fields["request_id"] = fmt.Sprint(requestId)
+	}
+	if ip := request.ClientIP(ctx); ip != "" {
+		fields["ip"] = ip
+	}
+	if userAgent := request.UserAgent(ctx); userAgent != "" {
+		fields["user_agent"] = userAgent
+	}
+	if len(fields) > 0 {
+		event.Fields = fields
+	}
+
+	if err := s.writer.Write(event); err != nil {
+		s.logger.ForContext(ctx).Error("Error while writing audit record: ", err)
+	}
+}
+
+func (s *AuditService) Recent(userId string, limit int) []audit.Record {
+	return s.recent.List(userId, limit)
+}
@@ -2,142 +2,588 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"jwtgo/internal/app/controller/http/dto"
 	"jwtgo/internal/app/controller/http/mapper"
+	"jwtgo/internal/app/entity"
 	customErr "jwtgo/internal/app/error"
 	repositoryInterface "jwtgo/internal/app/interface/repository"
 	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/app/schema"
+	"jwtgo/internal/pkg/audit"
+	"jwtgo/internal/pkg/metrics"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/internal/pkg/security"
+	"jwtgo/internal/pkg/tracing"
 	"jwtgo/pkg/logging"
 )
 
+const (
+	signInOutcomeSuccess     = "success"
+	signInOutcomeBadPassword = "bad_password"
+	signInOutcomeUnknownUser = "unknown_user"
+	signInOutcomeError       = "error"
+
+	// Tokens are only delivered as cookies for now, so every sign-in is
+	// attributed to the web client.
+	signInClientWeb = "web"
+)
+
 type AuthService struct {
-	userRepository  repositoryInterface.UserRepository
-	jwtService      serviceInterface.JWTService
-	passwordService serviceInterface.PasswordService
-	logger          *logging.Logger
+	userRepository    repositoryInterface.UserRepository
+	jwtService        serviceInterface.JWTService
+	passwordService   serviceInterface.PasswordService
+	authBackend       serviceInterface.AuthBackend
+	emailDomainPolicy serviceInterface.EmailDomainPolicy
+	disposableChecker serviceInterface.DisposableEmailChecker
+	passwordStrength  serviceInterface.PasswordStrengthPolicy
+	signupLimiter     serviceInterface.SignupLimiter
+	refreshBinder     serviceInterface.NetworkBinder
+	identityVerifier  serviceInterface.IdentityVerifier
+	singleSession     bool
+	refreshGrace      time.Duration
+	auditLogger       serviceInterface.AuditLogger
+	securityNotifier  serviceInterface.SecurityNotifier
+	metrics           *metrics.Metrics
+	logger            *logging.Logger
+
+	refreshFlights singleflight.Group
+
+	rotationsMu sync.Mutex
+	rotations   map[string]rotation
+}
+
+// rotation is the token pair a refresh token was rotated into, kept for the
+// grace period so late duplicates of the same refresh get the same pair.
+type rotation struct {
+	tokens    *dto.UserTokensDTO
+	expiresAt time.Time
+}
+
+// AuthServiceOptions holds the optional collaborators and settings of an
+// AuthService. A nil collaborator switches its check off.
+type AuthServiceOptions struct {
+	DisposableChecker serviceInterface.DisposableEmailChecker
+	PasswordStrength  serviceInterface.PasswordStrengthPolicy
+	SignupLimiter     serviceInterface.SignupLimiter
+	RefreshBinder     serviceInterface.NetworkBinder
+	IdentityVerifier  serviceInterface.IdentityVerifier
+	AuditLogger       serviceInterface.AuditLogger
+	SecurityNotifier  serviceInterface.SecurityNotifier
+	SingleSession     bool
+	// RefreshGracePeriod is how long a rotated refresh token keeps answering
+	// with the pair it was rotated into.
+	RefreshGracePeriod time.Duration
 }
 
 func NewAuthService(
 	userRepository repositoryInterface.UserRepository,
 	jwtService serviceInterface.JWTService,
 	passwordService serviceInterface.PasswordService,
+	authBackend serviceInterface.AuthBackend,
+	emailDomainPolicy serviceInterface.EmailDomainPolicy,
+	options AuthServiceOptions,
+	metrics *metrics.Metrics,
 	logger *logging.Logger,
 ) *AuthService {
 	return &AuthService{
-		userRepository:  userRepository,
-		jwtService:      jwtService,
-		passwordService: passwordService,
-		logger:          logger,
+		userRepository:    userRepository,
+		jwtService:        jwtService,
+		passwordService:   passwordService,
+		authBackend:       authBackend,
+		emailDomainPolicy: emailDomainPolicy,
+		disposableChecker: options.DisposableChecker,
+		passwordStrength:  options.PasswordStrength,
+		signupLimiter:     options.SignupLimiter,
+		refreshBinder:     options.RefreshBinder,
+		identityVerifier:  options.IdentityVerifier,
+		singleSession:     options.SingleSession,
+		refreshGrace:      options.RefreshGracePeriod,
+		auditLogger:       options.AuditLogger,
+		securityNotifier:  options.SecurityNotifier,
+		metrics:           metrics,
+		logger:            logger,
+		rotations:         make(map[string]rotation),
 	}
 }
 
-func (s *AuthService) SignUp(ctx context.Context, userCredentialsDTO *dto.UserCredentialsDTO) (bool, error) {
+func (s *AuthService) SignUp(ctx context.Context, userCredentialsDTO *dto.UserCredentialsDTO) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.SignUp")
+	defer func() { tracing.End(span, err) }()
+
+	// The slot is reserved up front so that parallel sign-ups from one address
+	// cannot all pass the check, and handed back if this one fails.
+	if clientIP := request.ClientIP(ctx); s.signupLimiter != nil && clientIP != "" {
+		release, allowed, retryAfter := s.signupLimiter.Reserve(clientIP)
+		if !allowed {
+			s.metrics.SignUp("cooldown")
+			return false, customErr.NewSignupCooldownError("Signup cooldown is active", retryAfter)
+		}
+		defer func() {
+			if err != nil {
+				release()
+			}
+		}()
+	}
+
+	if !s.emailDomainPolicy.IsAllowed(userCredentialsDTO.Email) {
+		s.metrics.SignUp("disallowed_domain")
+		return false, customErr.NewDisallowedEmailDomainError("Email domain is not allowed", userCredentialsDTO.Email)
+	}
+
+	if s.disposableChecker != nil && s.disposableChecker.IsDisposable(userCredentialsDTO.Email) {
+		s.metrics.SignUp("disposable_email")
+		return false, customErr.NewDisposableEmailError("Disposable email addresses are not allowed", userCredentialsDTO.Email)
+	}
+
+	if s.passwordStrength != nil {
+		if err := s.passwordStrength.Check(userCredentialsDTO.Password, userCredentialsDTO.Email); err != nil {
+			s.metrics.SignUp("weak_password")
+			return false, err
+		}
+	}
+
 	existingUserEntity, err := s.userRepository.GetByEmail(ctx, userCredentialsDTO.Email)
 	if err != nil {
-		s.logger.Error("Error while getting user: ", err)
-		return false, customErr.NewInternalServerError("Failed to check user email")
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		s.metrics.SignUp("error")
+		return false, customErr.NewInternalServerError("Failed to check user email", err)
 	}
 
 	if existingUserEntity != nil {
-		return false, customErr.NewAlreadyExistsError("Email already exists")
+		s.metrics.SignUp("already_exists")
+		return false, customErr.NewAlreadyExistsError("Email already exists", userCredentialsDTO.Email)
 	}
 
 	localSalt, err := s.passwordService.GenerateSalt(32)
 	if err != nil {
-		s.logger.Error("Error while generating local salt: ", err)
-		return false, customErr.NewInternalServerError("Failed to create a user")
+		s.logger.ForContext(ctx).Error("Error while generating local salt: ", err)
+		s.metrics.SignUp("error")
+		return false, customErr.NewInternalServerError("Failed to create a user", err)
 	}
 
 	hashedPassword, err := s.passwordService.HashPassword(userCredentialsDTO.Password, localSalt)
 	if err != nil {
-		s.logger.Error("Error while hashing password: ", err)
-		return false, customErr.NewInternalServerError("Failed to create a user")
+		s.logger.ForContext(ctx).Error("Error while hashing password: ", err)
+		s.metrics.SignUp("error")
+		return false, customErr.NewInternalServerError("Failed to create a user", err)
 	}
 
 	userCredentialsDTO.Password = hashedPassword
 
 	userCreateEntity := mapper.MapUserCredentialsDTOToDomainUser(userCredentialsDTO)
 	userCreateEntity.Salt = localSalt
+	if s.identityVerifier != nil {
+		userCreateEntity.Status = entity.UserStatusPendingVerification
+	}
 
 	_, err = s.userRepository.Create(ctx, userCreateEntity)
 	if err != nil {
-		s.logger.Error("Error while creating user: ", err)
-		return false, customErr.NewInternalServerError("Failed to create a user")
+		s.logger.ForContext(ctx).Error("Error while creating user: ", err)
+		s.metrics.SignUp("error")
+		return false, customErr.NewInternalServerError("Failed to create a user", err)
 	}
 
+	if s.identityVerifier != nil {
+		if err := s.startVerification(ctx, userCreateEntity.Email); err != nil {
+			s.metrics.SignUp("error")
+			return false, err
+		}
+	}
+
+	s.metrics.SignUp("success")
+
 	return true, nil
 }
 
-func (s *AuthService) SignIn(ctx context.Context, userCredentialsDTO *dto.UserCredentialsDTO) (*dto.UserTokensDTO, error) {
-	existingUserEntity, err := s.userRepository.GetByEmail(ctx, userCredentialsDTO.Email)
-	if err != nil {
-		s.logger.Error("Error while getting user: ", err)
-		return nil, customErr.NewInternalServerError("Failed to check user email")
-	}
+func (s *AuthService) SignIn(ctx context.Context, userCredentialsDTO *dto.UserCredentialsDTO) (_ *dto.UserTokensDTO, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.SignIn")
+	defer func() { tracing.End(span, err) }()
 
-	if existingUserEntity == nil {
-		return nil, customErr.NewInvalidCredentialsError("Invalid login or password")
+	existingUserEntity, err := s.authBackend.Authenticate(ctx, userCredentialsDTO.Email, userCredentialsDTO.Password)
+	switch {
+	case errors.Is(err, errUnknownUser):
+		s.logger.ForContext(ctx).Sampled("signin_failure").Warn("Sign-in failed: unknown email")
+		s.metrics.SignIn(signInOutcomeUnknownUser, signInClientWeb)
+		s.audit(ctx, "signin", "", AuditOutcomeFailure, map[string]string{"reason": "unknown_email", "email": userCredentialsDTO.Email})
+		return nil, customErr.NewInvalidCredentialsError("Invalid login or password", userCredentialsDTO.Email)
+	case errors.Is(err, errBadPassword):
+		// A directory user who never signed in has no local record yet.
+		userId := ""
+		if existingUserEntity != nil {
+			userId = existingUserEntity.Id
+		}
+
+		s.logger.ForContext(ctx).Sampled("signin_failure").Warn("Sign-in failed: invalid password for user ", userId)
+		s.metrics.SignIn(signInOutcomeBadPassword, signInClientWeb)
+		s.audit(ctx, "signin", userId, AuditOutcomeFailure, map[string]string{"reason": "invalid_password"})
+		return nil, customErr.NewInvalidCredentialsError("Invalid login or password", userCredentialsDTO.Email)
+	case err != nil:
+		s.logger.ForContext(ctx).Error("Error while authenticating user: ", err)
+		s.metrics.SignIn(signInOutcomeError, signInClientWeb)
+		return nil, err
 	}
 
-	passwordIsValid := s.passwordService.VerifyPassword(userCredentialsDTO.Password, existingUserEntity.Password, existingUserEntity.Salt)
-	if !passwordIsValid {
-		return nil, customErr.NewInvalidCredentialsError("Invalid login or password")
+	span.SetAttributes(tracing.UserId(existingUserEntity.Id))
+
+	if err := checkAccountStatus(existingUserEntity); err != nil {
+		s.metrics.SignIn(existingUserEntity.Status, signInClientWeb)
+		s.audit(ctx, "signin", existingUserEntity.Id, AuditOutcomeFailure, map[string]string{"reason": existingUserEntity.Status})
+		return nil, err
 	}
 
-	accessToken, refreshToken, err := s.jwtService.GenerateTokens(existingUserEntity.Id)
+	existingUserEntity.AuthTime = time.Now().UTC()
+
+	userTokensDTO, err := s.IssueTokens(ctx, existingUserEntity)
 	if err != nil {
-		s.logger.Error("Error while generating tokens: ", err)
-		return nil, customErr.NewInternalServerError("Token generation error")
+		s.metrics.SignIn(signInOutcomeError, signInClientWeb)
+		return nil, err
 	}
 
-	existingUserEntity.RefreshToken = refreshToken
-	existingUserEntity.UpdatedAt = time.Now().UTC()
+	s.metrics.SignIn(signInOutcomeSuccess, signInClientWeb)
+	s.audit(ctx, "signin", existingUserEntity.Id, AuditOutcomeSuccess, nil)
 
-	_, err = s.userRepository.Update(ctx, existingUserEntity.Id, existingUserEntity)
+	return userTokensDTO, nil
+}
+
+// Refresh rotates a refresh token. Concurrent calls with the same token share
+// a single rotation and all receive its token pair, and so do calls arriving
+// within the grace period after it, so a burst of refreshes from one client is
+// not mistaken for token reuse. A superseded token that is presented later is
+// reuse and revokes the whole token family.
+func (s *AuthService) Refresh(ctx context.Context, refreshTokenDTO *dto.UserRefreshTokenDTO) (*dto.UserTokensDTO, error) {
+	tokenHash := sha256.Sum256([]byte(refreshTokenDTO.RefreshToken))
+	key := hex.EncodeToString(tokenHash[:])
+
+	result, err, _ := s.refreshFlights.Do(key, func() (interface{}, error) {
+		if tokens, ok := s.recentRotation(key); ok {
+			s.metrics.Refresh("grace_period")
+			return tokens, nil
+		}
+
+		tokens, err := s.refresh(context.WithoutCancel(ctx), refreshTokenDTO)
+		if err == nil {
+			s.rememberRotation(key, tokens)
+		}
+		return tokens, err
+	})
 	if err != nil {
-		s.logger.Error("Error while updating user: ", err)
-		return nil, customErr.NewInternalServerError("Token updating error")
+		return nil, err
 	}
 
-	return mapper.MapToUserTokensDTO(accessToken, refreshToken), nil
+	return result.(*dto.UserTokensDTO), nil
 }
 
-func (s *AuthService) Refresh(ctx context.Context, refreshTokenDTO *dto.UserRefreshTokenDTO) (*dto.UserTokensDTO, error) {
-	claims, err := s.jwtService.ValidateToken(refreshTokenDTO.RefreshToken)
+func (s *AuthService) recentRotation(key string) (*dto.UserTokensDTO, bool) {
+	s.rotationsMu.Lock()
+	defer s.rotationsMu.Unlock()
+
+	rotated, ok := s.rotations[key]
+	if !ok || !time.Now().Before(rotated.expiresAt) {
+		return nil, false
+	}
+
+	return rotated.tokens, true
+}
+
+func (s *AuthService) rememberRotation(key string, tokens *dto.UserTokensDTO) {
+	if s.refreshGrace <= 0 {
+		return
+	}
+
+	s.rotationsMu.Lock()
+	defer s.rotationsMu.Unlock()
+
+	now := time.Now()
+	for oldKey, rotated := range s.rotations {
+		if !now.Before(rotated.expiresAt) {
+			delete(s.rotations, oldKey)
+		}
+	}
+
+	s.rotations[key] = rotation{tokens: tokens, expiresAt: now.Add(s.refreshGrace)}
+}
+
+func (s *AuthService) refresh(ctx context.Context, refreshTokenDTO *dto.UserRefreshTokenDTO) (_ *dto.UserTokensDTO, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Refresh")
+	defer func() { tracing.End(span, err) }()
+
+	claims, err := s.jwtService.ValidateToken(refreshTokenDTO.RefreshToken, schema.TokenTypeRefresh)
 	if err != nil {
+		s.metrics.Refresh("invalid_token")
 		return nil, err
 	}
 
+	span.SetAttributes(tracing.UserId(claims.Id))
+
 	existingUserEntity, err := s.userRepository.GetById(ctx, claims.Id)
 	if err != nil {
-		s.logger.Error("Error while getting user: ", err)
-		return nil, customErr.NewInternalServerError("Failed to check user id")
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		s.metrics.Refresh("error")
+		return nil, customErr.NewInternalServerError("Failed to check user id", err)
 	}
 
 	if existingUserEntity == nil {
-		return nil, customErr.NewUserNotFoundError("User not found")
+		s.metrics.Refresh("user_not_found")
+		s.audit(ctx, "refresh", claims.Id, AuditOutcomeFailure, map[string]string{"reason": "user_not_found"})
+		return nil, customErr.NewUserNotFoundError("User not found", claims.Id)
+	}
+
+	if refreshTokenDTO.RefreshToken != existingUserEntity.RefreshToken && !s.sameTokenFamily(claims, existingUserEntity.RefreshToken) {
+		s.metrics.Refresh("superseded_session")
+		s.audit(ctx, "refresh", claims.Id, AuditOutcomeFailure, map[string]string{"reason": "superseded_session"})
+		return nil, customErr.NewInvalidTokenError("Invalid refresh token", claims.ID)
 	}
 
 	if refreshTokenDTO.RefreshToken != existingUserEntity.RefreshToken {
-		return nil, customErr.NewInvalidTokenError("Invalid refresh token")
+		s.metrics.Refresh("superseded_token")
+		s.metrics.RefreshReuse()
+		s.audit(ctx, "refresh", claims.Id, AuditOutcomeFailure, map[string]string{"reason": "superseded_token"})
+		s.revokeTokenFamily(ctx, existingUserEntity)
+		s.notify(ctx, security.Event{
+			Type:     security.EventRefreshTokenReuse,
+			Severity: security.SeverityHigh,
+			UserId:   claims.Id,
+			Fields:   map[string]string{"jti": claims.ID, "family_revoked": "true"},
+		})
+		return nil, customErr.NewInvalidTokenError("Invalid refresh token", claims.ID)
+	}
+
+	if s.refreshBinder != nil && existingUserEntity.RefreshNetwork != "" {
+		if network := s.refreshBinder.Network(request.ClientIP(ctx)); network != existingUserEntity.RefreshNetwork {
+			s.metrics.Refresh("network_mismatch")
+			s.audit(ctx, "refresh", claims.Id, AuditOutcomeFailure, map[string]string{"reason": "network_mismatch", "network": network})
+			return nil, customErr.NewTokenBindingError("Refresh token was issued to a different network", claims.ID)
+		}
+	}
+
+	userTokensDTO, err := s.issueTokens(ctx, existingUserEntity, claims)
+	if err != nil {
+		s.metrics.Refresh("error")
+		return nil, err
+	}
+
+	s.metrics.Refresh("success")
+	s.audit(ctx, "refresh", existingUserEntity.Id, AuditOutcomeSuccess, nil)
+
+	return userTokensDTO, nil
+}
+
+func (s *AuthService) Reauthenticate(ctx context.Context, userId string, reauthenticateDTO *dto.ReauthenticateDTO) (_ *dto.ConfirmationTokenDTO, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Reauthenticate", tracing.UserId(userId))
+	defer func() { tracing.End(span, err) }()
+
+	existingUserEntity, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to check user id", err)
 	}
 
-	accessToken, refreshToken, err := s.jwtService.GenerateTokens(existingUserEntity.Id)
+	if existingUserEntity == nil {
+		return nil, customErr.NewUserNotFoundError("User not found", userId)
+	}
+
+	authenticatedUserEntity, err := s.authBackend.Authenticate(ctx, existingUserEntity.Email, reauthenticateDTO.Password)
+	if err != nil && !errors.Is(err, errUnknownUser) && !errors.Is(err, errBadPassword) {
+		s.logger.ForContext(ctx).Error("Error while authenticating user: ", err)
+		return nil, err
+	}
+
+	if err != nil || authenticatedUserEntity.Id != existingUserEntity.Id {
+		s.logger.ForContext(ctx).Sampled("signin_failure").Warn("Reauthentication failed: invalid password for user ", existingUserEntity.Id)
+		s.audit(ctx, "reauthenticate", existingUserEntity.Id, AuditOutcomeFailure, map[string]string{"reason": "invalid_password"})
+		return nil, customErr.NewInvalidCredentialsError("Invalid password", existingUserEntity.Email)
+	}
+
+	now := time.Now().UTC()
+	existingUserEntity.AuthTime = now
+	existingUserEntity.UpdatedAt = now
+
+	_, err = s.userRepository.Update(ctx, existingUserEntity.Id, existingUserEntity)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while updating user: ", err)
+		return nil, customErr.NewInternalServerError("Auth time updating error", err)
+	}
+
+	confirmationToken, err := s.jwtService.GenerateConfirmationToken(existingUserEntity.Id, now)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while generating confirmation token: ", err)
+		return nil, customErr.NewInternalServerError("Token generation error", err)
+	}
+
+	s.audit(ctx, "reauthenticate", existingUserEntity.Id, AuditOutcomeSuccess, nil)
+
+	return mapper.MapToConfirmationTokenDTO(confirmationToken), nil
+}
+
+// Revoke follows RFC 7009: a token that is already invalid or expired needs no
+// revocation, so it is accepted without an error.
+func (s *AuthService) Revoke(ctx context.Context, userId string, revokeTokenDTO *dto.RevokeTokenDTO) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Revoke", tracing.UserId(userId))
+	defer func() { tracing.End(span, err) }()
+
+	claims, err := s.jwtService.ParseToken(revokeTokenDTO.Token)
+	if err != nil {
+		return nil
+	}
+
+	if claims.Id != userId {
+		s.audit(ctx, "revoke_token", userId, AuditOutcomeFailure, map[string]string{"reason": "foreign_token", "jti": claims.ID})
+		return customErr.NewInvalidRequestError("Token was not issued to the authenticated user")
+	}
+
+	if claims.ID == "" {
+		return customErr.NewInvalidRequestError("Token was issued before revocation was supported")
+	}
+
+	s.jwtService.RevokeToken(claims)
+	s.audit(ctx, "revoke_token", userId, AuditOutcomeSuccess, map[string]string{"jti": claims.ID, "token_type": claims.TokenType})
+
+	return nil
+}
+
+// IssueTokens starts a new session for user. In single-session mode every
+// token issued to the user before it is revoked.
+func (s *AuthService) IssueTokens(ctx context.Context, user *entity.User) (*dto.UserTokensDTO, error) {
+	return s.issueTokens(ctx, user, nil)
+}
+
+// issueTokens rotates the refresh token described by refreshed, or starts a
+// new session when refreshed is nil.
+func (s *AuthService) issueTokens(ctx context.Context, user *entity.User, refreshed *schema.Claims) (_ *dto.UserTokensDTO, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.IssueTokens", tracing.UserId(user.Id))
+	defer func() { tracing.End(span, err) }()
+
+	defer s.metrics.ObserveTokenIssue(time.Now())
+
+	if err := checkAccountStatus(user); err != nil {
+		return nil, err
+	}
+
+	if refreshed == nil && s.singleSession && user.RefreshToken != "" {
+		s.jwtService.RevokeSubject(user.Id)
+		s.audit(ctx, "revoke_sessions", user.Id, AuditOutcomeSuccess, map[string]string{"reason": "single_session"})
+		s.notify(ctx, security.Event{
+			Type:     security.EventSessionsRevoked,
+			Severity: security.SeverityLow,
+			UserId:   user.Id,
+			Fields:   map[string]string{"reason": "single_session"},
+		})
+	}
+
+	subject := schema.Claims{Id: user.Id, Email: user.Email, Roles: user.Roles}
+	if refreshed != nil {
+		subject.Family = refreshed.Family
+	}
+
+	accessToken, refreshToken, err := s.jwtService.GenerateTokens(subject)
 	if err != nil {
-		s.logger.Error("Error while generating tokens: ", err)
-		return nil, customErr.NewInternalServerError("Token generation error")
+		s.logger.ForContext(ctx).Error("Error while generating tokens: ", err)
+		return nil, customErr.NewInternalServerError("Token generation error", err)
 	}
 
-	existingUserEntity.RefreshToken = refreshToken
-	existingUserEntity.UpdatedAt = time.Now().UTC()
+	user.RefreshToken = refreshToken
+	user.RefreshNetwork = ""
+	if s.refreshBinder != nil {
+		user.RefreshNetwork = s.refreshBinder.Network(request.ClientIP(ctx))
+	}
+	user.UpdatedAt = time.Now().UTC()
 
-	_, err = s.userRepository.Update(ctx, claims.Id, existingUserEntity)
+	_, err = s.userRepository.Update(ctx, user.Id, user)
 	if err != nil {
-		s.logger.Error("Error while updating user: ", err)
-		return nil, customErr.NewInternalServerError("Token updating error")
+		s.logger.ForContext(ctx).Error("Error while updating user: ", err)
+		return nil, customErr.NewInternalServerError("Token updating error", err)
+	}
+
+	userTokensDTO := mapper.MapToUserTokensDTO(accessToken, refreshToken)
+	userTokensDTO.Roles = user.Roles
+
+	return userTokensDTO, nil
+}
+
+// sameTokenFamily reports whether claims and the current refresh token were
+// rotated from the same sign-in. Only then is presenting an old token reuse;
+// otherwise a later sign-in, possibly on another device, replaced it.
+func (s *AuthService) sameTokenFamily(claims *schema.Claims, currentToken string) bool {
+	if claims.Family == "" {
+		return false
+	}
+
+	current, err := s.jwtService.ParseToken(currentToken)
+	return err == nil && current.Family == claims.Family
+}
+
+// revokeTokenFamily ends the session of user after a superseded refresh token
+// was presented. Either party may hold a stolen token, so the current refresh
+// token and every token issued to user before this second are revoked.
+func (s *AuthService) revokeTokenFamily(ctx context.Context, user *entity.User) {
+	s.jwtService.RevokeSubject(user.Id)
+	if claims, err := s.jwtService.ParseToken(user.RefreshToken); err == nil {
+		s.jwtService.RevokeToken(claims)
+	}
+
+	s.audit(ctx, "revoke_sessions", user.Id, AuditOutcomeSuccess, map[string]string{"reason": "refresh_token_reuse"})
+}
+
+// startVerification hands a new account to the identity verifier. If the
+// provider cannot be reached the account is removed again, so the user can
+// retry the sign-up with the same email.
+func (s *AuthService) startVerification(ctx context.Context, email string) error {
+	createdUser, approved, err := startVerification(ctx, s.userRepository, s.identityVerifier, email, s.logger)
+	if err != nil {
+		return err
+	}
+
+	s.audit(ctx, "identity_verification_started", createdUser.Id, AuditOutcomeSuccess, map[string]string{"approved": strconv.FormatBool(approved)})
+
+	return nil
+}
+
+func checkAccountStatus(user *entity.User) error {
+	switch user.Status {
+	case entity.UserStatusPendingVerification:
+		return customErr.NewAccountPendingError("Account is pending identity verification", user.Id)
+	case entity.UserStatusRejected:
+		return customErr.NewAccountRejectedError("Account failed identity verification", user.Id)
+	default:
+		return nil
+	}
+}
+
+func (s *AuthService) audit(ctx context.Context, action, userId, outcome string, fields map[string]string) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	s.auditLogger.Record(ctx, audit.Event{Action: action, UserId: userId, Outcome: outcome, Fields: fields})
+}
+
+func (s *AuthService) notify(ctx context.Context, event security.Event) {
+	if s.securityNotifier == nil {
+		return
+	}
+
+	event.Time = time.Now().UTC()
+	if requestId, ok := logging.FromContext(ctx).Data["request_id"]; ok {
+		if event.Fields == nil {
+			event.Fields = map[string]string{}
+		}
+		event.Fields["request_id"] = fmt.Sprint(requestId)
 	}
 
-	return mapper.MapToUserTokensDTO(accessToken, refreshToken), nil
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.securityNotifier.Notify(ctx, event); err != nil {
+			s.logger.ForContext(ctx).Warn("Error while sending security notification: ", err)
+		}
+	}()
 }
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	serviceInterface "jwtgo/internal/app/interface/service"
+)
+
+// Backends report rejected credentials with these errors so AuthService can
+// keep its sign-in outcomes and audit reasons independent of the backend.
+var (
+	errUnknownUser = errors.New("unknown user")
+	errBadPassword = errors.New("bad password")
+)
+
+type LocalAuthBackend struct {
+	userResolver    serviceInterface.UserResolver
+	passwordService serviceInterface.PasswordService
+}
+
+func NewLocalAuthBackend(userResolver serviceInterface.UserResolver, passwordService serviceInterface.PasswordService) *LocalAuthBackend {
+	return &LocalAuthBackend{
+		userResolver:    userResolver,
+		passwordService: passwordService,
+	}
+}
+
+func (b *LocalAuthBackend) Authenticate(ctx context.Context, identifier, password string) (*entity.User, error) {
+	existingUserEntity, err := b.userResolver.FindUserByIdentifier(ctx, identifier)
+	if errors.Is(err, customErr.ErrAmbiguousIdentifier) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, customErr.NewInternalServerError("Failed to check user email", err)
+	}
+
+	if existingUserEntity == nil {
+		return nil, errUnknownUser
+	}
+
+	if !b.passwordService.VerifyPassword(password, existingUserEntity.Password, existingUserEntity.Salt) {
+		return existingUserEntity, errBadPassword
+	}
+
+	return existingUserEntity, nil
+}
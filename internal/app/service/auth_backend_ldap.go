@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	"jwtgo/pkg/logging"
+)
+
+type LDAPOptions struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	UserAttribute      string
+	EmailAttribute     string
+	Timeout            time.Duration
+	PoolSize           int
+}
+
+// LDAPAuthBackend checks passwords against a directory: it binds as the
+// service account, finds the user's DN by attribute and binds as the user.
+// A local user without a password hash is created on the first successful
+// sign-in so tokens and audit work unchanged.
+type LDAPAuthBackend struct {
+	options        LDAPOptions
+	pool           chan *ldap.Conn
+	userRepository repositoryInterface.UserRepository
+	logger         *logging.Logger
+}
+
+func NewLDAPAuthBackend(options LDAPOptions, userRepository repositoryInterface.UserRepository, logger *logging.Logger) *LDAPAuthBackend {
+	return &LDAPAuthBackend{
+		options:        options,
+		pool:           make(chan *ldap.Conn, options.PoolSize),
+		userRepository: userRepository,
+		logger:         logger,
+	}
+}
+
+func (b *LDAPAuthBackend) Authenticate(ctx context.Context, email, password string) (*entity.User, error) {
+	// An empty password would be an unauthenticated bind, which servers accept.
+	if password == "" {
+		return nil, errBadPassword
+	}
+
+	directoryEmail, err := b.verify(email, password)
+	if err != nil {
+		if errors.Is(err, errBadPassword) {
+			existingUserEntity, _ := b.userRepository.GetByEmail(ctx, email)
+			return existingUserEntity, errBadPassword
+		}
+		return nil, err
+	}
+
+	existingUserEntity, err := b.userRepository.GetByEmail(ctx, directoryEmail)
+	if err != nil {
+		return nil, customErr.NewInternalServerError("Failed to check user email", err)
+	}
+
+	if existingUserEntity != nil {
+		return existingUserEntity, nil
+	}
+
+	return b.createShadowUser(ctx, directoryEmail)
+}
+
+func (b *LDAPAuthBackend) verify(email, password string) (string, error) {
+	conn, err := b.acquire()
+	if err != nil {
+		return "", customErr.NewInternalServerError("Directory is unavailable", err)
+	}
+
+	reusable := false
+	defer func() { b.release(conn, reusable) }()
+
+	if err = conn.Bind(b.options.BindDN, b.options.BindPassword); err != nil {
+		return "", customErr.NewInternalServerError("Directory service bind failed", err)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		b.options.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(b.options.Timeout.Seconds()), false,
+		fmt.Sprintf("(%s=%s)", b.options.UserAttribute, ldap.EscapeFilter(email)),
+		[]string{b.options.EmailAttribute},
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return "", customErr.NewInternalServerError("Directory search failed", err)
+	}
+	if result == nil || len(result.Entries) != 1 {
+		reusable = true
+		return "", errUnknownUser
+	}
+
+	entry := result.Entries[0]
+	if err = conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			reusable = true
+			return "", errBadPassword
+		}
+		return "", customErr.NewInternalServerError("Directory user bind failed", err)
+	}
+	reusable = true
+
+	directoryEmail := strings.ToLower(entry.GetAttributeValue(b.options.EmailAttribute))
+	if directoryEmail == "" {
+		directoryEmail = strings.ToLower(email)
+	}
+
+	return directoryEmail, nil
+}
+
+// acquire takes an idle connection from the pool or dials a new one. Each use
+// starts with a service bind, so connections left bound as a user are safe to
+// reuse.
+func (b *LDAPAuthBackend) acquire() (*ldap.Conn, error) {
+	select {
+	case conn := <-b.pool:
+		if !conn.IsClosing() {
+			return conn, nil
+		}
+	default:
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: b.options.InsecureSkipVerify}
+	conn, err := ldap.DialURL(b.options.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: b.options.Timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(b.options.Timeout)
+
+	if b.options.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (b *LDAPAuthBackend) release(conn *ldap.Conn, reusable bool) {
+	if reusable {
+		select {
+		case b.pool <- conn:
+			return
+		default:
+		}
+	}
+
+	conn.Close()
+}
+
+func (b *LDAPAuthBackend) createShadowUser(ctx context.Context, email string) (*entity.User, error) {
+	now := time.Now().UTC()
+
+	_, err := b.userRepository.Create(ctx, &entity.User{
+		Email:     email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		b.logger.ForContext(ctx).Error("Error while creating directory user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to create a user", err)
+	}
+
+	createdUserEntity, err := b.userRepository.GetByEmail(ctx, email)
+	if err != nil || createdUserEntity == nil {
+		b.logger.ForContext(ctx).Error("Error while getting created directory user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to create a user", err)
+	}
+
+	return createdUserEntity, nil
+}
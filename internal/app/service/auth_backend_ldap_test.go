@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+
+	"jwtgo/internal/app/adapter/memory/repository"
+	"jwtgo/pkg/logging"
+)
+
+const (
+	testBindDN       = "cn=service,dc=example,dc=com"
+	testBindPassword = "service-password"
+)
+
+// fakeDirectory is a minimal LDAP server that answers simple binds and
+// equality searches on uid from a fixed set of entries.
+type fakeDirectory struct {
+	listener    net.Listener
+	entries     map[string]fakeEntry
+	connections atomic.Int32
+	wg          sync.WaitGroup
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+type fakeEntry struct {
+	dn       string
+	mail     string
+	password string
+}
+
+func newFakeDirectory(t *testing.T, entries ...fakeEntry) *fakeDirectory {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directory := &fakeDirectory{listener: listener, entries: map[string]fakeEntry{}}
+	for _, entry := range entries {
+		uid := strings.TrimPrefix(strings.Split(entry.dn, ",")[0], "uid=")
+		directory.entries[uid] = entry
+	}
+
+	directory.wg.Add(1)
+	go directory.serve()
+	t.Cleanup(func() {
+		listener.Close()
+		// The backend pools connections, so close them from this side too.
+		directory.mu.Lock()
+		for _, conn := range directory.conns {
+			conn.Close()
+		}
+		directory.mu.Unlock()
+		directory.wg.Wait()
+	})
+
+	return directory
+}
+
+func (d *fakeDirectory) url() string {
+	return "ldap://" + d.listener.Addr().String()
+}
+
+func (d *fakeDirectory) serve() {
+	defer d.wg.Done()
+
+	for {
+		conn, err := d.listener.Accept()
+		if err != nil {
+			return
+		}
+		d.connections.Add(1)
+		d.mu.Lock()
+		d.conns = append(d.conns, conn)
+		d.mu.Unlock()
+
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			defer conn.Close()
+			d.handle(conn)
+		}()
+	}
+}
+
+func (d *fakeDirectory) handle(conn net.Conn) {
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		messageId := packet.Children[0].Value.(int64)
+		operation := packet.Children[1]
+
+		switch operation.Tag {
+		case ldap.ApplicationBindRequest:
+			name := operation.Children[1].Value.(string)
+			password := operation.Children[2].Data.String()
+			conn.Write(ldapResult(messageId, ldap.ApplicationBindResponse, d.bind(name, password)).Bytes())
+		case ldap.ApplicationSearchRequest:
+			filter, _ := ldap.DecompileFilter(operation.Children[6])
+			uid := strings.TrimSuffix(strings.TrimPrefix(filter, "(uid="), ")")
+			if entry, ok := d.entries[uid]; ok {
+				conn.Write(searchEntry(messageId, entry).Bytes())
+			}
+			conn.Write(ldapResult(messageId, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func (d *fakeDirectory) bind(name, password string) uint16 {
+	if name == testBindDN && password == testBindPassword {
+		return ldap.LDAPResultSuccess
+	}
+	for _, entry := range d.entries {
+		if entry.dn == name && entry.password == password {
+			return ldap.LDAPResultSuccess
+		}
+	}
+
+	return ldap.LDAPResultInvalidCredentials
+}
+
+func ldapMessage(messageId int64, operation *ber.Packet) *ber.Packet {
+	envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageId, ""))
+	envelope.AppendChild(operation)
+
+	return envelope
+}
+
+func ldapResult(messageId int64, tag ber.Tag, resultCode uint16) *ber.Packet {
+	operation := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	operation.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), ""))
+	operation.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	operation.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+
+	return ldapMessage(messageId, operation)
+}
+
+func searchEntry(messageId int64, entry fakeEntry) *ber.Packet {
+	values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+	values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.mail, ""))
+
+	attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "mail", ""))
+	attribute.AppendChild(values)
+
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	attributes.AppendChild(attribute)
+
+	operation := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	operation.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.dn, ""))
+	operation.AppendChild(attributes)
+
+	return ldapMessage(messageId, operation)
+}
+
+func newTestLDAPBackend(t *testing.T, url, bindPassword string) (*LDAPAuthBackend, *repository.UserRepository) {
+	t.Helper()
+
+	logger := logging.GetLogger("panic")
+	userRepository := repository.NewUserRepository()
+
+	return NewLDAPAuthBackend(LDAPOptions{
+		URL:            url,
+		BindDN:         testBindDN,
+		BindPassword:   bindPassword,
+		BaseDN:         "dc=example,dc=com",
+		UserAttribute:  "uid",
+		EmailAttribute: "mail",
+		Timeout:        time.Second,
+		PoolSize:       1,
+	}, userRepository, &logger), userRepository
+}
+
+func TestLDAPAuthBackend(t *testing.T) {
+	directory := newFakeDirectory(t, fakeEntry{dn: "uid=alice,ou=people,dc=example,dc=com", mail: "Alice@Example.com", password: "alice-password"})
+	backend, userRepository := newTestLDAPBackend(t, directory.url(), testBindPassword)
+
+	user, err := backend.Authenticate(context.Background(), "alice", "alice-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != "alice@example.com" || user.Password != "" {
+		t.Fatalf("user = %+v, want a local user for the directory email without a password hash", user)
+	}
+
+	again, err := backend.Authenticate(context.Background(), "alice", "alice-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Id != user.Id {
+		t.Fatal("a second sign-in created another local user")
+	}
+
+	users, _ := userRepository.GetAll(context.Background())
+	if len(users) != 1 {
+		t.Fatalf("%d local users, want 1", len(users))
+	}
+
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		wantErr  error
+	}{
+		{"bad password", "alice", "wrong", errBadPassword},
+		{"empty password", "alice", "", errBadPassword},
+		{"unknown user", "mallory", "alice-password", errUnknownUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := backend.Authenticate(context.Background(), tt.login, tt.password); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := directory.connections.Load(); got != 1 {
+		t.Fatalf("dialed %d connections, want the pooled one reused", got)
+	}
+}
+
+func TestLDAPAuthBackendFailures(t *testing.T) {
+	directory := newFakeDirectory(t, fakeEntry{dn: "uid=alice,ou=people,dc=example,dc=com", mail: "alice@example.com", password: "alice-password"})
+
+	unreachable, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachableURL := "ldap://" + unreachable.Addr().String()
+	unreachable.Close()
+
+	tests := []struct {
+		name         string
+		url          string
+		bindPassword string
+	}{
+		{"service bind rejected", directory.url(), "wrong"},
+		{"directory down", unreachableURL, testBindPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, _ := newTestLDAPBackend(t, tt.url, tt.bindPassword)
+
+			_, err := backend.Authenticate(context.Background(), "alice", "alice-password")
+			if err == nil || errors.Is(err, errBadPassword) || errors.Is(err, errUnknownUser) {
+				t.Fatalf("error = %v, want an internal error", err)
+			}
+		})
+	}
+}
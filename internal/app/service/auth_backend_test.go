@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"jwtgo/internal/app/adapter/memory/repository"
+	"jwtgo/internal/app/entity"
+)
+
+func TestLocalAuthBackend(t *testing.T) {
+	userRepository := repository.NewUserRepository()
+	passwordService := NewPasswordService(4, "test-salt")
+
+	salt, _ := passwordService.GenerateSalt(32)
+	hash, _ := passwordService.HashPassword(testPassword, salt)
+	if _, err := userRepository.Create(context.Background(), &entity.User{Email: "user@example.com", Password: hash, Salt: salt}); err != nil {
+		t.Fatal(err)
+	}
+
+	backend := NewLocalAuthBackend(NewUserResolver(userRepository, []string{IdentifierEmail}), passwordService)
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		wantUser bool
+		wantErr  error
+	}{
+		{"valid credentials", "user@example.com", testPassword, true, nil},
+		{"bad password", "user@example.com", "wrong password", true, errBadPassword},
+		{"unknown user", "nobody@example.com", testPassword, false, errUnknownUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := backend.Authenticate(context.Background(), tt.email, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if (user != nil) != tt.wantUser {
+				t.Fatalf("user = %+v, want a user: %t", user, tt.wantUser)
+			}
+		})
+	}
+}
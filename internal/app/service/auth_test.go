@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"jwtgo/internal/app/adapter/memory/repository"
+	"jwtgo/internal/app/controller/http/dto"
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/limiter"
+	"jwtgo/internal/pkg/request"
+	"jwtgo/internal/pkg/revocation"
+	"jwtgo/pkg/logging"
+)
+
+const testPassword = "correct horse battery staple"
+
+// newTestAuthService builds an AuthService on the in-memory repository with
+// every optional dependency disabled. Tests enable what they need by setting
+// the fields directly.
+func newTestAuthService(t testing.TB) (*AuthService, *repository.UserRepository) {
+	t.Helper()
+
+	logger := logging.GetLogger("panic")
+	userRepository := repository.NewUserRepository()
+	passwordService := NewPasswordService(4, "test-salt")
+	jwtService := NewJWTService("test-secret", 10, 60, 5, revocation.NewList(), nil, nil)
+
+	authService := NewAuthService(
+		userRepository,
+		jwtService,
+		passwordService,
+		NewLocalAuthBackend(NewUserResolver(userRepository, []string{IdentifierEmail}), passwordService),
+		NewEmailDomainPolicy(nil, false),
+		AuthServiceOptions{},
+		nil,
+		&logger,
+	)
+
+	return authService, userRepository
+}
+
+func signUp(t testing.TB, authService *AuthService, email string) {
+	t.Helper()
+
+	if _, err := authService.SignUp(context.Background(), &dto.UserCredentialsDTO{Email: email, Password: testPassword}); err != nil {
+		t.Fatalf("sign up %s: %v", email, err)
+	}
+}
+
+func signIn(t testing.TB, authService *AuthService, email string) *dto.UserTokensDTO {
+	t.Helper()
+
+	userTokensDTO, err := authService.SignIn(context.Background(), &dto.UserCredentialsDTO{Email: email, Password: testPassword})
+	if err != nil {
+		t.Fatalf("sign in %s: %v", email, err)
+	}
+
+	return userTokensDTO
+}
+
+func TestSignUpCooldownUnderConcurrency(t *testing.T) {
+	const limit = 2
+
+	authService, _ := newTestAuthService(t)
+	authService.signupLimiter = limiter.NewWindowLimiter(limit, time.Hour)
+	ctx := request.WithClientIP(context.Background(), "203.0.113.1")
+
+	var mu sync.Mutex
+	created, cooledDown := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := authService.SignUp(ctx, &dto.UserCredentialsDTO{Email: fmt.Sprintf("user%d@example.com", i), Password: testPassword})
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created++
+			case errors.Is(err, customErr.ErrSignupCooldown):
+				cooledDown++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if created != limit || cooledDown != 20-limit {
+		t.Fatalf("created %d and cooled down %d sign-ups, want %d and %d", created, cooledDown, limit, 20-limit)
+	}
+}
+
+func TestSignUpCooldownIgnoresFailedSignUps(t *testing.T) {
+	authService, _ := newTestAuthService(t)
+	authService.signupLimiter = limiter.NewWindowLimiter(1, time.Hour)
+	ctx := request.WithClientIP(context.Background(), "203.0.113.1")
+
+	signUp(t, authService, "taken@example.com")
+
+	if _, err := authService.SignUp(ctx, &dto.UserCredentialsDTO{Email: "taken@example.com", Password: testPassword}); !errors.Is(err, customErr.ErrAlreadyExists) {
+		t.Fatalf("duplicate sign-up error = %v, want ALREADY_EXISTS", err)
+	}
+
+	if _, err := authService.SignUp(ctx, &dto.UserCredentialsDTO{Email: "new@example.com", Password: testPassword}); err != nil {
+		t.Fatalf("a failed sign-up used up the cooldown: %v", err)
+	}
+
+	if _, err := authService.SignUp(ctx, &dto.UserCredentialsDTO{Email: "next@example.com", Password: testPassword}); !errors.Is(err, customErr.ErrSignupCooldown) {
+		t.Fatalf("sign-up beyond the limit error = %v, want SIGNUP_COOLDOWN", err)
+	}
+}
+
+func TestConcurrentSignUpSignInAndRefresh(t *testing.T) {
+	const users = 8
+	const rounds = 5
+
+	authService, userRepository := newTestAuthService(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < users; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			email := fmt.Sprintf("user%d@example.com", i)
+			if _, err := authService.SignUp(context.Background(), &dto.UserCredentialsDTO{Email: email, Password: testPassword}); err != nil {
+				t.Errorf("sign up %s: %v", email, err)
+				return
+			}
+
+			userTokensDTO, err := authService.SignIn(context.Background(), &dto.UserCredentialsDTO{Email: email, Password: testPassword})
+			if err != nil {
+				t.Errorf("sign in %s: %v", email, err)
+				return
+			}
+
+			for round := 0; round < rounds; round++ {
+				userTokensDTO, err = authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: userTokensDTO.RefreshToken})
+				if err != nil {
+					t.Errorf("refresh %s in round %d: %v", email, round, err)
+					return
+				}
+			}
+
+			stored, _ := userRepository.GetByEmail(context.Background(), email)
+			if stored == nil || stored.RefreshToken != userTokensDTO.RefreshToken {
+				t.Errorf("%s: stored refresh token is not the last one issued", email)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkSignIn(b *testing.B) {
+	for _, cost := range []int{4, 10, 12} {
+		b.Run(fmt.Sprintf("bcrypt_cost_%d", cost), func(b *testing.B) {
+			authService, userRepository := newTestAuthService(b)
+			passwordService := NewPasswordService(cost, "test-salt")
+			authService.passwordService = passwordService
+			authService.authBackend = NewLocalAuthBackend(NewUserResolver(userRepository, []string{IdentifierEmail}), passwordService)
+			signUp(b, authService, "user@example.com")
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				signIn(b, authService, "user@example.com")
+			}
+		})
+	}
+}
+
+func BenchmarkRefresh(b *testing.B) {
+	authService, _ := newTestAuthService(b)
+	signUp(b, authService, "user@example.com")
+	refreshToken := signIn(b, authService, "user@example.com").RefreshToken
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		userTokensDTO, err := authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: refreshToken})
+		if err != nil {
+			b.Fatal(err)
+		}
+		refreshToken = userTokensDTO.RefreshToken
+	}
+}
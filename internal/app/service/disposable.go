@@ -0,0 +1,55 @@
+package service
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+)
+
+//go:embed disposable_domains.txt
+var defaultDisposableDomains string
+
+type DisposableEmailChecker struct {
+	domains map[string]struct{}
+}
+
+func NewDisposableEmailChecker(extraDomains []string, replaceDefaults bool) *DisposableEmailChecker {
+	domains := make(map[string]struct{})
+
+	if !replaceDefaults {
+		scanner := bufio.NewScanner(strings.NewReader(defaultDisposableDomains))
+		for scanner.Scan() {
+			if domain := normalizeDomain(scanner.Text()); domain != "" && !strings.HasPrefix(domain, "#") {
+				domains[domain] = struct{}{}
+			}
+		}
+	}
+
+	for _, domain := range extraDomains {
+		if domain = normalizeDomain(domain); domain != "" {
+			domains[domain] = struct{}{}
+		}
+	}
+
+	return &DisposableEmailChecker{
+		domains: domains,
+	}
+}
+
+func (c *DisposableEmailChecker) IsDisposable(email string) bool {
+	domain := emailDomain(email)
+
+	for domain != "" {
+		if _, ok := c.domains[domain]; ok {
+			return true
+		}
+
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"strings"
+)
+
+type EmailDomainPolicy struct {
+	allowedDomains  map[string]struct{}
+	allowSubdomains bool
+}
+
+func NewEmailDomainPolicy(allowedDomains []string, allowSubdomains bool) *EmailDomainPolicy {
+	domains := make(map[string]struct{}, len(allowedDomains))
+	for _, domain := range allowedDomains {
+		domain = normalizeDomain(domain)
+		if domain != "" {
+			domains[domain] = struct{}{}
+		}
+	}
+
+	return &EmailDomainPolicy{
+		allowedDomains:  domains,
+		allowSubdomains: allowSubdomains,
+	}
+}
+
+func (p *EmailDomainPolicy) IsAllowed(email string) bool {
+	if len(p.allowedDomains) == 0 {
+		return true
+	}
+
+	domain := emailDomain(email)
+	if domain == "" {
+		return false
+	}
+
+	if _, ok := p.allowedDomains[domain]; ok {
+		return true
+	}
+
+	if p.allowSubdomains {
+		for parent := domain; strings.Contains(parent, "."); {
+			parent = parent[strings.Index(parent, ".")+1:]
+			if _, ok := p.allowedDomains[parent]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return normalizeDomain(email[at+1:])
+}
+
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"jwtgo/internal/app/controller/http/dto"
+	customErr "jwtgo/internal/app/error"
+)
+
+func TestSignUpEmailDomainPolicy(t *testing.T) {
+	allowed := []string{"example.com", " Corp.Example.ORG. "}
+
+	tests := []struct {
+		name            string
+		email           string
+		allowSubdomains bool
+		wantAllowed     bool
+	}{
+		{"allowed domain", "user@example.com", false, true},
+		{"disallowed domain", "user@other.com", false, false},
+		{"allowed domain in upper case", "User@EXAMPLE.COM", false, true},
+		{"configured domain normalized", "user@corp.example.org", false, true},
+		{"trailing dot", "user@example.com.", false, true},
+		{"lookalike suffix", "user@notexample.com", true, false},
+		{"allowed domain as a prefix", "user@example.com.evil.net", true, false},
+		{"subdomain when disabled", "user@eu.example.com", false, false},
+		{"subdomain when enabled", "user@eu.example.com", true, true},
+		{"nested subdomain in mixed case", "user@Mail.EU.Example.com", true, true},
+		{"parent of an allowed subdomain", "user@example.org", true, false},
+		{"no domain", "user", false, false},
+		{"empty domain", "user@", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService, _ := newTestAuthService(t)
+			authService.emailDomainPolicy = NewEmailDomainPolicy(allowed, tt.allowSubdomains)
+
+			_, err := authService.SignUp(context.Background(), &dto.UserCredentialsDTO{Email: tt.email, Password: testPassword})
+
+			rejected := errors.Is(err, customErr.ErrDisallowedEmailDomain)
+			if rejected == tt.wantAllowed {
+				t.Fatalf("SignUp(%q) = %v, want allowed %t", tt.email, err, tt.wantAllowed)
+			}
+			if tt.wantAllowed && err != nil {
+				t.Fatalf("SignUp(%q) = %v", tt.email, err)
+			}
+
+			var domainErr *customErr.DisallowedEmailDomainError
+			if rejected && errors.As(err, &domainErr) {
+				if status, _ := customErr.Status(domainErr.Code()); status != http.StatusForbidden {
+					t.Fatalf("status = %d, want %d", status, http.StatusForbidden)
+				}
+			}
+		})
+	}
+}
+
+func TestEmptyEmailDomainPolicyAllowsEverything(t *testing.T) {
+	for _, domains := range [][]string{nil, {}, {"", "  "}} {
+		policy := NewEmailDomainPolicy(domains, false)
+
+		if !policy.IsAllowed("user@anywhere.net") {
+			t.Errorf("policy for %q rejected an address", domains)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/controller/http/mapper"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/audit"
+	"jwtgo/internal/pkg/tracing"
+	"jwtgo/pkg/logging"
+)
+
+type ExportService struct {
+	userRepository repositoryInterface.UserRepository
+	auditLogger    serviceInterface.AuditLogger
+	logger         *logging.Logger
+}
+
+func NewExportService(
+	userRepository repositoryInterface.UserRepository,
+	auditLogger serviceInterface.AuditLogger,
+	logger *logging.Logger,
+) *ExportService {
+	return &ExportService{
+		userRepository: userRepository,
+		auditLogger:    auditLogger,
+		logger:         logger,
+	}
+}
+
+func (s *ExportService) Export(ctx context.Context, userId string) (_ *dto.UserExportDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.Export", tracing.UserId(userId))
+	defer func() { tracing.End(span, err) }()
+
+	existingUserEntity, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to check user id", err)
+	}
+
+	if existingUserEntity == nil {
+		return nil, customErr.NewUserNotFoundError("User not found", userId)
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.Record(ctx, audit.Event{
+			Action:  "data_export",
+			UserId:  existingUserEntity.Id,
+			Outcome: AuditOutcomeSuccess,
+		})
+	}
+
+	return mapper.MapToUserExportDTO(existingUserEntity, time.Now().UTC()), nil
+}
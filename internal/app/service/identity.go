@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/controller/http/mapper"
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/audit"
+	"jwtgo/internal/pkg/tracing"
+	"jwtgo/pkg/logging"
+)
+
+type IdentityService struct {
+	userRepository repositoryInterface.UserRepository
+	passwordLogin  bool
+	auditLogger    serviceInterface.AuditLogger
+	logger         *logging.Logger
+}
+
+func NewIdentityService(
+	userRepository repositoryInterface.UserRepository,
+	passwordLogin bool,
+	auditLogger serviceInterface.AuditLogger,
+	logger *logging.Logger,
+) *IdentityService {
+	return &IdentityService{
+		userRepository: userRepository,
+		passwordLogin:  passwordLogin,
+		auditLogger:    auditLogger,
+		logger:         logger,
+	}
+}
+
+func (s *IdentityService) List(ctx context.Context, userId string) (_ []dto.IdentityDTO, err error) {
+	ctx, span := tracing.Start(ctx, "IdentityService.List", tracing.UserId(userId))
+	defer func() { tracing.End(span, err) }()
+
+	existingUserEntity, err := s.getUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapToIdentityDTOs(existingUserEntity.Identities), nil
+}
+
+func (s *IdentityService) Unlink(ctx context.Context, userId, provider string) (err error) {
+	ctx, span := tracing.Start(ctx, "IdentityService.Unlink", tracing.UserId(userId))
+	defer func() { tracing.End(span, err) }()
+
+	existingUserEntity, err := s.getUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	remainingIdentities := make([]entity.Identity, 0, len(existingUserEntity.Identities))
+	for _, identity := range existingUserEntity.Identities {
+		if identity.Provider != provider {
+			remainingIdentities = append(remainingIdentities, identity)
+		}
+	}
+
+	if len(remainingIdentities) == len(existingUserEntity.Identities) {
+		return customErr.NewIdentityNotFoundError("Identity is not linked", provider)
+	}
+
+	hasOtherSignInMethod := (s.passwordLogin && existingUserEntity.Password != "") ||
+		len(existingUserEntity.WebAuthnCredentials) > 0 ||
+		len(remainingIdentities) > 0
+	if !hasOtherSignInMethod {
+		return customErr.NewLastSignInMethodError("Cannot remove the last sign-in method", userId)
+	}
+
+	existingUserEntity.Identities = remainingIdentities
+	existingUserEntity.UpdatedAt = time.Now().UTC()
+
+	_, err = s.userRepository.Update(ctx, existingUserEntity.Id, existingUserEntity)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while updating user: ", err)
+		return customErr.NewInternalServerError("Identity unlinking error", err)
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.Record(ctx, audit.Event{
+			Action:  "identity_unlink",
+			UserId:  existingUserEntity.Id,
+			Outcome: AuditOutcomeSuccess,
+			Fields:  map[string]string{"provider": provider},
+		})
+	}
+
+	return nil
+}
+
+func (s *IdentityService) getUser(ctx context.Context, userId string) (*entity.User, error) {
+	existingUserEntity, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to check user id", err)
+	}
+
+	if existingUserEntity == nil {
+		return nil, customErr.NewUserNotFoundError("User not found", userId)
+	}
+
+	return existingUserEntity, nil
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -8,43 +10,88 @@ import (
 
 	customErr "jwtgo/internal/app/error"
 	"jwtgo/internal/app/schema"
+	"jwtgo/internal/pkg/metrics"
+	"jwtgo/internal/pkg/revocation"
 )
 
+var signingMethod = jwt.SigningMethodHS256
+
 type JWTService struct {
-	secretKey       string
-	accessLifetime  int
-	refreshLifetime int
+	secretKey            []byte
+	parser               *jwt.Parser
+	keyFunc              jwt.Keyfunc
+	accessLifetime       int
+	refreshLifetime      int
+	confirmationLifetime int
+	revocations          *revocation.List
+	encryption           *TokenEncryption
+	metrics              *metrics.Metrics
 }
 
-func NewJWTService(secretKey string, accessLifetime, refreshLifetime int) *JWTService {
+// NewJWTService issues access tokens as JWE when encryption is set; refresh and
+// confirmation tokens stay plain JWS.
+func NewJWTService(secretKey string, accessLifetime, refreshLifetime, confirmationLifetime int, revocations *revocation.List, encryption *TokenEncryption, metrics *metrics.Metrics) *JWTService {
+	key := []byte(secretKey)
+
 	return &JWTService{
-		secretKey:       secretKey,
-		accessLifetime:  accessLifetime,
-		refreshLifetime: refreshLifetime,
+		secretKey:            key,
+		parser:               jwt.NewParser(jwt.WithValidMethods([]string{signingMethod.Alg()})),
+		keyFunc:              func(*jwt.Token) (interface{}, error) { return key, nil },
+		accessLifetime:       accessLifetime,
+		refreshLifetime:      refreshLifetime,
+		confirmationLifetime: confirmationLifetime,
+		revocations:          revocations,
+		encryption:           encryption,
+		metrics:              metrics,
 	}
 }
 
-func (s *JWTService) GenerateTokens(id string) (string, string, error) {
+// GenerateTokens issues a token pair for subject. Only the access token carries
+// its email, roles and extensions; the refresh token names the user and its
+// family, the refresh tokens rotated from one sign-in. subject.Family carries
+// the family over on rotation; a new one is started when it is empty.
+func (s *JWTService) GenerateTokens(subject schema.Claims) (string, string, error) {
+	family := subject.Family
+	if family == "" {
+		var err error
+		if family, err = randomId(); err != nil {
+			return "", "", err
+		}
+	}
+
 	accessClaims := &schema.Claims{
-		Id: id,
+		Id:         subject.Id,
+		TokenType:  schema.TokenTypeAccess,
+		Email:      subject.Email,
+		Roles:      subject.Roles,
+		Extensions: subject.Extensions,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(time.Minute * time.Duration(s.accessLifetime))),
 		},
 	}
 
 	refreshClaims := &schema.Claims{
-		Id: id,
+		Id:        subject.Id,
+		TokenType: schema.TokenTypeRefresh,
+		Family:    family,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(time.Minute * time.Duration(s.refreshLifetime))),
 		},
 	}
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString([]byte(s.secretKey))
+	accessToken, err := s.sign(accessClaims)
 	if err != nil {
 		return "", "", err
 	}
 
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString([]byte(s.secretKey))
+	if s.encryption != nil {
+		accessToken, err = s.encryption.Encrypt(accessToken)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	refreshToken, err := s.sign(refreshClaims)
 	if err != nil {
 		return "", "", err
 	}
@@ -52,27 +99,144 @@ func (s *JWTService) GenerateTokens(id string) (string, string, error) {
 	return accessToken, refreshToken, nil
 }
 
-func (s *JWTService) ValidateToken(signedToken string) (*schema.Claims, error) {
-	token, err := jwt.ParseWithClaims(
-		signedToken,
-		&schema.Claims{},
-		func(token *jwt.Token) (interface{}, error) {
-			return []byte(s.secretKey), nil
+func (s *JWTService) GenerateConfirmationToken(id string, authTime time.Time) (string, error) {
+	confirmationClaims := &schema.Claims{
+		Id:        id,
+		TokenType: schema.TokenTypeConfirmation,
+		AuthTime:  jwt.NewNumericDate(authTime),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(time.Minute * time.Duration(s.confirmationLifetime))),
 		},
-	)
+	}
+
+	return s.sign(confirmationClaims)
+}
+
+func (s *JWTService) sign(claims *schema.Claims) (string, error) {
+	defer s.metrics.ObserveTokenSign(signingMethod.Alg(), claims.TokenType, time.Now())
+
+	tokenId, err := randomId()
+	if err != nil {
+		return "", err
+	}
+	claims.ID = tokenId
+
+	now := jwt.NewNumericDate(time.Now().UTC())
+	claims.IssuedAt = now
+	claims.NotBefore = now
+
+	return jwt.NewWithClaims(signingMethod, claims).SignedString(s.secretKey)
+}
+
+func randomId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func (s *JWTService) ValidateToken(signedToken, tokenType string) (*schema.Claims, error) {
+	start := time.Now()
+
+	claims, failureReason, err := s.parse(signedToken)
+	if err == nil && claims.TokenType != tokenType {
+		failureReason = "wrong_type"
+		claims, err = nil, customErr.NewWrongTokenTypeError("Wrong token type", claims.ID, tokenType, claims.TokenType)
+	}
+
+	s.metrics.ObserveTokenVerify(signingMethod.Alg(), failureReason, start)
+	return claims, err
+}
+
+// ParseToken validates a token without requiring a particular token type.
+func (s *JWTService) ParseToken(signedToken string) (*schema.Claims, error) {
+	start := time.Now()
+
+	claims, failureReason, err := s.parse(signedToken)
+
+	s.metrics.ObserveTokenVerify(signingMethod.Alg(), failureReason, start)
+	return claims, err
+}
+
+func (s *JWTService) parse(signedToken string) (*schema.Claims, string, error) {
+	if isEncryptedToken(signedToken) {
+		if s.encryption == nil {
+			return nil, "encrypted", customErr.NewInvalidTokenError("Token is invalid", "")
+		}
+
+		decryptedToken, err := s.encryption.Decrypt(signedToken)
+		if err != nil {
+			return nil, "decryption_failed", customErr.NewInvalidTokenError("Token is invalid", "")
+		}
+		signedToken = decryptedToken
+	}
+
+	token, err := s.parser.ParseWithClaims(signedToken, &schema.Claims{}, s.keyFunc)
 
 	if err != nil {
+		var tokenId string
+		if token != nil {
+			if claims, ok := token.Claims.(*schema.Claims); ok {
+				tokenId = claims.ID
+			}
+		}
+
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return nil, customErr.NewExpiredTokenError("Token is expired")
+			return nil, verificationFailureReason(err), customErr.NewExpiredTokenError("Token is expired", tokenId)
 		} else {
-			return nil, customErr.NewInvalidTokenError("Token is invalid")
+			return nil, verificationFailureReason(err), customErr.NewInvalidTokenError("Token is invalid", tokenId)
 		}
 	}
 
 	claims, ok := token.Claims.(*schema.Claims)
 	if !ok {
-		return nil, customErr.NewInvalidTokenError("Token is invalid")
+		return nil, "invalid", customErr.NewInvalidTokenError("Token is invalid", "")
+	}
+
+	if s.revocations != nil && claims.ID != "" && s.revocations.IsRevoked(claims.ID) {
+		return nil, "revoked", customErr.NewInvalidTokenError("Token has been revoked", claims.ID)
 	}
 
-	return claims, nil
+	if s.revocations != nil && claims.IssuedAt != nil && s.revocations.IsSubjectRevoked(claims.Id, claims.IssuedAt.Time) {
+		return nil, "revoked", customErr.NewInvalidTokenError("Session has been revoked", claims.ID)
+	}
+
+	return claims, "", nil
+}
+
+func (s *JWTService) RevokeToken(claims *schema.Claims) {
+	if s.revocations == nil || claims.ID == "" || claims.ExpiresAt == nil {
+		return
+	}
+
+	s.revocations.Revoke(claims.ID, claims.ExpiresAt.Time)
+}
+
+// RevokeSubject revokes every token issued to subject before the current
+// second. Token timestamps have second precision, so a token issued within the
+// same second as the call stays valid.
+func (s *JWTService) RevokeSubject(subject string) {
+	if s.revocations == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	longestLifetime := max(s.accessLifetime, s.refreshLifetime, s.confirmationLifetime)
+	s.revocations.RevokeSubject(subject, now.Truncate(time.Second), now.Add(time.Minute*time.Duration(longestLifetime)))
+}
+
+func verificationFailureReason(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "expired"
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return "bad_signature"
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return "malformed"
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return "not_valid_yet"
+	default:
+		return "invalid"
+	}
 }
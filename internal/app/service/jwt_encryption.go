@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+const (
+	keyAlgorithm      = jose.DIRECT
+	contentEncryption = jose.A256GCM
+)
+
+// TokenEncryption wraps signed tokens in a compact JWE (JWS-then-JWE) so the
+// claims cannot be read by clients. The signature is still checked after
+// decryption.
+type TokenEncryption struct {
+	key       []byte
+	encrypter jose.Encrypter
+}
+
+func NewTokenEncryption(key []byte) (*TokenEncryption, error) {
+	encrypter, err := jose.NewEncrypter(
+		contentEncryption,
+		jose.Recipient{Algorithm: keyAlgorithm, Key: key},
+		(&jose.EncrypterOptions{}).WithContentType("JWT"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create token encrypter: %w", err)
+	}
+
+	return &TokenEncryption{
+		key:       key,
+		encrypter: encrypter,
+	}, nil
+}
+
+func (e *TokenEncryption) Encrypt(signedToken string) (string, error) {
+	encrypted, err := e.encrypter.Encrypt([]byte(signedToken))
+	if err != nil {
+		return "", err
+	}
+	return encrypted.CompactSerialize()
+}
+
+func (e *TokenEncryption) Decrypt(encryptedToken string) (string, error) {
+	encrypted, err := jose.ParseEncrypted(encryptedToken, []jose.KeyAlgorithm{keyAlgorithm}, []jose.ContentEncryption{contentEncryption})
+	if err != nil {
+		return "", err
+	}
+
+	signedToken, err := encrypted.Decrypt(e.key)
+	if err != nil {
+		return "", err
+	}
+	return string(signedToken), nil
+}
+
+// isEncryptedToken tells a compact JWE (five parts) from a JWS (three parts).
+func isEncryptedToken(token string) bool {
+	return strings.Count(token, ".") == 4
+}
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"jwtgo/internal/app/schema"
+	"jwtgo/internal/pkg/metrics"
+	"jwtgo/internal/pkg/revocation"
+)
+
+func newTestJWTService() *JWTService {
+	return NewJWTService("test-secret", 10, 60, 5, revocation.NewList(), nil, nil)
+}
+
+func newTestTokenEncryption(t testing.TB) *TokenEncryption {
+	t.Helper()
+
+	encryption, err := NewTokenEncryption(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encryption
+}
+
+func generateTokens(t testing.TB, jwtService *JWTService) (string, string) {
+	t.Helper()
+
+	accessToken, refreshToken, err := jwtService.GenerateTokens(schema.Claims{Id: "user-1", Email: "user@example.com", Roles: []string{"user"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return accessToken, refreshToken
+}
+
+// signClaims signs claims with jwtService's key without the defaults sign
+// fills in, so tests can issue tokens that are already expired.
+func signClaims(t *testing.T, jwtService *JWTService, claims *schema.Claims) string {
+	t.Helper()
+
+	signedToken, err := jwt.NewWithClaims(signingMethod, claims).SignedString(jwtService.secretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signedToken
+}
+
+func verificationFailures(t *testing.T, m *metrics.Metrics, reason string) float64 {
+	t.Helper()
+
+	families, err := m.Registry().Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != "jwtgo_token_verification_failures_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "reason" && label.GetValue() == reason {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestValidateTokenFailureReasons(t *testing.T) {
+	m := metrics.New(nil)
+	jwtService := NewJWTService("test-secret", 10, 60, 5, revocation.NewList(), nil, m)
+	accessToken, refreshToken := generateTokens(t, jwtService)
+
+	otherAccessToken, _ := generateTokens(t, NewJWTService("other-secret", 10, 60, 5, nil, nil, nil))
+	encryptedAccessToken, _ := generateTokens(t, NewJWTService("test-secret", 10, 60, 5, nil, newTestTokenEncryption(t), nil))
+
+	hourAgo := time.Now().Add(-time.Hour)
+	expiredToken := signClaims(t, jwtService, &schema.Claims{
+		Id:               "user-1",
+		TokenType:        schema.TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(hourAgo), IssuedAt: jwt.NewNumericDate(hourAgo)},
+	})
+	futureToken := signClaims(t, jwtService, &schema.Claims{
+		Id:               "user-1",
+		TokenType:        schema.TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{NotBefore: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	})
+
+	revokedToken, _ := generateTokens(t, jwtService)
+	revokedClaims, err := jwtService.ParseToken(revokedToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwtService.RevokeToken(revokedClaims)
+
+	tests := []struct {
+		name   string
+		token  string
+		reason string
+	}{
+		{"expired", expiredToken, "expired"},
+		{"other secret", otherAccessToken, "bad_signature"},
+		{"garbage", "garbage", "malformed"},
+		{"not valid yet", futureToken, "not_valid_yet"},
+		{"refresh as access", refreshToken, "wrong_type"},
+		{"revoked", revokedToken, "revoked"},
+		{"encrypted without a key", encryptedAccessToken, "encrypted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := verificationFailures(t, m, tt.reason)
+
+			if _, err := jwtService.ValidateToken(tt.token, schema.TokenTypeAccess); err == nil {
+				t.Fatal("token was accepted")
+			}
+
+			if after := verificationFailures(t, m, tt.reason); after != before+1 {
+				t.Fatalf("failures with reason %q went from %v to %v, want one more", tt.reason, before, after)
+			}
+		})
+	}
+
+	if _, err := jwtService.ValidateToken(accessToken, schema.TokenTypeAccess); err != nil {
+		t.Fatalf("valid access token was rejected: %v", err)
+	}
+}
+
+func TestEncryptedAccessTokens(t *testing.T) {
+	jwtService := NewJWTService("test-secret", 10, 60, 5, revocation.NewList(), newTestTokenEncryption(t), nil)
+	accessToken, refreshToken := generateTokens(t, jwtService)
+
+	if !isEncryptedToken(accessToken) || isEncryptedToken(refreshToken) {
+		t.Fatal("want an encrypted access token and a signed refresh token")
+	}
+
+	claims, err := jwtService.ValidateToken(accessToken, schema.TokenTypeAccess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Email != "user@example.com" {
+		t.Fatalf("email = %q, want user@example.com", claims.Email)
+	}
+}
+
+func TestValidateTokenOnlyAcceptsHS256(t *testing.T) {
+	jwtService := newTestJWTService()
+	claims := &schema.Claims{Id: "user-1", TokenType: schema.TokenTypeAccess}
+
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		key    interface{}
+	}{
+		{"HS384 with the same secret", jwt.SigningMethodHS384, jwtService.secretKey},
+		{"HS512 with the same secret", jwt.SigningMethodHS512, jwtService.secretKey},
+		{"unsigned", jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signedToken, err := jwt.NewWithClaims(tt.method, claims).SignedString(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := jwtService.ValidateToken(signedToken, schema.TokenTypeAccess); err == nil {
+				t.Fatal("token was accepted")
+			}
+		})
+	}
+}
+
+// TestConcurrentValidation shares one service, and so one parser and key
+// function, between goroutines that sign, validate and revoke. Run with -race.
+func TestConcurrentValidation(t *testing.T) {
+	for _, format := range []struct {
+		name       string
+		encryption *TokenEncryption
+	}{
+		{"jws", nil},
+		{"jwe", newTestTokenEncryption(t)},
+	} {
+		t.Run(format.name, func(t *testing.T) {
+			jwtService := NewJWTService("test-secret", 10, 60, 5, revocation.NewList(), format.encryption, metrics.New(nil))
+			sharedToken, _ := generateTokens(t, jwtService)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 32)
+			for worker := range 32 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					for i := range 50 {
+						if _, err := jwtService.ValidateToken(sharedToken, schema.TokenTypeAccess); err != nil {
+							errs <- fmt.Errorf("shared token: %w", err)
+							return
+						}
+
+						id := fmt.Sprintf("user-%d-%d", worker, i)
+						accessToken, _, err := jwtService.GenerateTokens(schema.Claims{Id: id})
+						if err != nil {
+							errs <- err
+							return
+						}
+
+						claims, err := jwtService.ValidateToken(accessToken, schema.TokenTypeAccess)
+						if err != nil || claims.Id != id {
+							errs <- fmt.Errorf("%s: claims %+v, error %v", id, claims, err)
+							return
+						}
+
+						if i%5 == 0 {
+							jwtService.RevokeToken(claims)
+							if _, err := jwtService.ValidateToken(accessToken, schema.TokenTypeAccess); err == nil {
+								errs <- fmt.Errorf("%s: revoked token was accepted", id)
+								return
+							}
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestTokensAfterKeyOrFormatChange issues tokens under one configuration and
+// validates them after a restart with another.
+func TestTokensAfterKeyOrFormatChange(t *testing.T) {
+	oldEncryption := newTestTokenEncryption(t)
+	newEncryption, err := NewTokenEncryption(bytes.Repeat([]byte{9}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		before, after *JWTService
+		accessValid   bool
+		refreshValid  bool
+	}{
+		{
+			name:         "same configuration",
+			before:       NewJWTService("test-secret", 10, 60, 5, nil, nil, nil),
+			after:        NewJWTService("test-secret", 10, 60, 5, nil, nil, nil),
+			accessValid:  true,
+			refreshValid: true,
+		},
+		{
+			name:   "rotated secret",
+			before: NewJWTService("old-secret", 10, 60, 5, nil, nil, nil),
+			after:  NewJWTService("new-secret", 10, 60, 5, nil, nil, nil),
+		},
+		{
+			name:   "rotated secret with JWE",
+			before: NewJWTService("old-secret", 10, 60, 5, nil, oldEncryption, nil),
+			after:  NewJWTService("new-secret", 10, 60, 5, nil, oldEncryption, nil),
+		},
+		{
+			name:         "rotated encryption key",
+			before:       NewJWTService("test-secret", 10, 60, 5, nil, oldEncryption, nil),
+			after:        NewJWTService("test-secret", 10, 60, 5, nil, newEncryption, nil),
+			refreshValid: true,
+		},
+		{
+			name:         "JWE back to JWS",
+			before:       NewJWTService("test-secret", 10, 60, 5, nil, oldEncryption, nil),
+			after:        NewJWTService("test-secret", 10, 60, 5, nil, nil, nil),
+			refreshValid: true,
+		},
+		{
+			// Plain access tokens stay valid so sessions survive enabling JWE.
+			name:         "JWS to JWE",
+			before:       NewJWTService("test-secret", 10, 60, 5, nil, nil, nil),
+			after:        NewJWTService("test-secret", 10, 60, 5, nil, oldEncryption, nil),
+			accessValid:  true,
+			refreshValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessToken, refreshToken := generateTokens(t, tt.before)
+
+			if _, err := tt.after.ValidateToken(accessToken, schema.TokenTypeAccess); (err == nil) != tt.accessValid {
+				t.Errorf("access token error = %v, want valid: %t", err, tt.accessValid)
+			}
+			if _, err := tt.after.ValidateToken(refreshToken, schema.TokenTypeRefresh); (err == nil) != tt.refreshValid {
+				t.Errorf("refresh token error = %v, want valid: %t", err, tt.refreshValid)
+			}
+		})
+	}
+}
+
+func BenchmarkGenerateTokens(b *testing.B) {
+	jwtService := newTestJWTService()
+	claims := schema.Claims{Id: "user-1", Email: "user@example.com", Roles: []string{"user"}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := jwtService.GenerateTokens(claims); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignAndValidate(b *testing.B) {
+	formats := []struct {
+		name       string
+		encryption *TokenEncryption
+	}{
+		{"jws", nil},
+		{"jwe", newTestTokenEncryption(b)},
+	}
+
+	for _, format := range formats {
+		jwtService := NewJWTService("test-secret", 10, 60, 5, revocation.NewList(), format.encryption, metrics.New(nil))
+		claims := schema.Claims{Id: "user-1", Email: "user@example.com", Roles: []string{"user"}}
+
+		b.Run(format.name+"/sign", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := jwtService.GenerateTokens(claims); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(format.name+"/validate", func(b *testing.B) {
+			accessToken, _ := generateTokens(b, jwtService)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := jwtService.ValidateToken(accessToken, schema.TokenTypeAccess); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(format.name+"/validate_parallel", func(b *testing.B) {
+			accessToken, _ := generateTokens(b, jwtService)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := jwtService.ValidateToken(accessToken, schema.TokenTypeAccess); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkParse compares the parser and key function built once in
+// NewJWTService with building them and converting the secret on every call.
+func BenchmarkParse(b *testing.B) {
+	jwtService := newTestJWTService()
+	accessToken, _ := generateTokens(b, jwtService)
+	secret := string(jwtService.secretKey)
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := jwtService.parse(accessToken); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("per_call", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			parser := jwt.NewParser(jwt.WithValidMethods([]string{signingMethod.Alg()}))
+			if _, err := parser.ParseWithClaims(accessToken, &schema.Claims{}, func(*jwt.Token) (interface{}, error) {
+				return []byte(secret), nil
+			}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"net/netip"
+)
+
+type SubnetBinder struct {
+	ipv4Prefix int
+	ipv6Prefix int
+}
+
+func NewSubnetBinder(ipv4Prefix, ipv6Prefix int) *SubnetBinder {
+	return &SubnetBinder{
+		ipv4Prefix: ipv4Prefix,
+		ipv6Prefix: ipv6Prefix,
+	}
+}
+
+// Network returns the subnet an address belongs to, for example 203.0.113.0/24,
+// or an empty string when the address cannot be parsed.
+func (b *SubnetBinder) Network(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	bits := b.ipv6Prefix
+	if addr.Is4() {
+		bits = b.ipv4Prefix
+	}
+
+	prefix, err := addr.WithZone("").Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return prefix.String()
+}
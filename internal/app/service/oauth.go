@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/state"
+	"jwtgo/pkg/logging"
+)
+
+type OAuthService struct {
+	providers         map[string]serviceInterface.OAuthProvider
+	states            *state.Signer
+	userRepository    repositoryInterface.UserRepository
+	authService       serviceInterface.AuthService
+	emailDomainPolicy serviceInterface.EmailDomainPolicy
+	allowLinking      bool
+	logger            *logging.Logger
+}
+
+func NewOAuthService(
+	providers []serviceInterface.OAuthProvider,
+	states *state.Signer,
+	userRepository repositoryInterface.UserRepository,
+	authService serviceInterface.AuthService,
+	emailDomainPolicy serviceInterface.EmailDomainPolicy,
+	allowLinking bool,
+	logger *logging.Logger,
+) *OAuthService {
+	providersByName := make(map[string]serviceInterface.OAuthProvider, len(providers))
+	for _, provider := range providers {
+		providersByName[provider.Name()] = provider
+	}
+
+	return &OAuthService{
+		providers:         providersByName,
+		states:            states,
+		userRepository:    userRepository,
+		authService:       authService,
+		emailDomainPolicy: emailDomainPolicy,
+		allowLinking:      allowLinking,
+		logger:            logger,
+	}
+}
+
+func (s *OAuthService) LoginURL(providerName string) (string, string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", customErr.NewInvalidRequestError("Unknown OAuth provider")
+	}
+
+	stateValue, err := s.states.GenerateState(providerName)
+	if err != nil {
+		return "", "", customErr.NewInternalServerError("Failed to generate OAuth state", err)
+	}
+
+	return provider.AuthCodeURL(stateValue), stateValue, nil
+}
+
+func (s *OAuthService) Callback(ctx context.Context, providerName, code, stateValue string) (*dto.UserTokensDTO, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, customErr.NewInvalidRequestError("Unknown OAuth provider")
+	}
+
+	statePayload, err := s.states.VerifyState(stateValue)
+	if err != nil || statePayload != providerName {
+		return nil, customErr.NewInvalidOAuthStateError("OAuth state is invalid or expired")
+	}
+
+	token, err := provider.Exchange(ctx, code)
+	if err != nil {
+		s.logger.ForContext(ctx).Warn(providerName, " code exchange failed: ", err)
+		return nil, customErr.NewOAuthProviderError("Failed to exchange authorization code", providerName, err)
+	}
+
+	userInfo, err := provider.FetchUserInfo(ctx, token)
+	if err != nil {
+		s.logger.ForContext(ctx).Warn(providerName, " user info request failed: ", err)
+		return nil, customErr.NewOAuthProviderError("Failed to fetch user info", providerName, err)
+	}
+
+	return s.signIn(ctx, providerName, userInfo)
+}
+
+func fetchJSON(ctx context.Context, client *http.Client, url string, target any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with status %d", url, response.StatusCode)
+	}
+
+	return json.NewDecoder(response.Body).Decode(target)
+}
+
+func (s *OAuthService) signIn(ctx context.Context, provider string, userInfo *entity.OAuthUserInfo) (*dto.UserTokensDTO, error) {
+	if userInfo.Email == "" {
+		return nil, customErr.NewMissingProviderEmailError("Provider did not return a verified email", provider)
+	}
+
+	if !userInfo.EmailVerified {
+		return nil, customErr.NewUnverifiedEmailError("Provider email is not verified", userInfo.Email)
+	}
+
+	email := strings.ToLower(userInfo.Email)
+	now := time.Now().UTC()
+	identity := entity.Identity{Provider: provider, Subject: userInfo.Subject, Email: email, LinkedAt: now}
+
+	existingUserEntity, err := s.userRepository.GetByIdentity(ctx, provider, userInfo.Subject)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to check user identity", err)
+	}
+
+	if existingUserEntity == nil {
+		existingUserEntity, err = s.userRepository.GetByEmail(ctx, email)
+		if err != nil {
+			s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+			return nil, customErr.NewInternalServerError("Failed to check user email", err)
+		}
+
+		if existingUserEntity != nil {
+			if !s.allowLinking {
+				return nil, customErr.NewAlreadyExistsError("Email already exists", email)
+			}
+
+			existingUserEntity.Identities = append(existingUserEntity.Identities, identity)
+			s.logger.ForContext(ctx).Info("Linked ", provider, " identity to user ", existingUserEntity.Id)
+		} else {
+			existingUserEntity, err = s.createUser(ctx, identity)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	existingUserEntity.AuthTime = now
+
+	return s.authService.IssueTokens(ctx, existingUserEntity)
+}
+
+func (s *OAuthService) createUser(ctx context.Context, identity entity.Identity) (*entity.User, error) {
+	if !s.emailDomainPolicy.IsAllowed(identity.Email) {
+		return nil, customErr.NewDisallowedEmailDomainError("Email domain is not allowed", identity.Email)
+	}
+
+	_, err := s.userRepository.Create(ctx, &entity.User{
+		Email:      identity.Email,
+		Identities: []entity.Identity{identity},
+		CreatedAt:  identity.LinkedAt,
+		UpdatedAt:  identity.LinkedAt,
+	})
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while creating user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to create a user", err)
+	}
+
+	createdUserEntity, err := s.userRepository.GetByEmail(ctx, identity.Email)
+	if err != nil || createdUserEntity == nil {
+		s.logger.ForContext(ctx).Error("Error while getting created user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to create a user", err)
+	}
+
+	return createdUserEntity, nil
+}
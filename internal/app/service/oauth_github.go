@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/oauth2"
+
+	"jwtgo/internal/app/entity"
+)
+
+const (
+	githubUserURL   = "https://api.github.com/user"
+	githubEmailsURL = "https://api.github.com/user/emails"
+)
+
+type GitHubProvider struct {
+	config *oauth2.Config
+}
+
+func NewGitHubProvider(clientId, clientSecret, redirectURL string) *GitHubProvider {
+	return &GitHubProvider{
+		config: &oauth2.Config{
+			ClientID:     clientId,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       []string{"read:user", "user:email"},
+			Endpoint: oauth2.Endpoint{
+				AuthURL:   "https://github.com/login/oauth/authorize",
+				TokenURL:  "https://github.com/login/oauth/access_token",
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+		},
+	}
+}
+
+func (p *GitHubProvider) Name() string {
+	return "github"
+}
+
+func (p *GitHubProvider) AuthCodeURL(state string) string {
+	return p.config.AuthCodeURL(state)
+}
+
+func (p *GitHubProvider) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	return p.config.Exchange(ctx, code)
+}
+
+func (p *GitHubProvider) FetchUserInfo(ctx context.Context, token *oauth2.Token) (*entity.OAuthUserInfo, error) {
+	client := p.config.Client(ctx, token)
+
+	var user struct {
+		Id int64 `json:"id"`
+	}
+	if err := fetchJSON(ctx, client, githubUserURL, &user); err != nil {
+		return nil, err
+	}
+
+	if user.Id == 0 {
+		return nil, fmt.Errorf("user info has no subject")
+	}
+
+	// The public profile email may be hidden or unverified, so the primary
+	// verified address is taken from the emails endpoint instead.
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := fetchJSON(ctx, client, githubEmailsURL, &emails); err != nil {
+		return nil, err
+	}
+
+	userInfo := &entity.OAuthUserInfo{Subject: strconv.FormatInt(user.Id, 10)}
+	for _, email := range emails {
+		if !email.Verified {
+			continue
+		}
+
+		if userInfo.Email == "" || email.Primary {
+			userInfo.Email = email.Email
+			userInfo.EmailVerified = true
+		}
+	}
+
+	return userInfo, nil
+}
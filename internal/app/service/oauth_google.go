@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2"
+
+	"jwtgo/internal/app/entity"
+)
+
+const googleUserInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+
+type GoogleProvider struct {
+	config *oauth2.Config
+}
+
+func NewGoogleProvider(clientId, clientSecret, redirectURL string) *GoogleProvider {
+	return &GoogleProvider{
+		config: &oauth2.Config{
+			ClientID:     clientId,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       []string{"openid", "email", "profile"},
+			Endpoint: oauth2.Endpoint{
+				AuthURL:   "https://accounts.google.com/o/oauth2/v2/auth",
+				TokenURL:  "https://oauth2.googleapis.com/token",
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+		},
+	}
+}
+
+func (p *GoogleProvider) Name() string {
+	return "google"
+}
+
+func (p *GoogleProvider) AuthCodeURL(state string) string {
+	return p.config.AuthCodeURL(state)
+}
+
+func (p *GoogleProvider) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	return p.config.Exchange(ctx, code)
+}
+
+func (p *GoogleProvider) FetchUserInfo(ctx context.Context, token *oauth2.Token) (*entity.OAuthUserInfo, error) {
+	var userInfo struct {
+		Subject       string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+
+	if err := fetchJSON(ctx, p.config.Client(ctx, token), googleUserInfoURL, &userInfo); err != nil {
+		return nil, err
+	}
+
+	if userInfo.Subject == "" {
+		return nil, fmt.Errorf("user info has no subject")
+	}
+
+	return &entity.OAuthUserInfo{Subject: userInfo.Subject, Email: userInfo.Email, EmailVerified: userInfo.EmailVerified}, nil
+}
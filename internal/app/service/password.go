@@ -53,3 +53,9 @@ func (s *PasswordService) VerifyPassword(plainPassword, hashedPassword, localSal
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(preHashedPassword))
 	return err == nil
 }
+
+// IsHash reports whether hashedPassword has the format HashPassword produces.
+func (s *PasswordService) IsHash(hashedPassword string) bool {
+	_, err := bcrypt.Cost([]byte(hashedPassword))
+	return err == nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"time"
+
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/metrics"
+)
+
+type InstrumentedPasswordService struct {
+	serviceInterface.PasswordService
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedPasswordService(passwordService serviceInterface.PasswordService, metrics *metrics.Metrics) *InstrumentedPasswordService {
+	return &InstrumentedPasswordService{
+		PasswordService: passwordService,
+		metrics:         metrics,
+	}
+}
+
+func (s *InstrumentedPasswordService) HashPassword(password, localSalt string) (string, error) {
+	defer s.metrics.ObservePasswordHash("hash", time.Now())
+
+	return s.PasswordService.HashPassword(password, localSalt)
+}
+
+func (s *InstrumentedPasswordService) VerifyPassword(plainPassword, hashedPassword, localSalt string) bool {
+	defer s.metrics.ObservePasswordHash("verify", time.Now())
+
+	return s.PasswordService.VerifyPassword(plainPassword, hashedPassword, localSalt)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"bufio"
+	_ "embed"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	customErr "jwtgo/internal/app/error"
+	serviceInterface "jwtgo/internal/app/interface/service"
+)
+
+const (
+	SuggestionLonger          = "use_longer_password"
+	SuggestionMixClasses      = "mix_character_classes"
+	SuggestionAvoidRepeats    = "avoid_repeated_characters"
+	SuggestionAvoidSequences  = "avoid_sequences"
+	SuggestionAvoidKeyboard   = "avoid_keyboard_patterns"
+	SuggestionAvoidCommon     = "avoid_common_passwords"
+	SuggestionAvoidPersonal   = "avoid_personal_information"
+	recommendedPasswordLength = 12
+	minDictionaryWordLength   = 4
+	predictableRuneWeight     = 0.2
+)
+
+//go:embed common_passwords.txt
+var defaultCommonPasswords string
+
+var (
+	leetSubstitutions = map[rune]rune{'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's'}
+	keyboardRows      = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm", "1234567890"}
+)
+
+// EntropyEstimator is a dependency-free approximation of zxcvbn: it starts
+// from the brute-force entropy of the character classes used and discounts
+// repeats, sequences, keyboard walks, common passwords and personal details.
+type EntropyEstimator struct {
+	commonPasswords []string
+}
+
+func NewEntropyEstimator() *EntropyEstimator {
+	var commonPasswords []string
+	scanner := bufio.NewScanner(strings.NewReader(defaultCommonPasswords))
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); len(word) >= minDictionaryWordLength {
+			commonPasswords = append(commonPasswords, word)
+		}
+	}
+
+	return &EntropyEstimator{
+		commonPasswords: commonPasswords,
+	}
+}
+
+func (e *EntropyEstimator) Estimate(password string, userInputs []string) (float64, []string) {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0, []string{SuggestionLonger}
+	}
+
+	var suggestions []string
+	suggest := func(suggestion string) {
+		if !slices.Contains(suggestions, suggestion) {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	normalized := make([]rune, len(runes))
+	for i, r := range runes {
+		r = unicode.ToLower(r)
+		if substitute, ok := leetSubstitutions[r]; ok {
+			r = substitute
+		}
+		normalized[i] = r
+	}
+
+	weights := make([]float64, len(runes))
+	for i := range weights {
+		weights[i] = 1
+	}
+
+	for i := 1; i < len(runes); i++ {
+		step := runes[i] - runes[i-1]
+		switch {
+		case step == 0:
+			weights[i] = predictableRuneWeight
+			suggest(SuggestionAvoidRepeats)
+		case i >= 2 && (step == 1 || step == -1) && step == runes[i-1]-runes[i-2]:
+			weights[i], weights[i-1] = predictableRuneWeight, predictableRuneWeight
+			suggest(SuggestionAvoidSequences)
+		}
+	}
+
+	lowered := strings.ToLower(password)
+	for _, row := range keyboardRows {
+		if discountMatches(lowered, substrings(row, minDictionaryWordLength), weights, predictableRuneWeight) > 0 {
+			suggest(SuggestionAvoidKeyboard)
+		}
+	}
+
+	var bonus float64
+	if matches := discountMatches(string(normalized), e.commonPasswords, weights, 0); matches > 0 {
+		bonus += float64(matches) * math.Log2(float64(len(e.commonPasswords)))
+		suggest(SuggestionAvoidCommon)
+	}
+	if matches := discountMatches(string(normalized), personalTokens(userInputs), weights, 0); matches > 0 {
+		bonus += float64(matches)
+		suggest(SuggestionAvoidPersonal)
+	}
+
+	var effectiveLength float64
+	for _, weight := range weights {
+		effectiveLength += weight
+	}
+
+	charset := charsetSize(runes)
+	bits := effectiveLength*math.Log2(float64(charset)) + bonus
+
+	if len(runes) < recommendedPasswordLength {
+		suggest(SuggestionLonger)
+	}
+	if charset <= 36 {
+		suggest(SuggestionMixClasses)
+	}
+
+	return bits, suggestions
+}
+
+// discountMatches caps the weight of every rune after the first one in each
+// occurrence of words and returns the number of occurrences found.
+func discountMatches(text string, words []string, weights []float64, weight float64) int {
+	matches := 0
+	for _, word := range words {
+		for offset := 0; ; {
+			index := strings.Index(text[offset:], word)
+			if index < 0 {
+				break
+			}
+
+			start := utf8.RuneCountInString(text[:offset+index])
+			for i := start + 1; i < start+utf8.RuneCountInString(word); i++ {
+				weights[i] = min(weights[i], weight)
+			}
+
+			matches++
+			offset += index + len(word)
+		}
+	}
+	return matches
+}
+
+func substrings(row string, length int) []string {
+	var result []string
+	for i := 0; i+length <= len(row); i++ {
+		result = append(result, row[i:i+length])
+	}
+	return result
+}
+
+func personalTokens(userInputs []string) []string {
+	var tokens []string
+	for _, input := range userInputs {
+		if at := strings.LastIndex(input, "@"); at >= 0 {
+			input = input[:at]
+		}
+
+		for _, token := range strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+			return !unicode.IsLetter(r)
+		}) {
+			if len(token) >= minDictionaryWordLength {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+func charsetSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.present {
+			size += class.size
+		}
+	}
+	return size
+}
+
+type PasswordStrengthPolicy struct {
+	estimator serviceInterface.PasswordStrengthEstimator
+	minBits   float64
+}
+
+func NewPasswordStrengthPolicy(estimator serviceInterface.PasswordStrengthEstimator, minBits float64) *PasswordStrengthPolicy {
+	return &PasswordStrengthPolicy{
+		estimator: estimator,
+		minBits:   minBits,
+	}
+}
+
+func (p *PasswordStrengthPolicy) Check(password string, userInputs ...string) error {
+	bits, suggestions := p.estimator.Estimate(password, userInputs)
+	if bits >= p.minBits {
+		return nil
+	}
+
+	return customErr.NewWeakPasswordError("Password is too weak", bits, p.minBits, suggestions)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"jwtgo/internal/app/controller/http/dto"
+	customErr "jwtgo/internal/app/error"
+)
+
+func TestEntropyEstimator(t *testing.T) {
+	estimator := NewEntropyEstimator()
+	userInputs := []string{"john.smith@example.com"}
+
+	tests := []struct {
+		password   string
+		maxBits    float64
+		minBits    float64
+		suggestion string
+	}{
+		{"password", 40, 0, SuggestionAvoidCommon},
+		{"P@ssw0rd123", 40, 0, SuggestionAvoidCommon},
+		{"aaaaaaaaaaaaaa", 40, 0, SuggestionAvoidRepeats},
+		{"abcdefghijkl", 40, 0, SuggestionAvoidSequences},
+		{"qwertyuiop12", 40, 0, SuggestionAvoidKeyboard},
+		{"johnsmith2024", 40, 0, SuggestionAvoidPersonal},
+		{"zq", 40, 0, SuggestionLonger},
+		{"Tr0ub4dor&3", 1000, 60, SuggestionLonger},
+		{"x7#Kp9!qLm2$", 1000, 60, ""},
+		{testPassword, 1000, 60, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			bits, suggestions := estimator.Estimate(tt.password, userInputs)
+
+			if bits < tt.minBits || bits > tt.maxBits {
+				t.Fatalf("estimate = %.1f bits, want between %.0f and %.0f", bits, tt.minBits, tt.maxBits)
+			}
+			if tt.suggestion != "" && !slices.Contains(suggestions, tt.suggestion) {
+				t.Fatalf("suggestions = %v, want %s", suggestions, tt.suggestion)
+			}
+		})
+	}
+}
+
+func TestEntropyEstimatorPersonalInformation(t *testing.T) {
+	estimator := NewEntropyEstimator()
+
+	withEmail, suggestions := estimator.Estimate("johnsmith2024", []string{"john.smith@example.com"})
+	withoutEmail, _ := estimator.Estimate("johnsmith2024", nil)
+
+	if withEmail >= withoutEmail {
+		t.Fatalf("estimate with the email (%.1f bits) is not below the one without (%.1f bits)", withEmail, withoutEmail)
+	}
+	if !slices.Contains(suggestions, SuggestionAvoidPersonal) {
+		t.Fatalf("suggestions = %v, want %s", suggestions, SuggestionAvoidPersonal)
+	}
+}
+
+type fixedEstimator struct {
+	bits       float64
+	userInputs []string
+}
+
+func (e *fixedEstimator) Estimate(password string, userInputs []string) (float64, []string) {
+	e.userInputs = userInputs
+	return e.bits, []string{SuggestionLonger}
+}
+
+func TestPasswordStrengthPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		bits    float64
+		minBits float64
+		weak    bool
+	}{
+		{"below threshold", 39.9, 40, true},
+		{"at threshold", 40, 40, false},
+		{"above threshold", 80, 40, false},
+		{"custom threshold", 50, 60, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimator := &fixedEstimator{bits: tt.bits}
+			err := NewPasswordStrengthPolicy(estimator, tt.minBits).Check("secret", "user@example.com")
+
+			if !slices.Equal(estimator.userInputs, []string{"user@example.com"}) {
+				t.Fatalf("estimator got user inputs %v", estimator.userInputs)
+			}
+			if !tt.weak {
+				if err != nil {
+					t.Fatalf("error = %v, want none", err)
+				}
+				return
+			}
+
+			var weakErr *customErr.WeakPasswordError
+			if !errors.As(err, &weakErr) || !errors.Is(err, customErr.ErrWeakPassword) {
+				t.Fatalf("error = %v, want a WeakPasswordError", err)
+			}
+			if weakErr.Bits != tt.bits || weakErr.MinBits != tt.minBits || !slices.Equal(weakErr.Suggestions, []string{SuggestionLonger}) {
+				t.Fatalf("error = %+v", weakErr)
+			}
+		})
+	}
+}
+
+func TestSignUpRejectsWeakPassword(t *testing.T) {
+	authService, userRepository := newTestAuthService(t)
+	authService.passwordStrength = NewPasswordStrengthPolicy(NewEntropyEstimator(), 40)
+	ctx := context.Background()
+
+	if _, err := authService.SignUp(ctx, &dto.UserCredentialsDTO{Email: "john.smith@example.com", Password: "johnsmith2024"}); !errors.Is(err, customErr.ErrWeakPassword) {
+		t.Fatalf("error = %v, want WEAK_PASSWORD", err)
+	}
+	if user, _ := userRepository.GetByEmail(ctx, "john.smith@example.com"); user != nil {
+		t.Fatal("a user was created with a weak password")
+	}
+
+	signUp(t, authService, "john.smith@example.com")
+}
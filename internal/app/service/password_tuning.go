@@ -0,0 +1,34 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const bcryptTuningSamples = 3
+
+// MeasureBcryptCost times a hash at cost and returns the cost whose duration is
+// closest to target, assuming each cost step doubles the work. The fastest
+// sample is used to keep scheduler noise out of the estimate.
+func MeasureBcryptCost(cost int, target time.Duration) (int, time.Duration, error) {
+	password := []byte("bcrypt-cost-calibration")
+
+	var measured time.Duration
+	for i := 0; i < bcryptTuningSamples; i++ {
+		start := time.Now()
+		if _, err := bcrypt.GenerateFromPassword(password, cost); err != nil {
+			return cost, 0, err
+		}
+
+		if elapsed := time.Since(start); measured == 0 || elapsed < measured {
+			measured = elapsed
+		}
+	}
+
+	steps := int(math.Round(math.Log2(float64(target) / float64(measured))))
+	recommended := min(max(cost+steps, bcrypt.MinCost), bcrypt.MaxCost)
+
+	return recommended, measured, nil
+}
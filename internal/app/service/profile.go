@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/controller/http/mapper"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	"jwtgo/internal/pkg/tracing"
+	"jwtgo/pkg/logging"
+)
+
+type ProfileService struct {
+	userRepository repositoryInterface.UserRepository
+	logger         *logging.Logger
+}
+
+func NewProfileService(userRepository repositoryInterface.UserRepository, logger *logging.Logger) *ProfileService {
+	return &ProfileService{
+		userRepository: userRepository,
+		logger:         logger,
+	}
+}
+
+func (s *ProfileService) Get(ctx context.Context, userId string) (_ *dto.ProfileDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ProfileService.Get", tracing.UserId(userId))
+	defer func() { tracing.End(span, err) }()
+
+	existingUserEntity, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to check user id", err)
+	}
+
+	if existingUserEntity == nil {
+		return nil, customErr.NewUserNotFoundError("User not found", userId)
+	}
+
+	return mapper.MapToProfileDTO(existingUserEntity), nil
+}
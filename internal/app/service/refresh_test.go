@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	"jwtgo/internal/app/schema"
+	"jwtgo/internal/pkg/security"
+)
+
+// fakeNotifier records security notifications. AuthService sends them from a
+// goroutine, so tests read them through wait.
+type fakeNotifier struct {
+	mu     sync.Mutex
+	events []security.Event
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, event security.Event) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.events = append(n.events, event)
+	return nil
+}
+
+// wait returns the types of the notifications sent so far, once want of them
+// have arrived and no more turned up shortly after.
+func (n *fakeNotifier) wait(t *testing.T, want int) []string {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		n.mu.Lock()
+		count := len(n.events)
+		n.mu.Unlock()
+
+		if count >= want || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	types := make([]string, 0, len(n.events))
+	for _, event := range n.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// slowUserRepository delays user lookups so concurrent callers overlap, and
+// counts updates.
+type slowUserRepository struct {
+	repositoryInterface.UserRepository
+	delay   time.Duration
+	updates atomic.Int32
+}
+
+func (r *slowUserRepository) GetById(ctx context.Context, id string) (*entity.User, error) {
+	time.Sleep(r.delay)
+	return r.UserRepository.GetById(ctx, id)
+}
+
+func (r *slowUserRepository) Update(ctx context.Context, id string, user *entity.User) (bool, error) {
+	r.updates.Add(1)
+	return r.UserRepository.Update(ctx, id, user)
+}
+
+func TestConcurrentRefreshesShareOneRotation(t *testing.T) {
+	authService, userRepository := newTestAuthService(t)
+	notifier := &fakeNotifier{}
+	authService.securityNotifier = notifier
+
+	signUp(t, authService, "user@example.com")
+	refreshToken := signIn(t, authService, "user@example.com").RefreshToken
+
+	repository := &slowUserRepository{UserRepository: userRepository, delay: 200 * time.Millisecond}
+	authService.userRepository = repository
+
+	const callers = 50
+
+	results := make([]*dto.UserTokensDTO, callers)
+	errs := make([]error, callers)
+	start := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: refreshToken})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("refresh %d: %v", i, err)
+		}
+		if results[i].AccessToken == "" || results[i].AccessToken != results[0].AccessToken || results[i].RefreshToken != results[0].RefreshToken {
+			t.Fatalf("refresh %d got a different token pair", i)
+		}
+	}
+
+	if updates := repository.updates.Load(); updates != 1 {
+		t.Fatalf("%d rotations, want 1", updates)
+	}
+	if results[0].RefreshToken == refreshToken {
+		t.Fatal("the refresh token was not rotated")
+	}
+
+	user, _ := userRepository.GetByEmail(context.Background(), "user@example.com")
+	if user.RefreshToken != results[0].RefreshToken {
+		t.Fatal("the stored refresh token is not the one handed out")
+	}
+
+	if events := notifier.wait(t, 0); len(events) != 0 {
+		t.Fatalf("notifications %v, want no reuse alarms", events)
+	}
+}
+
+func TestLateDuplicateRefreshWithinGracePeriod(t *testing.T) {
+	authService, userRepository := newTestAuthService(t)
+	notifier := &fakeNotifier{}
+	authService.securityNotifier = notifier
+	authService.refreshGrace = 200 * time.Millisecond
+	ctx := context.Background()
+
+	signUp(t, authService, "user@example.com")
+	refreshToken := signIn(t, authService, "user@example.com").RefreshToken
+
+	repository := &slowUserRepository{UserRepository: userRepository}
+	authService.userRepository = repository
+
+	first, err := authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: refreshToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	late, err := authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: refreshToken})
+	if err != nil {
+		t.Fatalf("late duplicate: %v", err)
+	}
+	if late.AccessToken != first.AccessToken || late.RefreshToken != first.RefreshToken {
+		t.Fatal("the late duplicate got a different token pair")
+	}
+	if updates := repository.updates.Load(); updates != 1 {
+		t.Fatalf("%d rotations, want 1", updates)
+	}
+	if events := notifier.wait(t, 0); len(events) != 0 {
+		t.Fatalf("notifications %v, want no reuse alarms", events)
+	}
+
+	if _, err := authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: first.RefreshToken}); err != nil {
+		t.Fatalf("refreshing the handed out pair: %v", err)
+	}
+
+	time.Sleep(authService.refreshGrace)
+
+	_, err = authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: refreshToken})
+	if !errors.Is(err, customErr.ErrInvalidToken) {
+		t.Fatalf("refresh after the grace period = %v, want %v", err, customErr.ErrInvalidToken)
+	}
+	if events := notifier.wait(t, 1); !slices.Equal(events, []string{security.EventRefreshTokenReuse}) {
+		t.Fatalf("notifications %v, want the reuse alarm", events)
+	}
+}
+
+// nextSecond waits for the next wall-clock second. Subject revocation has
+// second precision, so tokens must be issued in an earlier second than the
+// revocation for it to cover them.
+func nextSecond() {
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	authService, _ := newTestAuthService(t)
+	notifier := &fakeNotifier{}
+	authService.securityNotifier = notifier
+	ctx := context.Background()
+
+	signUp(t, authService, "user@example.com")
+	stolen := signIn(t, authService, "user@example.com")
+	current, err := authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: stolen.RefreshToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nextSecond()
+
+	if _, err := authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: stolen.RefreshToken}); !errors.Is(err, customErr.ErrInvalidToken) {
+		t.Fatalf("reused refresh token error = %v, want INVALID_TOKEN", err)
+	}
+
+	if _, err := authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: current.RefreshToken}); !errors.Is(err, customErr.ErrInvalidToken) {
+		t.Fatalf("current refresh token error = %v, want INVALID_TOKEN after the family was revoked", err)
+	}
+	if _, err := authService.jwtService.ValidateToken(current.AccessToken, schema.TokenTypeAccess); err == nil {
+		t.Fatal("the access token of the revoked family is still valid")
+	}
+
+	events := notifier.wait(t, 1)
+	if len(events) != 1 || events[0] != security.EventRefreshTokenReuse {
+		t.Fatalf("notifications = %v, want one %s for the reuse only", events, security.EventRefreshTokenReuse)
+	}
+
+	// Signing in again starts a new family.
+	fresh := signIn(t, authService, "user@example.com")
+	if _, err := authService.Refresh(ctx, &dto.UserRefreshTokenDTO{RefreshToken: fresh.RefreshToken}); err != nil {
+		t.Fatalf("refresh after signing in again: %v", err)
+	}
+}
+
+// TestSecurityNotifications runs each flow against a fresh service and checks
+// exactly which notifications it sends.
+func TestSecurityNotifications(t *testing.T) {
+	tests := []struct {
+		name string
+		flow func(t *testing.T, authService *AuthService)
+		want []string
+	}{
+		{
+			name: "sign-in, refresh and reauthentication",
+			flow: func(t *testing.T, authService *AuthService) {
+				tokens := signIn(t, authService, "user@example.com")
+				if _, err := authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: tokens.RefreshToken}); err != nil {
+					t.Fatal(err)
+				}
+				user, _ := authService.userRepository.GetByEmail(context.Background(), "user@example.com")
+				if _, err := authService.Reauthenticate(context.Background(), user.Id, &dto.ReauthenticateDTO{Password: testPassword}); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "failed sign-in",
+			flow: func(t *testing.T, authService *AuthService) {
+				authService.SignIn(context.Background(), &dto.UserCredentialsDTO{Email: "user@example.com", Password: "wrong password"})
+			},
+		},
+		{
+			name: "invalid refresh token",
+			flow: func(t *testing.T, authService *AuthService) {
+				authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: "garbage"})
+			},
+		},
+		{
+			name: "refresh token reuse",
+			flow: func(t *testing.T, authService *AuthService) {
+				tokens := signIn(t, authService, "user@example.com")
+				authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: tokens.RefreshToken})
+				authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: tokens.RefreshToken})
+			},
+			want: []string{security.EventRefreshTokenReuse},
+		},
+		{
+			name: "refresh of a revoked family",
+			flow: func(t *testing.T, authService *AuthService) {
+				tokens := signIn(t, authService, "user@example.com")
+				current, _ := authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: tokens.RefreshToken})
+				authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: tokens.RefreshToken})
+				authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: current.RefreshToken})
+				authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: current.RefreshToken})
+			},
+			want: []string{security.EventRefreshTokenReuse},
+		},
+		{
+			name: "refresh of a session replaced by a later sign-in",
+			flow: func(t *testing.T, authService *AuthService) {
+				firstDevice := signIn(t, authService, "user@example.com")
+				secondDevice := signIn(t, authService, "user@example.com")
+				if _, err := authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: firstDevice.RefreshToken}); !errors.Is(err, customErr.ErrInvalidToken) {
+					t.Fatalf("first device refresh error = %v, want INVALID_TOKEN", err)
+				}
+				if _, err := authService.Refresh(context.Background(), &dto.UserRefreshTokenDTO{RefreshToken: secondDevice.RefreshToken}); err != nil {
+					t.Fatalf("second device refresh: %v", err)
+				}
+			},
+		},
+		{
+			name: "second sign-in in single-session mode",
+			flow: func(t *testing.T, authService *AuthService) {
+				authService.singleSession = true
+				signIn(t, authService, "user@example.com")
+				signIn(t, authService, "user@example.com")
+			},
+			want: []string{security.EventSessionsRevoked},
+		},
+		{
+			name: "second sign-in without single-session mode",
+			flow: func(t *testing.T, authService *AuthService) {
+				signIn(t, authService, "user@example.com")
+				signIn(t, authService, "user@example.com")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService, _ := newTestAuthService(t)
+			signUp(t, authService, "user@example.com")
+
+			notifier := &fakeNotifier{}
+			authService.securityNotifier = notifier
+			tt.flow(t, authService)
+
+			if events := notifier.wait(t, len(tt.want)); !slices.Equal(events, tt.want) && len(events)+len(tt.want) > 0 {
+				t.Fatalf("notifications = %v, want %v", events, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/controller/http/mapper"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/tracing"
+)
+
+type SecurityEventService struct {
+	auditReader serviceInterface.AuditReader
+}
+
+func NewSecurityEventService(auditReader serviceInterface.AuditReader) *SecurityEventService {
+	return &SecurityEventService{
+		auditReader: auditReader,
+	}
+}
+
+func (s *SecurityEventService) List(ctx context.Context, userId string, limit int) (_ []dto.SecurityEventDTO, err error) {
+	_, span := tracing.Start(ctx, "SecurityEventService.List", tracing.UserId(userId))
+	defer func() { tracing.End(span, err) }()
+
+	return mapper.MapToSecurityEventDTOs(s.auditReader.Recent(userId, limit)), nil
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/audit"
+	"jwtgo/internal/pkg/tracing"
+	"jwtgo/pkg/logging"
+)
+
+const (
+	ImportStatusCreated = "created"
+	ImportStatusFailed  = "failed"
+)
+
+type UserImportService struct {
+	userRepository   repositoryInterface.UserRepository
+	passwordService  serviceInterface.PasswordService
+	identityVerifier serviceInterface.IdentityVerifier
+	auditLogger      serviceInterface.AuditLogger
+	workers          int
+	logger           *logging.Logger
+}
+
+func NewUserImportService(
+	userRepository repositoryInterface.UserRepository,
+	passwordService serviceInterface.PasswordService,
+	identityVerifier serviceInterface.IdentityVerifier,
+	auditLogger serviceInterface.AuditLogger,
+	logger *logging.Logger,
+) *UserImportService {
+	return &UserImportService{
+		userRepository:   userRepository,
+		passwordService:  passwordService,
+		identityVerifier: identityVerifier,
+		auditLogger:      auditLogger,
+		workers:          runtime.GOMAXPROCS(0),
+		logger:           logger,
+	}
+}
+
+// Import creates each record independently, so a failed record is reported in
+// its result and does not roll back the others. Records are validated in
+// order and then created by one worker per CPU, because hashing temporary
+// passwords dominates the cost of a large batch.
+func (s *UserImportService) Import(ctx context.Context, importUsersDTO *dto.ImportUsersDTO) (_ []dto.ImportResultDTO, err error) {
+	ctx, span := tracing.Start(ctx, "UserImportService.Import")
+	defer func() { tracing.End(span, err) }()
+
+	results := make([]dto.ImportResultDTO, len(importUsersDTO.Users))
+	seen := make(map[string]struct{}, len(importUsersDTO.Users))
+
+	var workers errgroup.Group
+	workers.SetLimit(s.workers)
+
+	for i := range importUsersDTO.Users {
+		importUserDTO := &importUsersDTO.Users[i]
+		results[i] = dto.ImportResultDTO{Email: importUserDTO.Email, Status: ImportStatusCreated}
+
+		if err := checkImportRecord(importUserDTO, seen, s.passwordService); err != nil {
+			s.failImportResult(ctx, &results[i], err)
+			continue
+		}
+
+		workers.Go(func() error {
+			if err := s.importUser(ctx, importUserDTO); err != nil {
+				s.failImportResult(ctx, &results[i], err)
+			}
+			return nil
+		})
+	}
+	_ = workers.Wait()
+
+	created := 0
+	for _, result := range results {
+		if result.Status == ImportStatusCreated {
+			created++
+		}
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.Record(ctx, audit.Event{
+			Action:  "user_import",
+			Outcome: AuditOutcomeSuccess,
+			Fields: map[string]string{
+				"created": strconv.Itoa(created),
+				"failed":  strconv.Itoa(len(results) - created),
+			},
+		})
+	}
+
+	return results, nil
+}
+
+// Export lists every user, oldest first. Password hashes and salts are left
+// out: an API key cannot re-authenticate, so nothing would stand between a
+// leaked admin key and every hash in the store.
+func (s *UserImportService) Export(ctx context.Context) (_ []dto.ExportedUserDTO, err error) {
+	ctx, span := tracing.Start(ctx, "UserImportService.Export")
+	defer func() { tracing.End(span, err) }()
+
+	users, err := s.userRepository.GetAll(ctx)
+	if err != nil {
+		return nil, customErr.NewInternalServerError("Failed to get users", err)
+	}
+
+	slices.SortFunc(users, func(a, b *entity.User) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Email, b.Email)
+	})
+
+	exportedUserDTOs := make([]dto.ExportedUserDTO, 0, len(users))
+	for _, user := range users {
+		status := user.Status
+		if status == "" {
+			status = entity.UserStatusActive
+		}
+
+		exportedUserDTOs = append(exportedUserDTOs, dto.ExportedUserDTO{
+			Id:        user.Id,
+			Email:     user.Email,
+			Status:    status,
+			Verified:  status == entity.UserStatusActive,
+			Roles:     user.Roles,
+			CreatedAt: user.CreatedAt,
+		})
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.Record(ctx, audit.Event{
+			Action:  "user_export",
+			Outcome: AuditOutcomeSuccess,
+			Fields:  map[string]string{"exported": strconv.Itoa(len(exportedUserDTOs))},
+		})
+	}
+
+	return exportedUserDTOs, nil
+}
+
+// failImportResult reports err in the record's result. Internal errors carry
+// the backend cause, so only their generic description reaches the client and
+// the cause is logged instead.
+func (s *UserImportService) failImportResult(ctx context.Context, result *dto.ImportResultDTO, err error) {
+	result.Status = ImportStatusFailed
+	result.Code = customErr.CodeInternalServerError
+	var coded interface{ Code() string }
+	if errors.As(err, &coded) {
+		result.Code = coded.Code()
+	}
+
+	if result.Code != customErr.CodeInternalServerError {
+		result.Reason = err.Error()
+		return
+	}
+
+	definition, _ := customErr.Lookup(customErr.CodeInternalServerError)
+	result.Reason = definition.Description
+	s.logger.ForContext(ctx).WithField("email", result.Email).Error("Failed to import user: ", err)
+}
+
+// checkImportRecord rejects records that can be refused without touching the
+// repository, including an email repeated earlier in the same batch.
+func checkImportRecord(importUserDTO *dto.ImportUserDTO, seen map[string]struct{}, passwordService serviceInterface.PasswordService) error {
+	address, err := mail.ParseAddress(importUserDTO.Email)
+	if err != nil || address.Address != importUserDTO.Email {
+		return customErr.NewInvalidRequestError("Invalid email address")
+	}
+
+	key := strings.ToLower(importUserDTO.Email)
+	if _, ok := seen[key]; ok {
+		return customErr.NewAlreadyExistsError("Email is repeated in the batch", importUserDTO.Email)
+	}
+	seen[key] = struct{}{}
+
+	hasPassword := importUserDTO.Password != ""
+	hasHash := importUserDTO.PasswordHash != "" || importUserDTO.Salt != ""
+	if hasPassword == hasHash {
+		return customErr.NewInvalidRequestError("Exactly one of password or password_hash must be set")
+	}
+
+	if hasHash {
+		if !passwordService.IsHash(importUserDTO.PasswordHash) {
+			return customErr.NewInvalidRequestError("Password hash does not match the configured hasher")
+		}
+		if salt, err := hex.DecodeString(importUserDTO.Salt); err != nil || len(salt) != 32 {
+			return customErr.NewInvalidRequestError("Salt must be 32 hex-encoded bytes")
+		}
+	}
+
+	return nil
+}
+
+func (s *UserImportService) importUser(ctx context.Context, importUserDTO *dto.ImportUserDTO) error {
+	if err := ctx.Err(); err != nil {
+		return customErr.NewInternalServerError("Import did not finish in time", err)
+	}
+
+	existingUserEntity, err := s.userRepository.GetByEmail(ctx, importUserDTO.Email)
+	if err != nil {
+		return customErr.NewInternalServerError("Failed to check user email", err)
+	}
+
+	if existingUserEntity != nil {
+		return customErr.NewAlreadyExistsError("Email already exists", importUserDTO.Email)
+	}
+
+	hashedPassword, localSalt := importUserDTO.PasswordHash, importUserDTO.Salt
+	if importUserDTO.Password != "" {
+		localSalt, err = s.passwordService.GenerateSalt(32)
+		if err != nil {
+			return customErr.NewInternalServerError("Failed to create a user", err)
+		}
+
+		hashedPassword, err = s.passwordService.HashPassword(importUserDTO.Password, localSalt)
+		if err != nil {
+			return customErr.NewInternalServerError("Failed to create a user", err)
+		}
+	}
+
+	// Without a verifier nothing could ever activate a pending account, so
+	// unverified users are only held back when verification is enabled.
+	pending := !importUserDTO.Verified && s.identityVerifier != nil
+
+	status := entity.UserStatusActive
+	if pending {
+		status = entity.UserStatusPendingVerification
+	}
+
+	now := time.Now().UTC()
+	_, err = s.userRepository.Create(ctx, &entity.User{
+		Email:     importUserDTO.Email,
+		Password:  hashedPassword,
+		Salt:      localSalt,
+		Status:    status,
+		Roles:     importUserDTO.Roles,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return customErr.NewInternalServerError("Failed to create a user", err)
+	}
+
+	if pending {
+		_, _, err = startVerification(ctx, s.userRepository, s.identityVerifier, importUserDTO.Email, s.logger)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"jwtgo/internal/app/adapter/memory/repository"
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/entity"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/verification"
+	"jwtgo/pkg/logging"
+)
+
+// trackingPasswordService records how many hashes run at the same time.
+type trackingPasswordService struct {
+	serviceInterface.PasswordService
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (p *trackingPasswordService) HashPassword(password, localSalt string) (string, error) {
+	running := p.running.Add(1)
+	defer p.running.Add(-1)
+
+	for {
+		peak := p.peak.Load()
+		if running <= peak || p.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	return p.PasswordService.HashPassword(password, localSalt)
+}
+
+func newTestUserImportService(t *testing.T) (*UserImportService, *repository.UserRepository, *PasswordService) {
+	t.Helper()
+
+	logger := logging.GetLogger("panic")
+	userRepository := repository.NewUserRepository()
+	passwordService := NewPasswordService(4, "test-salt")
+
+	return NewUserImportService(userRepository, passwordService, nil, nil, &logger), userRepository, passwordService
+}
+
+func importUsers(t *testing.T, userImportService *UserImportService, users ...dto.ImportUserDTO) []dto.ImportResultDTO {
+	t.Helper()
+
+	results, err := userImportService.Import(context.Background(), &dto.ImportUsersDTO{Users: users})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(users) {
+		t.Fatalf("got %d results for %d users", len(results), len(users))
+	}
+
+	return results
+}
+
+func TestImportReportsEachRecord(t *testing.T) {
+	userImportService, userRepository, passwordService := newTestUserImportService(t)
+
+	salt, _ := passwordService.GenerateSalt(32)
+	hash, _ := passwordService.HashPassword(testPassword, salt)
+
+	if _, err := userRepository.Create(context.Background(), &entity.User{Email: "existing@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		user   dto.ImportUserDTO
+		status string
+		code   string
+	}{
+		{"temporary password", dto.ImportUserDTO{Email: "temp@example.com", Password: "temporary", Verified: true}, ImportStatusCreated, ""},
+		{"repeated in batch", dto.ImportUserDTO{Email: "TEMP@example.com", Password: "temporary"}, ImportStatusFailed, "ALREADY_EXISTS"},
+		{"pre-hashed password", dto.ImportUserDTO{Email: "hashed@example.com", PasswordHash: hash, Salt: salt}, ImportStatusCreated, ""},
+		{"foreign hash format", dto.ImportUserDTO{Email: "argon@example.com", PasswordHash: "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$aGFzaA", Salt: salt}, ImportStatusFailed, "INVALID_REQUEST"},
+		{"short salt", dto.ImportUserDTO{Email: "salt@example.com", PasswordHash: hash, Salt: "abcd"}, ImportStatusFailed, "INVALID_REQUEST"},
+		{"password and hash", dto.ImportUserDTO{Email: "both@example.com", Password: "temporary", PasswordHash: hash, Salt: salt}, ImportStatusFailed, "INVALID_REQUEST"},
+		{"no password", dto.ImportUserDTO{Email: "none@example.com"}, ImportStatusFailed, "INVALID_REQUEST"},
+		{"invalid email", dto.ImportUserDTO{Email: "not an email", Password: "temporary"}, ImportStatusFailed, "INVALID_REQUEST"},
+		{"existing user", dto.ImportUserDTO{Email: "existing@example.com", Password: "temporary"}, ImportStatusFailed, "ALREADY_EXISTS"},
+	}
+
+	users := make([]dto.ImportUserDTO, 0, len(tests))
+	for _, tt := range tests {
+		users = append(users, tt.user)
+	}
+
+	results := importUsers(t, userImportService, users...)
+	for i, tt := range tests {
+		if results[i].Email != tt.user.Email || results[i].Status != tt.status || results[i].Code != tt.code {
+			t.Errorf("%s: result = %+v, want status %q and code %q", tt.name, results[i], tt.status, tt.code)
+		}
+	}
+
+	imported, _ := userRepository.GetByEmail(context.Background(), "hashed@example.com")
+	if imported == nil || !passwordService.VerifyPassword(testPassword, imported.Password, imported.Salt) {
+		t.Fatal("pre-hashed password does not verify after import")
+	}
+}
+
+func TestImportActivatesUnverifiedUsersWithoutVerifier(t *testing.T) {
+	userImportService, userRepository, _ := newTestUserImportService(t)
+
+	importUsers(t, userImportService, dto.ImportUserDTO{Email: "user@example.com", Password: "temporary", Roles: []string{"admin"}})
+
+	imported, _ := userRepository.GetByEmail(context.Background(), "user@example.com")
+	if imported.Status != entity.UserStatusActive {
+		t.Fatalf("status = %q, want %q", imported.Status, entity.UserStatusActive)
+	}
+	if len(imported.Roles) != 1 || imported.Roles[0] != "admin" {
+		t.Fatalf("roles = %v, want [admin]", imported.Roles)
+	}
+}
+
+func TestImportStartsVerificationForUnverifiedUsers(t *testing.T) {
+	userImportService, userRepository, _ := newTestUserImportService(t)
+	verifier := &verification.Mock{}
+	userImportService.identityVerifier = verifier
+
+	importUsers(t, userImportService,
+		dto.ImportUserDTO{Email: "pending@example.com", Password: "temporary"},
+		dto.ImportUserDTO{Email: "verified@example.com", Password: "temporary", Verified: true},
+	)
+
+	if started := verifier.Started(); len(started) != 1 || started[0] != "pending@example.com" {
+		t.Fatalf("verification started for %v, want only pending@example.com", started)
+	}
+
+	pending, _ := userRepository.GetByEmail(context.Background(), "pending@example.com")
+	if pending.Status != entity.UserStatusPendingVerification {
+		t.Fatalf("unverified status = %q, want %q", pending.Status, entity.UserStatusPendingVerification)
+	}
+
+	verified, _ := userRepository.GetByEmail(context.Background(), "verified@example.com")
+	if verified.Status != entity.UserStatusActive {
+		t.Fatalf("verified status = %q, want %q", verified.Status, entity.UserStatusActive)
+	}
+}
+
+func TestImportRemovesUserWhenVerificationFails(t *testing.T) {
+	userImportService, userRepository, _ := newTestUserImportService(t)
+	userImportService.identityVerifier = &verification.Mock{Err: fmt.Errorf("provider unavailable")}
+
+	results := importUsers(t, userImportService, dto.ImportUserDTO{Email: "user@example.com", Password: "temporary"})
+	if results[0].Status != ImportStatusFailed || results[0].Code != "INTERNAL_SERVER_ERROR" {
+		t.Fatalf("result = %+v, want an internal error", results[0])
+	}
+
+	if user, _ := userRepository.GetByEmail(context.Background(), "user@example.com"); user != nil {
+		t.Fatal("user was kept although verification could not start")
+	}
+}
+
+func TestImportHashesWithBoundedParallelism(t *testing.T) {
+	const workers = 4
+
+	userImportService, _, passwordService := newTestUserImportService(t)
+	tracking := &trackingPasswordService{PasswordService: passwordService}
+	userImportService.passwordService = tracking
+	userImportService.workers = workers
+
+	users := make([]dto.ImportUserDTO, 40)
+	for i := range users {
+		users[i] = dto.ImportUserDTO{Email: fmt.Sprintf("user%d@example.com", i), Password: "temporary", Verified: true}
+	}
+
+	for _, result := range importUsers(t, userImportService, users...) {
+		if result.Status != ImportStatusCreated {
+			t.Fatalf("result = %+v, want created", result)
+		}
+	}
+
+	if peak := tracking.peak.Load(); peak < 2 || peak > workers {
+		t.Fatalf("peak concurrent hashes = %d, want between 2 and %d", peak, workers)
+	}
+}
+
+func TestImportFailsRemainingRecordsWhenContextEnds(t *testing.T) {
+	userImportService, userRepository, _ := newTestUserImportService(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := userImportService.Import(ctx, &dto.ImportUsersDTO{Users: []dto.ImportUserDTO{{Email: "user@example.com", Password: "temporary"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Status != ImportStatusFailed {
+		t.Fatalf("result = %+v, want failed", results[0])
+	}
+
+	if user, _ := userRepository.GetByEmail(context.Background(), "user@example.com"); user != nil {
+		t.Fatal("user was created after the context ended")
+	}
+}
+
+// failingUserRepository fails every Create with a driver-style error.
+type failingUserRepository struct {
+	*repository.UserRepository
+	err error
+}
+
+func (r *failingUserRepository) Create(context.Context, *entity.User) (bool, error) {
+	return false, r.err
+}
+
+func TestImportHidesInternalCauses(t *testing.T) {
+	const cause = "connection(10.0.0.5:27017[-3]) incomplete read of message header"
+
+	userImportService, userRepository, _ := newTestUserImportService(t)
+	userImportService.userRepository = &failingUserRepository{UserRepository: userRepository, err: errors.New(cause)}
+
+	nullLogger, hook := test.NewNullLogger()
+	userImportService.logger = &logging.Logger{Entry: logrus.NewEntry(nullLogger)}
+
+	results := importUsers(t, userImportService,
+		dto.ImportUserDTO{Email: "user@example.com", Password: "temporary", Verified: true},
+		dto.ImportUserDTO{Email: "not an email", Password: "temporary"},
+	)
+
+	if results[0].Code != "INTERNAL_SERVER_ERROR" || strings.Contains(results[0].Reason, "10.0.0.5") || results[0].Reason == "" {
+		t.Fatalf("internal failure = %+v, want a generic reason", results[0])
+	}
+	if results[1].Code != "INVALID_REQUEST" || results[1].Reason != "Invalid email address" {
+		t.Fatalf("client failure = %+v, want its own reason", results[1])
+	}
+
+	body, err := json.Marshal(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "27017") {
+		t.Fatalf("response leaks the cause: %s", body)
+	}
+
+	logged := false
+	for _, entry := range hook.AllEntries() {
+		if strings.Contains(fmt.Sprint(entry.Message), cause) && entry.Data["email"] == "user@example.com" {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Fatal("the cause was not logged")
+	}
+}
+
+func TestExportRoundTripsThroughImport(t *testing.T) {
+	userImportService, userRepository, _ := newTestUserImportService(t)
+	ctx := context.Background()
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, user := range []*entity.User{
+		{Email: "legacy@example.com", Password: "hash", Salt: "salt", CreatedAt: created},
+		{Email: "admin@example.com", Password: "hash", Salt: "salt", Status: entity.UserStatusActive, Roles: []string{"admin"}, CreatedAt: created.Add(time.Hour)},
+		{Email: "pending@example.com", Password: "hash", Salt: "salt", Status: entity.UserStatusPendingVerification, CreatedAt: created.Add(2 * time.Hour)},
+	} {
+		user.Id = fmt.Sprintf("user-%d", i)
+		if _, err := userRepository.Create(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exported, err := userImportService.Export(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		email    string
+		status   string
+		verified bool
+	}{
+		{"legacy@example.com", entity.UserStatusActive, true},
+		{"admin@example.com", entity.UserStatusActive, true},
+		{"pending@example.com", entity.UserStatusPendingVerification, false},
+	}
+	if len(exported) != len(want) {
+		t.Fatalf("exported %d users, want %d", len(exported), len(want))
+	}
+	for i, w := range want {
+		if exported[i].Email != w.email || exported[i].Status != w.status || exported[i].Verified != w.verified {
+			t.Errorf("line %d = %+v, want %s %s verified=%t", i, exported[i], w.email, w.status, w.verified)
+		}
+	}
+
+	body, err := json.Marshal(exported[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "hash") || strings.Contains(string(body), "salt") {
+		t.Fatalf("the export carries password material: %s", body)
+	}
+
+	// Each exported line is an import record once a temporary password is set.
+	var record dto.ImportUserDTO
+	if err := json.Unmarshal(body, &record); err != nil {
+		t.Fatal(err)
+	}
+	record.Email = "copy@example.com"
+	record.Password = testPassword
+
+	if results := importUsers(t, userImportService, record); results[0].Status != ImportStatusCreated {
+		t.Fatalf("re-importing an exported user: %+v", results[0])
+	}
+	user, _ := userRepository.GetByEmail(ctx, "copy@example.com")
+	if user == nil || user.Status != entity.UserStatusActive || len(user.Roles) != 1 || user.Roles[0] != "admin" {
+		t.Fatalf("re-imported user = %+v", user)
+	}
+}
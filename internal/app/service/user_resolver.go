@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+)
+
+const (
+	IdentifierEmail = "email"
+	IdentifierId    = "id"
+)
+
+var DefaultIdentifierOrder = []string{IdentifierEmail, IdentifierId}
+
+type UserResolver struct {
+	userRepository repositoryInterface.UserRepository
+	order          []string
+}
+
+func NewUserResolver(userRepository repositoryInterface.UserRepository, order []string) *UserResolver {
+	if len(order) == 0 {
+		order = DefaultIdentifierOrder
+	}
+
+	return &UserResolver{
+		userRepository: userRepository,
+		order:          order,
+	}
+}
+
+// FindUserByIdentifier tries every configured lookup in order and returns the
+// first match, or nil when none matches. An identifier that resolves to
+// different users under different lookups is rejected as ambiguous.
+func (r *UserResolver) FindUserByIdentifier(ctx context.Context, identifier string) (*entity.User, error) {
+	var resolvedUser *entity.User
+	var resolvedBy string
+
+	for _, kind := range r.order {
+		user, err := r.lookup(ctx, kind, identifier)
+		if err != nil {
+			return nil, err
+		}
+
+		if user == nil {
+			continue
+		}
+
+		if resolvedUser != nil && resolvedUser.Id != user.Id {
+			return nil, customErr.NewAmbiguousIdentifierError("Identifier matches more than one user", identifier, []string{resolvedBy, kind})
+		}
+
+		if resolvedUser == nil {
+			resolvedUser, resolvedBy = user, kind
+		}
+	}
+
+	return resolvedUser, nil
+}
+
+func (r *UserResolver) lookup(ctx context.Context, kind, identifier string) (*entity.User, error) {
+	switch kind {
+	case IdentifierEmail:
+		return r.userRepository.GetByEmail(ctx, identifier)
+	case IdentifierId:
+		return r.userRepository.GetById(ctx, identifier)
+	default:
+		return nil, customErr.NewInternalServerError("Failed to look up user", fmt.Errorf("unknown identifier type %q", kind))
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"jwtgo/internal/app/adapter/memory/repository"
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+)
+
+// newResolverRepository stores users whose ids and emails overlap: Mallory's
+// id is Bob's email, and Carol's id is her own email.
+func newResolverRepository(t *testing.T) *repository.UserRepository {
+	t.Helper()
+
+	userRepository := repository.NewUserRepository()
+	for _, user := range []*entity.User{
+		{Id: "alice-id", Email: "alice@example.com"},
+		{Id: "bob-id", Email: "bob@example.com"},
+		{Id: "bob@example.com", Email: "mallory@example.com"},
+		{Id: "carol@example.com", Email: "carol@example.com"},
+	} {
+		if _, err := userRepository.Create(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return userRepository
+}
+
+func TestFindUserByIdentifier(t *testing.T) {
+	userRepository := newResolverRepository(t)
+
+	const ambiguous = "ambiguous"
+
+	// want maps each resolution order to the id of the user found, "" for no
+	// user, or "ambiguous".
+	tests := []struct {
+		identifier string
+		want       map[string]string
+	}{
+		{"alice@example.com", map[string]string{
+			"email,id": "alice-id", "id,email": "alice-id", "email": "alice-id", "id": "",
+		}},
+		{"alice-id", map[string]string{
+			"email,id": "alice-id", "id,email": "alice-id", "email": "", "id": "alice-id",
+		}},
+		{"bob@example.com", map[string]string{
+			"email,id": ambiguous, "id,email": ambiguous, "email": "bob-id", "id": "bob@example.com",
+		}},
+		{"carol@example.com", map[string]string{
+			"email,id": "carol@example.com", "id,email": "carol@example.com", "email": "carol@example.com", "id": "carol@example.com",
+		}},
+		{"nobody@example.com", map[string]string{
+			"email,id": "", "id,email": "", "email": "", "id": "",
+		}},
+	}
+
+	for _, tt := range tests {
+		for order, want := range tt.want {
+			t.Run(tt.identifier+"/"+order, func(t *testing.T) {
+				kinds := strings.Split(order, ",")
+				user, err := NewUserResolver(userRepository, kinds).FindUserByIdentifier(context.Background(), tt.identifier)
+
+				if want == ambiguous {
+					var ambiguousErr *customErr.AmbiguousIdentifierError
+					if !errors.As(err, &ambiguousErr) || !errors.Is(err, customErr.ErrAmbiguousIdentifier) {
+						t.Fatalf("error = %v, want AMBIGUOUS_IDENTIFIER", err)
+					}
+					if !slices.Equal(ambiguousErr.Kinds, kinds) {
+						t.Fatalf("ambiguous between %v, want %v", ambiguousErr.Kinds, kinds)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := userId(user); got != want {
+					t.Fatalf("resolved user %q, want %q", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestFindUserByIdentifierDefaultOrder(t *testing.T) {
+	resolver := NewUserResolver(newResolverRepository(t), nil)
+
+	for _, identifier := range []string{"alice@example.com", "alice-id"} {
+		user, err := resolver.FindUserByIdentifier(context.Background(), identifier)
+		if err != nil || userId(user) != "alice-id" {
+			t.Fatalf("%s resolved to %q, %v, want alice-id", identifier, userId(user), err)
+		}
+	}
+}
+
+func TestFindUserByIdentifierUnknownKind(t *testing.T) {
+	resolver := NewUserResolver(newResolverRepository(t), []string{IdentifierEmail, "username"})
+
+	if _, err := resolver.FindUserByIdentifier(context.Background(), "nobody"); !errors.Is(err, customErr.ErrInternalServer) {
+		t.Fatalf("error = %v, want an internal error", err)
+	}
+}
+
+func userId(user *entity.User) string {
+	if user == nil {
+		return ""
+	}
+	return user.Id
+}
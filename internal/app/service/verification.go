@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/internal/pkg/audit"
+	"jwtgo/internal/pkg/tracing"
+	"jwtgo/pkg/logging"
+)
+
+type VerificationService struct {
+	userRepository repositoryInterface.UserRepository
+	auditLogger    serviceInterface.AuditLogger
+	logger         *logging.Logger
+}
+
+func NewVerificationService(
+	userRepository repositoryInterface.UserRepository,
+	auditLogger serviceInterface.AuditLogger,
+	logger *logging.Logger,
+) *VerificationService {
+	return &VerificationService{
+		userRepository: userRepository,
+		auditLogger:    auditLogger,
+		logger:         logger,
+	}
+}
+
+func (s *VerificationService) Complete(ctx context.Context, userId string, approved bool) (err error) {
+	ctx, span := tracing.Start(ctx, "VerificationService.Complete", tracing.UserId(userId))
+	defer func() { tracing.End(span, err) }()
+
+	existingUserEntity, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return customErr.NewInternalServerError("Failed to check user id", err)
+	}
+
+	// Decisions are final, so a late or duplicated callback cannot reactivate
+	// a rejected account.
+	if existingUserEntity == nil || existingUserEntity.Status != entity.UserStatusPendingVerification {
+		return customErr.NewInvalidRequestError("User is not pending identity verification")
+	}
+
+	status, outcome := entity.UserStatusActive, AuditOutcomeSuccess
+	if !approved {
+		status, outcome = entity.UserStatusRejected, AuditOutcomeFailure
+	}
+
+	_, err = s.userRepository.Update(ctx, userId, &entity.User{Status: status, UpdatedAt: time.Now().UTC()})
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while updating user: ", err)
+		return customErr.NewInternalServerError("Failed to update user status", err)
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.Record(ctx, audit.Event{Action: "identity_verification", UserId: userId, Outcome: outcome})
+	}
+
+	return nil
+}
+
+// startVerification hands a newly created pending user to identityVerifier and
+// activates the account when it is approved right away. The user is deleted
+// again when the verifier fails, so that creating it can be retried.
+func startVerification(
+	ctx context.Context,
+	userRepository repositoryInterface.UserRepository,
+	identityVerifier serviceInterface.IdentityVerifier,
+	email string,
+	logger *logging.Logger,
+) (*entity.User, bool, error) {
+	createdUser, err := userRepository.GetByEmail(ctx, email)
+	if err != nil || createdUser == nil {
+		logger.ForContext(ctx).Error("Error while getting created user: ", err)
+		return nil, false, customErr.NewInternalServerError("Failed to create a user", err)
+	}
+
+	approved, err := identityVerifier.StartVerification(ctx, createdUser.Id, createdUser.Email)
+	if err != nil {
+		logger.ForContext(ctx).Error("Error while starting identity verification: ", err)
+		if _, deleteErr := userRepository.Delete(ctx, createdUser.Id); deleteErr != nil {
+			logger.ForContext(ctx).Error("Error while deleting unverified user: ", deleteErr)
+		}
+		return nil, false, customErr.NewInternalServerError("Failed to start identity verification", err)
+	}
+
+	if approved {
+		_, err = userRepository.Update(ctx, createdUser.Id, &entity.User{Status: entity.UserStatusActive, UpdatedAt: time.Now().UTC()})
+		if err != nil {
+			logger.ForContext(ctx).Error("Error while updating user: ", err)
+			return nil, false, customErr.NewInternalServerError("Failed to activate a user", err)
+		}
+	}
+
+	return createdUser, approved, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	"jwtgo/internal/pkg/verification"
+)
+
+func TestSignUpWithIdentityVerification(t *testing.T) {
+	authService, userRepository := newTestAuthService(t)
+	verifier := &verification.Mock{}
+	authService.identityVerifier = verifier
+	verificationService := NewVerificationService(userRepository, nil, authService.logger)
+	ctx := context.Background()
+
+	signUp(t, authService, "pending@example.com")
+	signUp(t, authService, "rejected@example.com")
+
+	if started := verifier.Started(); len(started) != 2 {
+		t.Fatalf("verification started for %v, want both users", started)
+	}
+
+	credentials := func(email string) *dto.UserCredentialsDTO {
+		return &dto.UserCredentialsDTO{Email: email, Password: testPassword}
+	}
+
+	if _, err := authService.SignIn(ctx, credentials("pending@example.com")); !errors.Is(err, customErr.ErrAccountPending) {
+		t.Fatalf("pending sign-in error = %v, want ACCOUNT_PENDING_VERIFICATION", err)
+	}
+
+	pending, _ := userRepository.GetByEmail(ctx, "pending@example.com")
+	rejected, _ := userRepository.GetByEmail(ctx, "rejected@example.com")
+
+	if err := verificationService.Complete(ctx, pending.Id, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := verificationService.Complete(ctx, rejected.Id, false); err != nil {
+		t.Fatal(err)
+	}
+
+	signIn(t, authService, "pending@example.com")
+	if _, err := authService.SignIn(ctx, credentials("rejected@example.com")); !errors.Is(err, customErr.ErrAccountRejected) {
+		t.Fatalf("rejected sign-in error = %v, want ACCOUNT_REJECTED", err)
+	}
+
+	// Decisions are final.
+	if err := verificationService.Complete(ctx, rejected.Id, true); err == nil {
+		t.Fatal("a second decision was accepted")
+	}
+}
+
+func TestSignUpWithImmediateApproval(t *testing.T) {
+	authService, userRepository := newTestAuthService(t)
+	authService.identityVerifier = &verification.Mock{Approve: true}
+
+	signUp(t, authService, "user@example.com")
+
+	user, _ := userRepository.GetByEmail(context.Background(), "user@example.com")
+	if user.Status != entity.UserStatusActive {
+		t.Fatalf("status = %q, want %q", user.Status, entity.UserStatusActive)
+	}
+	signIn(t, authService, "user@example.com")
+}
+
+func TestSignUpRemovesUserWhenVerificationFails(t *testing.T) {
+	authService, userRepository := newTestAuthService(t)
+	authService.identityVerifier = &verification.Mock{Err: fmt.Errorf("provider unavailable")}
+
+	if _, err := authService.SignUp(context.Background(), &dto.UserCredentialsDTO{Email: "user@example.com", Password: testPassword}); err == nil {
+		t.Fatal("sign-up succeeded although verification could not start")
+	}
+
+	if user, _ := userRepository.GetByEmail(context.Background(), "user@example.com"); user != nil {
+		t.Fatal("the user was kept, so the sign-up cannot be retried")
+	}
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"jwtgo/internal/app/controller/http/dto"
+	"jwtgo/internal/app/entity"
+	customErr "jwtgo/internal/app/error"
+	repositoryInterface "jwtgo/internal/app/interface/repository"
+	serviceInterface "jwtgo/internal/app/interface/service"
+	"jwtgo/pkg/logging"
+)
+
+const webAuthnSessionLifetime = 5 * time.Minute
+
+type WebAuthnService struct {
+	webAuthn       *webauthn.WebAuthn
+	userRepository repositoryInterface.UserRepository
+	authService    serviceInterface.AuthService
+	sessions       *webAuthnSessionStore
+	logger         *logging.Logger
+}
+
+func NewWebAuthnService(
+	webAuthn *webauthn.WebAuthn,
+	userRepository repositoryInterface.UserRepository,
+	authService serviceInterface.AuthService,
+	logger *logging.Logger,
+) *WebAuthnService {
+	return &WebAuthnService{
+		webAuthn:       webAuthn,
+		userRepository: userRepository,
+		authService:    authService,
+		sessions:       newWebAuthnSessionStore(),
+		logger:         logger,
+	}
+}
+
+func (s *WebAuthnService) BeginRegistration(ctx context.Context, userId string) (*protocol.CredentialCreation, string, error) {
+	existingUserEntity, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return nil, "", customErr.NewInternalServerError("Failed to check user id", err)
+	}
+
+	if existingUserEntity == nil {
+		return nil, "", customErr.NewUserNotFoundError("User not found", userId)
+	}
+
+	user := &webAuthnUser{user: existingUserEntity}
+
+	creation, sessionData, err := s.webAuthn.BeginRegistration(user, webauthn.WithExclusions(user.credentialDescriptors()))
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while beginning passkey registration: ", err)
+		return nil, "", customErr.NewInternalServerError("Failed to begin passkey registration", err)
+	}
+
+	sessionId, err := s.sessions.put(existingUserEntity.Id, sessionData)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while storing passkey session: ", err)
+		return nil, "", customErr.NewInternalServerError("Failed to begin passkey registration", err)
+	}
+
+	return creation, sessionId, nil
+}
+
+func (s *WebAuthnService) FinishRegistration(
+	ctx context.Context,
+	userId, sessionId string,
+	response *protocol.ParsedCredentialCreationData,
+) (bool, error) {
+	session, ok := s.sessions.take(sessionId)
+	if !ok || session.userId != userId {
+		return false, customErr.NewInvalidRequestError("Passkey registration session is invalid or expired")
+	}
+
+	existingUserEntity, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return false, customErr.NewInternalServerError("Failed to check user id", err)
+	}
+
+	if existingUserEntity == nil {
+		return false, customErr.NewUserNotFoundError("User not found", userId)
+	}
+
+	credential, err := s.webAuthn.CreateCredential(&webAuthnUser{user: existingUserEntity}, session.data, response)
+	if err != nil {
+		return false, customErr.NewInvalidRequestError("Passkey registration failed")
+	}
+
+	existingUserEntity.WebAuthnCredentials = append(existingUserEntity.WebAuthnCredentials, mapWebAuthnCredential(credential))
+	existingUserEntity.UpdatedAt = time.Now().UTC()
+
+	_, err = s.userRepository.Update(ctx, existingUserEntity.Id, existingUserEntity)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while updating user: ", err)
+		return false, customErr.NewInternalServerError("Failed to save passkey", err)
+	}
+
+	return true, nil
+}
+
+func (s *WebAuthnService) BeginLogin(ctx context.Context, webAuthnLoginDTO *dto.WebAuthnLoginDTO) (*protocol.CredentialAssertion, string, error) {
+	existingUserEntity, err := s.userRepository.GetByEmail(ctx, webAuthnLoginDTO.Email)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return nil, "", customErr.NewInternalServerError("Failed to check user email", err)
+	}
+
+	if existingUserEntity == nil || len(existingUserEntity.WebAuthnCredentials) == 0 {
+		return nil, "", customErr.NewInvalidCredentialsError("Invalid login or passkey", webAuthnLoginDTO.Email)
+	}
+
+	assertion, sessionData, err := s.webAuthn.BeginLogin(&webAuthnUser{user: existingUserEntity})
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while beginning passkey login: ", err)
+		return nil, "", customErr.NewInternalServerError("Failed to begin passkey login", err)
+	}
+
+	sessionId, err := s.sessions.put(existingUserEntity.Id, sessionData)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while storing passkey session: ", err)
+		return nil, "", customErr.NewInternalServerError("Failed to begin passkey login", err)
+	}
+
+	return assertion, sessionId, nil
+}
+
+func (s *WebAuthnService) FinishLogin(
+	ctx context.Context,
+	sessionId string,
+	response *protocol.ParsedCredentialAssertionData,
+) (*dto.UserTokensDTO, error) {
+	session, ok := s.sessions.take(sessionId)
+	if !ok {
+		return nil, customErr.NewInvalidRequestError("Passkey login session is invalid or expired")
+	}
+
+	existingUserEntity, err := s.userRepository.GetById(ctx, session.userId)
+	if err != nil {
+		s.logger.ForContext(ctx).Error("Error while getting user: ", err)
+		return nil, customErr.NewInternalServerError("Failed to check user id", err)
+	}
+
+	if existingUserEntity == nil {
+		return nil, customErr.NewUserNotFoundError("User not found", session.userId)
+	}
+
+	credential, err := s.webAuthn.ValidateLogin(&webAuthnUser{user: existingUserEntity}, session.data, response)
+	if err != nil {
+		return nil, customErr.NewInvalidCredentialsError("Invalid login or passkey", existingUserEntity.Email)
+	}
+
+	if credential.Authenticator.CloneWarning {
+		s.logger.ForContext(ctx).Warn("Passkey sign counter went backwards, possible cloned authenticator for user ", existingUserEntity.Id)
+	}
+
+	for i := range existingUserEntity.WebAuthnCredentials {
+		if string(existingUserEntity.WebAuthnCredentials[i].Id) == string(credential.ID) {
+			existingUserEntity.WebAuthnCredentials[i].SignCount = credential.Authenticator.SignCount
+			existingUserEntity.WebAuthnCredentials[i].BackupState = credential.Flags.BackupState
+		}
+	}
+
+	return s.authService.IssueTokens(ctx, existingUserEntity)
+}
+
+type webAuthnUser struct {
+	user *entity.User
+}
+
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(u.user.Id)
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Email
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.user.WebAuthnCredentials))
+	for _, stored := range u.user.WebAuthnCredentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(stored.Transports))
+		for _, transport := range stored.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		credentials = append(credentials, webauthn.Credential{
+			ID:              stored.Id,
+			PublicKey:       stored.PublicKey,
+			AttestationType: stored.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: stored.BackupEligible,
+				BackupState:    stored.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    stored.AAGUID,
+				SignCount: stored.SignCount,
+			},
+		})
+	}
+	return credentials
+}
+
+func (u *webAuthnUser) credentialDescriptors() []protocol.CredentialDescriptor {
+	credentials := u.WebAuthnCredentials()
+	descriptors := make([]protocol.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptors = append(descriptors, credential.Descriptor())
+	}
+	return descriptors
+}
+
+func mapWebAuthnCredential(credential *webauthn.Credential) entity.WebAuthnCredential {
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	return entity.WebAuthnCredential{
+		Id:              credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      transports,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+		CreatedAt:       time.Now().UTC(),
+	}
+}
+
+type webAuthnSession struct {
+	userId    string
+	data      webauthn.SessionData
+	expiresAt time.Time
+}
+
+type webAuthnSessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]webAuthnSession
+}
+
+func newWebAuthnSessionStore() *webAuthnSessionStore {
+	return &webAuthnSessionStore{
+		sessions: make(map[string]webAuthnSession),
+	}
+}
+
+func (ss *webAuthnSessionStore) put(userId string, data *webauthn.SessionData) (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	sessionId := hex.EncodeToString(randomBytes)
+
+	now := time.Now().UTC()
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	for id, session := range ss.sessions {
+		if now.After(session.expiresAt) {
+			delete(ss.sessions, id)
+		}
+	}
+
+	ss.sessions[sessionId] = webAuthnSession{
+		userId:    userId,
+		data:      *data,
+		expiresAt: now.Add(webAuthnSessionLifetime),
+	}
+
+	return sessionId, nil
+}
+
+func (ss *webAuthnSessionStore) take(sessionId string) (webAuthnSession, bool) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	session, ok := ss.sessions[sessionId]
+	if !ok {
+		return webAuthnSession{}, false
+	}
+	delete(ss.sessions, sessionId)
+
+	if time.Now().UTC().After(session.expiresAt) {
+		return webAuthnSession{}, false
+	}
+
+	return session, true
+}
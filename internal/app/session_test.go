@@ -0,0 +1,65 @@
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"jwtgo/internal/app/config"
+)
+
+// nextSecond waits for the next wall-clock second. Session revocation compares
+// second-precision issue times, so the sessions compared here must start in
+// different seconds.
+func nextSecond() {
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+}
+
+func sessionWorks(t *testing.T, app *Application, accessToken, refreshToken *http.Cookie) (me, refresh bool) {
+	t.Helper()
+
+	me = serve(app.Router, http.MethodGet, "/auth/me", accessToken.Value).Code == http.StatusOK
+	refresh = postJSON(app.Router, "/auth/refresh", "", refreshToken).Code == http.StatusOK
+
+	return me, refresh
+}
+
+func TestSingleSession(t *testing.T) {
+	tests := []struct {
+		singleSession bool
+		oldWorks      bool
+	}{
+		{singleSession: true, oldWorks: false},
+		{singleSession: false, oldWorks: true},
+	}
+
+	for _, tt := range tests {
+		name := "single_session"
+		if !tt.singleSession {
+			name = "multiple_sessions"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			app := newTestApplication(t, func(cfg *config.Config) {
+				cfg.Security.SingleSession = tt.singleSession
+			})
+
+			oldAccessToken, oldRefreshToken := signInCookies(t, app, "user@example.com")
+			nextSecond()
+			newAccessToken, newRefreshToken := signInCookies(t, app, "user@example.com")
+
+			if recorder := serve(app.Router, http.MethodGet, "/auth/me", oldAccessToken.Value); (recorder.Code == http.StatusOK) != tt.oldWorks {
+				t.Errorf("old access token status = %d, want it to work: %t", recorder.Code, tt.oldWorks)
+			}
+			// Only one refresh token is stored per user, so the later sign-in
+			// replaces the old one in either mode.
+			if recorder := postJSON(app.Router, "/auth/refresh", "", oldRefreshToken); recorder.Code != http.StatusUnauthorized {
+				t.Errorf("old refresh token status = %d, want 401", recorder.Code)
+			}
+
+			if me, refresh := sessionWorks(t, app, newAccessToken, newRefreshToken); !me || !refresh {
+				t.Fatalf("new session works: me %t, refresh %t", me, refresh)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ShutdownSignals delivers the first SIGINT or SIGTERM. A second one exits the
+// process immediately, without waiting for the graceful shutdown to finish.
+func (app *Application) ShutdownSignals() <-chan os.Signal {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	first := make(chan os.Signal, 1)
+	go func() {
+		first <- <-signals
+
+		sig := <-signals
+		app.Logger.Warn("Received ", sig, " again, exiting immediately")
+		os.Exit(1)
+	}()
+
+	return first
+}
+
+// Shutdown fails the readiness probe, drains the HTTP and gRPC servers within
+// app.shutdown_timeout and then stops the remaining components, each after
+// the ones that still depend on it.
+func (app *Application) Shutdown(server *http.Server) {
+	app.HealthChecker.SetShuttingDown()
+
+	if delay := time.Duration(app.Config.App.ShutdownDelay) * time.Second; delay > 0 {
+		app.Logger.Info("Waiting ", delay, " for load balancers to notice the failing readiness probe")
+		time.Sleep(delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(app.Config.App.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		app.Logger.Error("HTTP server did not drain in time: ", err)
+	}
+
+	if app.GRPCServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			app.GRPCServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			app.Logger.Error("gRPC server did not drain in time")
+			app.GRPCServer.Stop()
+		}
+	}
+
+	app.Cancel()
+
+	if app.MongoClient != nil {
+		if err := app.MongoClient.Disconnect(ctx); err != nil {
+			app.Logger.Error("Failed to disconnect from MongoDB: ", err)
+		}
+	}
+
+	if app.TracingShutdown != nil {
+		if err := app.TracingShutdown(ctx); err != nil {
+			app.Logger.Error("Failed to flush traces: ", err)
+		}
+	}
+
+	if app.AuditWriter != nil {
+		if err := app.AuditWriter.Close(); err != nil {
+			app.Logger.Error("Failed to close audit log: ", err)
+		}
+	}
+
+	app.Logger.Info("Application stopped")
+
+	if app.LogWebhook != nil {
+		if err := app.LogWebhook.Close(ctx); err != nil {
+			app.Logger.Error("Failed to flush log webhook: ", err)
+		}
+	}
+
+	if app.LogFile != nil {
+		_ = app.LogFile.Close()
+	}
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/app/config"
+	"jwtgo/internal/pkg/health"
+)
+
+type slowResponse struct {
+	status int
+	body   string
+	err    error
+}
+
+// startSlowServer serves app on a local port with a /slow route that blocks
+// until release is closed. It returns once a /slow request is in flight.
+func startSlowServer(t *testing.T, app *Application) (server *http.Server, address string, release chan struct{}, response <-chan slowResponse) {
+	t.Helper()
+
+	entered := make(chan struct{})
+	release = make(chan struct{})
+	app.Router.GET("/slow", func(c *gin.Context) {
+		close(entered)
+		<-release
+		c.String(http.StatusOK, "done")
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server = &http.Server{Handler: app.Router}
+	go server.Serve(listener)
+
+	responses := make(chan slowResponse, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			responses <- slowResponse{err: err}
+			return
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		responses <- slowResponse{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow request did not start")
+	}
+
+	return server, listener.Addr().String(), release, responses
+}
+
+func waitForRefusedConnections(t *testing.T, address string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", address, 100*time.Millisecond)
+		if err != nil {
+			return
+		}
+		conn.Close()
+
+		if time.Now().After(deadline) {
+			t.Fatal("the listener still accepts connections")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestShutdownDrainsInFlightRequests(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.App.ShutdownTimeout = 10
+	})
+	server, address, release, response := startSlowServer(t, app)
+
+	stopped := make(chan struct{})
+	go func() {
+		app.Shutdown(server)
+		close(stopped)
+	}()
+
+	waitForRefusedConnections(t, address)
+
+	if report := app.HealthChecker.Check(context.Background()); report.Status != health.StatusShutdown {
+		t.Fatalf("readiness = %s while draining, want %s", report.Status, health.StatusShutdown)
+	}
+	select {
+	case <-stopped:
+		t.Fatal("shutdown finished before the in-flight request")
+	case <-app.Context.Done():
+		t.Fatal("the root context was cancelled before the in-flight request finished")
+	default:
+	}
+
+	close(release)
+
+	if resp := <-response; resp.err != nil || resp.status != http.StatusOK || resp.body != "done" {
+		t.Fatalf("in-flight request = %d %q, %v, want 200 done", resp.status, resp.body, resp.err)
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not finish after the last request")
+	}
+	if app.Context.Err() == nil {
+		t.Fatal("the root context was not cancelled")
+	}
+}
+
+func TestShutdownGivesUpAfterTimeout(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.App.ShutdownTimeout = 1
+	})
+	server, _, release, response := startSlowServer(t, app)
+	defer func() {
+		close(release)
+		<-response
+	}()
+
+	start := time.Now()
+	app.Shutdown(server)
+
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("shutdown took %s with a 1s timeout", elapsed)
+	}
+	if app.Context.Err() == nil {
+		t.Fatal("the root context was not cancelled")
+	}
+}
+
+func TestReadinessFailsBeforeListenerCloses(t *testing.T) {
+	app := newTestApplication(t, func(cfg *config.Config) {
+		cfg.App.ShutdownDelay = 1
+		cfg.App.ShutdownTimeout = 10
+	})
+	server, address, release, response := startSlowServer(t, app)
+	close(release)
+	<-response
+
+	stopped := make(chan struct{})
+	go func() {
+		app.Shutdown(server)
+		close(stopped)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := http.Get("http://" + address + "/readyz")
+		if err != nil {
+			t.Fatalf("the listener closed before readiness failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("readiness = %d during shutdown, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	waitForRefusedConnections(t, address)
+	<-stopped
+}
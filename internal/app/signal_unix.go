@@ -0,0 +1,34 @@
+//go:build !windows
+
+package app
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func (app *Application) HandleLogSignals() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGHUP)
+
+	go func() {
+		for sig := range signals {
+			switch sig {
+			case syscall.SIGUSR1:
+				app.Logger.Warn("Log level raised to ", app.Logger.IncreaseVerbosity())
+			case syscall.SIGUSR2:
+				app.Logger.Warn("Log level lowered to ", app.Logger.DecreaseVerbosity())
+			case syscall.SIGHUP:
+				if app.LogFile == nil {
+					continue
+				}
+				if err := app.LogFile.Reopen(); err != nil {
+					app.Logger.Error("Failed to reopen log file: ", err)
+				} else {
+					app.Logger.Info("Log file reopened")
+				}
+			}
+		}
+	}()
+}
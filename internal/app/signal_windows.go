@@ -0,0 +1,5 @@
+//go:build windows
+
+package app
+
+func (app *Application) HandleLogSignals() {}
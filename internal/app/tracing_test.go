@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestSignInSpanTree checks that a sign-in produces one trace in which the
+// service span hangs off the HTTP span and the repository spans off the
+// service span.
+func TestSignInSpanTree(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+
+	app := newTestApplication(t, nil)
+
+	credentials := `{"email":"traced@example.com","password":"` + testPassword + `"}`
+	postJSON(app.Router, "/auth/signup", credentials)
+	exporter.Reset()
+
+	if recorder := postJSON(app.Router, "/auth/signin", credentials); recorder.Code != http.StatusOK {
+		t.Fatalf("sign-in status = %d: %s", recorder.Code, recorder.Body)
+	}
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStub, len(spans))
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+
+	httpSpan, ok := byName["POST /auth/signin"]
+	if !ok {
+		t.Fatalf("no HTTP span among %d spans", len(spans))
+	}
+	if httpSpan.Parent.IsValid() {
+		t.Error("the HTTP span has a parent")
+	}
+
+	signIn, ok := byName["AuthService.SignIn"]
+	if !ok {
+		t.Fatal("no AuthService.SignIn span")
+	}
+	if signIn.Parent.SpanID() != httpSpan.SpanContext.SpanID() {
+		t.Error("AuthService.SignIn is not a child of the HTTP span")
+	}
+
+	lookup, ok := byName["repository.get_by_email"]
+	if !ok {
+		t.Fatal("no repository span for the email lookup")
+	}
+	if lookup.Parent.SpanID() != signIn.SpanContext.SpanID() {
+		t.Error("repository.get_by_email is not a child of AuthService.SignIn")
+	}
+
+	for _, span := range spans {
+		if span.SpanContext.TraceID() != httpSpan.SpanContext.TraceID() {
+			t.Errorf("%s belongs to another trace", span.Name)
+		}
+	}
+}
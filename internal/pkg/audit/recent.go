@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Recent keeps the latest event records of every user in memory so they can be
+// listed without scanning the audit log.
+type Recent struct {
+	mu      sync.RWMutex
+	perUser int
+	records map[string][]Record
+}
+
+func NewRecent(perUser int) *Recent {
+	return &Recent{
+		perUser: perUser,
+		records: make(map[string][]Record),
+	}
+}
+
+func (r *Recent) Add(record Record) {
+	if record.Type != RecordTypeEvent || record.UserId == "" || r.perUser <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := append(r.records[record.UserId], record)
+	if len(records) > r.perUser {
+		records = append(records[:0:0], records[len(records)-r.perUser:]...)
+	}
+	r.records[record.UserId] = records
+}
+
+// List returns up to limit records of userId, newest first.
+func (r *Recent) List(userId string, limit int) []Record {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := r.records[userId]
+	if limit <= 0 || limit > len(records) {
+		limit = len(records)
+	}
+
+	result := make([]Record, 0, limit)
+	for i := len(records) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, records[i])
+	}
+	return result
+}
+
+// Load seeds the index from an existing audit log. Lines that are not valid
+// records are skipped; the hash chain is checked by Verify, not here.
+func (r *Recent) Load(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		r.Add(record)
+	}
+
+	return scanner.Err()
+}
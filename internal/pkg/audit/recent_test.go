@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+)
+
+func actions(records []Record) string {
+	var names []string
+	for _, record := range records {
+		names = append(names, record.Action)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestRecent(t *testing.T) {
+	recent := NewRecent(3)
+
+	for _, action := range []string{"a", "b", "c", "d"} {
+		recent.Add(Record{Type: RecordTypeEvent, UserId: "user-1", Action: action})
+	}
+	recent.Add(Record{Type: RecordTypeEvent, UserId: "user-2", Action: "other"})
+	recent.Add(Record{Type: RecordTypeEvent, Action: "anonymous"})
+	recent.Add(Record{Type: RecordTypeCheckpoint, UserId: "user-1", Action: "checkpoint"})
+
+	tests := []struct {
+		userId string
+		limit  int
+		want   string
+	}{
+		{"user-1", 0, "d,c,b"},
+		{"user-1", 2, "d,c"},
+		{"user-1", 10, "d,c,b"},
+		{"user-2", 0, "other"},
+		{"user-3", 0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := actions(recent.List(tt.userId, tt.limit)); got != tt.want {
+			t.Errorf("List(%s, %d) = %q, want %q", tt.userId, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestRecentLoad(t *testing.T) {
+	log := strings.Join([]string{
+		`{"seq":1,"type":"event","action":"signin","user_id":"user-1"}`,
+		`not a record`,
+		`{"seq":2,"type":"event","action":"refresh","user_id":"user-1"}`,
+		`{"seq":3,"type":"event","action":"signin","user_id":"user-2"}`,
+	}, "\n")
+
+	recent := NewRecent(10)
+	if err := recent.Load(strings.NewReader(log)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := actions(recent.List("user-1", 0)); got != "refresh,signin" {
+		t.Fatalf("user-1 events = %q, want refresh,signin", got)
+	}
+}
@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+const (
+	RecordTypeEvent      = "event"
+	RecordTypeCheckpoint = "checkpoint"
+)
+
+type Event struct {
+	Action  string
+	UserId  string
+	Outcome string
+	Fields  map[string]string
+}
+
+type Record struct {
+	Sequence uint64            `json:"seq"`
+	Type     string            `json:"type"`
+	Time     time.Time         `json:"time"`
+	Action   string            `json:"action,omitempty"`
+	UserId   string            `json:"user_id,omitempty"`
+	Outcome  string            `json:"outcome,omitempty"`
+	Fields   map[string]string `json:"fields,omitempty"`
+	PrevHash string            `json:"prev_hash"`
+	Hash     string            `json:"hash"`
+}
+
+func (r Record) canonical() ([]byte, error) {
+	r.Hash = ""
+	return json.Marshal(r)
+}
+
+func (r Record) computeHash(key []byte) (string, error) {
+	canonical, err := r.canonical()
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(r.PrevHash))
+	mac.Write(canonical)
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const maxRecordSize = 1024 * 1024
+
+type ChainError struct {
+	Line     int
+	Sequence uint64
+	Reason   string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit chain broken at line %d (seq %d): %s", e.Line, e.Sequence, e.Reason)
+}
+
+func Verify(r io.Reader, key []byte) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+
+	var prevHash string
+	var prevSequence uint64
+	verified := 0
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return verified, &ChainError{Line: line, Sequence: prevSequence + 1, Reason: "record is not valid JSON"}
+		}
+
+		if verified > 0 && record.Sequence != prevSequence+1 {
+			return verified, &ChainError{Line: line, Sequence: record.Sequence, Reason: fmt.Sprintf("expected sequence %d", prevSequence+1)}
+		}
+		if verified > 0 && record.PrevHash != prevHash {
+			return verified, &ChainError{Line: line, Sequence: record.Sequence, Reason: "previous hash does not match"}
+		}
+
+		hash, err := record.computeHash(key)
+		if err != nil {
+			return verified, err
+		}
+		if hash != record.Hash {
+			return verified, &ChainError{Line: line, Sequence: record.Sequence, Reason: "record hash does not match"}
+		}
+
+		prevHash = record.Hash
+		prevSequence = record.Sequence
+		verified++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return verified, err
+	}
+
+	return verified, nil
+}
@@ -0,0 +1,122 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testKey = []byte("test-audit-key")
+
+func writeChain(t *testing.T, events int) []string {
+	t.Helper()
+
+	var out bytes.Buffer
+	writer := NewWriter(&out, testKey, 0)
+	for i := 0; i < events; i++ {
+		if err := writer.Write(Event{Action: "signin", UserId: "user-1", Outcome: "success"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+}
+
+func TestVerifyIntactChain(t *testing.T) {
+	lines := writeChain(t, 5)
+
+	verified, err := Verify(strings.NewReader(strings.Join(lines, "\n")), testKey)
+	if err != nil || verified != 5 {
+		t.Fatalf("Verify = %d, %v, want 5, nil", verified, err)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	const events = 5
+
+	for record := 0; record < events; record++ {
+		lines := writeChain(t, events)
+
+		// Flip one bit of the action, which keeps the line valid JSON.
+		line := []byte(lines[record])
+		at := bytes.Index(line, []byte(`"signin"`)) + 1
+		line[at] ^= 0x01
+		lines[record] = string(line)
+
+		verified, err := Verify(strings.NewReader(strings.Join(lines, "\n")), testKey)
+
+		var chainErr *ChainError
+		if !errors.As(err, &chainErr) {
+			t.Fatalf("record %d: Verify error = %v, want a ChainError", record+1, err)
+		}
+		if chainErr.Line != record+1 || chainErr.Sequence != uint64(record+1) || chainErr.Reason != "record hash does not match" {
+			t.Errorf("record %d: %v, want the hash mismatch at line %d", record+1, chainErr, record+1)
+		}
+		if verified != record {
+			t.Errorf("record %d: verified = %d, want %d", record+1, verified, record)
+		}
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		edit   func(lines []string) []string
+		key    []byte
+		line   int
+		reason string
+	}{
+		{
+			name:   "wrong key",
+			edit:   func(lines []string) []string { return lines },
+			key:    []byte("other-key"),
+			line:   1,
+			reason: "record hash does not match",
+		},
+		{
+			name:   "removed record",
+			edit:   func(lines []string) []string { return append(lines[:2:2], lines[3:]...) },
+			line:   3,
+			reason: "expected sequence 3",
+		},
+		{
+			name: "swapped records",
+			edit: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+			line:   2,
+			reason: "expected sequence 2",
+		},
+		{
+			name: "truncated record",
+			edit: func(lines []string) []string {
+				lines[3] = lines[3][:len(lines[3])/2]
+				return lines
+			},
+			line:   4,
+			reason: "record is not valid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := testKey
+			if tt.key != nil {
+				key = tt.key
+			}
+
+			lines := tt.edit(writeChain(t, 5))
+			_, err := Verify(strings.NewReader(strings.Join(lines, "\n")), key)
+
+			var chainErr *ChainError
+			if !errors.As(err, &chainErr) {
+				t.Fatalf("Verify error = %v, want a ChainError", err)
+			}
+			if chainErr.Line != tt.line || chainErr.Reason != tt.reason {
+				t.Fatalf("%v, want line %d: %s", chainErr, tt.line, tt.reason)
+			}
+		})
+	}
+}
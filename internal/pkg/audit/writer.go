@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type Writer struct {
+	mu              sync.Mutex
+	out             io.Writer
+	key             []byte
+	checkpointEvery int
+	sequence        uint64
+	prevHash        string
+	sinceCheckpoint int
+	recent          *Recent
+	now             func() time.Time
+}
+
+func NewWriter(out io.Writer, key []byte, checkpointEvery int) *Writer {
+	return &Writer{
+		out:             out,
+		key:             key,
+		checkpointEvery: checkpointEvery,
+		now:             time.Now,
+	}
+}
+
+func OpenFile(path string, key []byte, checkpointEvery int) (*Writer, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create audit directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+
+	last, err := lastRecord(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	writer := NewWriter(file, key, checkpointEvery)
+	if last != nil {
+		writer.sequence = last.Sequence
+		writer.prevHash = last.Hash
+	}
+
+	return writer, nil
+}
+
+func (w *Writer) Write(event Event) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.append(Record{
+		Type:    RecordTypeEvent,
+		Action:  event.Action,
+		UserId:  event.UserId,
+		Outcome: event.Outcome,
+		Fields:  event.Fields,
+	}); err != nil {
+		return err
+	}
+
+	w.sinceCheckpoint++
+	if w.checkpointEvery > 0 && w.sinceCheckpoint >= w.checkpointEvery {
+		return w.checkpoint()
+	}
+
+	return nil
+}
+
+// SetRecent makes the writer add every event it writes to recent.
+func (w *Writer) SetRecent(recent *Recent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.recent = recent
+}
+
+func (w *Writer) Checkpoint() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.checkpoint()
+}
+
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if closer, ok := w.out.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func (w *Writer) checkpoint() error {
+	err := w.append(Record{
+		Type:   RecordTypeCheckpoint,
+		Fields: map[string]string{"records": strconv.Itoa(w.sinceCheckpoint)},
+	})
+	if err != nil {
+		return err
+	}
+
+	w.sinceCheckpoint = 0
+	return nil
+}
+
+func (w *Writer) append(record Record) error {
+	record.Sequence = w.sequence + 1
+	record.Time = w.now().UTC()
+	record.PrevHash = w.prevHash
+
+	hash, err := record.computeHash(w.key)
+	if err != nil {
+		return fmt.Errorf("hash audit record: %w", err)
+	}
+	record.Hash = hash
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode audit record: %w", err)
+	}
+
+	if _, err := w.out.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write audit record: %w", err)
+	}
+
+	w.sequence = record.Sequence
+	w.prevHash = record.Hash
+
+	if w.recent != nil {
+		w.recent.Add(record)
+	}
+
+	return nil
+}
+
+func lastRecord(file *os.File) (*Record, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var last *Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("read audit log: %w", err)
+		}
+		last = &record
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+
+	return last, nil
+}
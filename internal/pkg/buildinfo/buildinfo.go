@@ -0,0 +1,29 @@
+package buildinfo
+
+import (
+	"runtime"
+)
+
+// Overridden at build time, e.g.
+// go build -ldflags "-X jwtgo/internal/pkg/buildinfo.version=v1.2.3 -X jwtgo/internal/pkg/buildinfo.commit=$(git rev-parse HEAD)"
+var (
+	version   = "dev"
+	commit    = "dev"
+	buildDate = "unknown"
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+func Get() Info {
+	return Info{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}
+}
@@ -0,0 +1,45 @@
+package buildinfo_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	v1 "jwtgo/internal/app/controller/http/v1"
+	"jwtgo/internal/pkg/buildinfo"
+)
+
+func TestVersionEndpointReportsLinkedValues(t *testing.T) {
+	t.Cleanup(buildinfo.SetForTest("v1.2.3", "0123abcd", "2024-01-02T03:04:05Z"))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	v1.NewVersionController(buildinfo.Get()).Register(router)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var body map[string]string
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"version":    "v1.2.3",
+		"commit":     "0123abcd",
+		"build_date": "2024-01-02T03:04:05Z",
+		"go_version": runtime.Version(),
+	}
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("%s = %q, want %q", key, body[key], value)
+		}
+	}
+}
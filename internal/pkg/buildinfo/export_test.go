@@ -0,0 +1,12 @@
+package buildinfo
+
+// SetForTest stands in for the -X linker flags and returns a function that
+// puts the previous values back.
+func SetForTest(v, c, d string) func() {
+	previousVersion, previousCommit, previousBuildDate := version, commit, buildDate
+	version, commit, buildDate = v, c, d
+
+	return func() {
+		version, commit, buildDate = previousVersion, previousCommit, previousBuildDate
+	}
+}
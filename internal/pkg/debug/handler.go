@@ -0,0 +1,70 @@
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+type runtimeStats struct {
+	GoVersion   string         `json:"go_version"`
+	Goroutines  int            `json:"goroutines"`
+	CPUs        int            `json:"cpus"`
+	HeapAlloc   uint64         `json:"heap_alloc_bytes"`
+	HeapObjects uint64         `json:"heap_objects"`
+	NumGC       uint32         `json:"num_gc"`
+	LastGC      time.Time      `json:"last_gc"`
+	PauseTotal  time.Duration  `json:"gc_pause_total_ns"`
+	BuildInfo   *buildSettings `json:"build_info,omitempty"`
+	CollectedAt time.Time      `json:"collected_at"`
+}
+
+type buildSettings struct {
+	Path     string            `json:"path"`
+	Main     string            `json:"main_version"`
+	Settings map[string]string `json:"settings"`
+}
+
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/runtime", runtimeHandler)
+
+	return mux
+}
+
+func runtimeHandler(w http.ResponseWriter, r *http.Request) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	stats := runtimeStats{
+		GoVersion:   runtime.Version(),
+		Goroutines:  runtime.NumGoroutine(),
+		CPUs:        runtime.NumCPU(),
+		HeapAlloc:   memStats.HeapAlloc,
+		HeapObjects: memStats.HeapObjects,
+		NumGC:       memStats.NumGC,
+		LastGC:      time.Unix(0, int64(memStats.LastGC)).UTC(),
+		PauseTotal:  time.Duration(memStats.PauseTotalNs),
+		CollectedAt: time.Now().UTC(),
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		settings := make(map[string]string, len(info.Settings))
+		for _, setting := range info.Settings {
+			settings[setting.Key] = setting.Value
+		}
+		stats.BuildInfo = &buildSettings{Path: info.Path, Main: info.Main.Version, Settings: settings}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
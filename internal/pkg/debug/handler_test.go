@@ -0,0 +1,35 @@
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+func TestHandlerServesRuntimeStats(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/runtime", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var stats runtimeStats
+	if err := json.Unmarshal(recorder.Body.Bytes(), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.GoVersion != runtime.Version() || stats.Goroutines == 0 {
+		t.Fatalf("stats = %+v, want the running go version and goroutine count", stats)
+	}
+}
+
+func TestHandlerServesPprofIndex(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,136 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"jwtgo/pkg/logging"
+)
+
+const (
+	StatusOk          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+	StatusTimeout     = "timeout"
+	StatusShutdown    = "shutting_down"
+)
+
+type Probe func(ctx context.Context) error
+
+type Check struct {
+	Name     string
+	Critical bool
+	Timeout  time.Duration
+	Probe    Probe
+}
+
+type CheckResult struct {
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	Latency  string `json:"latency"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+func (r Report) Ready() bool {
+	return r.Status != StatusUnavailable && r.Status != StatusShutdown
+}
+
+type Checker struct {
+	checks       []Check
+	cacheTTL     time.Duration
+	shuttingDown atomic.Bool
+	logger       *logging.Logger
+
+	mu        sync.Mutex
+	cached    *Report
+	checkedAt time.Time
+}
+
+func NewChecker(cacheTTL time.Duration, logger *logging.Logger, checks ...Check) *Checker {
+	return &Checker{
+		checks:   checks,
+		cacheTTL: cacheTTL,
+		logger:   logger,
+	}
+}
+
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+func (c *Checker) Check(ctx context.Context) Report {
+	if c.shuttingDown.Load() {
+		return Report{Status: StatusShutdown, Checks: map[string]CheckResult{}}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cached != nil && time.Since(c.checkedAt) < c.cacheTTL {
+		return *c.cached
+	}
+
+	// The report is shared with later callers, so a client hanging up must not
+	// turn it into a timeout. Each probe is still bounded by its own Timeout.
+	report := c.run(context.WithoutCancel(ctx))
+	c.cached = &report
+	c.checkedAt = time.Now()
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context) Report {
+	results := make([]CheckResult, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.probe(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOk, Checks: make(map[string]CheckResult, len(c.checks))}
+	for i, check := range c.checks {
+		result := results[i]
+		report.Checks[check.Name] = result
+
+		if result.Status == StatusOk {
+			continue
+		}
+		if check.Critical {
+			report.Status = StatusUnavailable
+		} else if report.Status == StatusOk {
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) probe(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Probe(ctx)
+	result := CheckResult{Status: StatusOk, Critical: check.Critical, Latency: time.Since(start).String()}
+
+	if err != nil {
+		result.Status = StatusUnavailable
+		if ctx.Err() != nil {
+			result.Status = StatusTimeout
+		}
+		c.logger.ForContext(ctx).Warn("Health check ", check.Name, " failed: ", err)
+	}
+
+	return result
+}
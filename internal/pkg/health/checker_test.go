@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"jwtgo/pkg/logging"
+)
+
+func TestCheck(t *testing.T) {
+	logger := logging.GetLogger("panic")
+	failing := errors.New("connection refused")
+
+	tests := []struct {
+		name   string
+		checks []Check
+		status string
+		ready  bool
+	}{
+		{"no checks", nil, StatusOk, true},
+		{
+			name: "all passing",
+			checks: []Check{
+				{Name: "db", Critical: true, Timeout: time.Second, Probe: func(context.Context) error { return nil }},
+			},
+			status: StatusOk,
+			ready:  true,
+		},
+		{
+			name: "optional failing",
+			checks: []Check{
+				{Name: "db", Critical: true, Timeout: time.Second, Probe: func(context.Context) error { return nil }},
+				{Name: "cache", Timeout: time.Second, Probe: func(context.Context) error { return failing }},
+			},
+			status: StatusDegraded,
+			ready:  true,
+		},
+		{
+			name: "critical failing",
+			checks: []Check{
+				{Name: "db", Critical: true, Timeout: time.Second, Probe: func(context.Context) error { return failing }},
+			},
+			status: StatusUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewChecker(0, &logger, tt.checks...).Check(context.Background())
+
+			if report.Status != tt.status || report.Ready() != tt.ready {
+				t.Fatalf("report = %s (ready %t), want %s (ready %t)", report.Status, report.Ready(), tt.status, tt.ready)
+			}
+		})
+	}
+}
+
+func TestCheckOutlivesCallerContext(t *testing.T) {
+	logger := logging.GetLogger("panic")
+	checker := NewChecker(time.Minute, &logger, Check{
+		Name:     "db",
+		Critical: true,
+		Timeout:  time.Second,
+		Probe: func(ctx context.Context) error {
+			select {
+			case <-time.After(10 * time.Millisecond):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if report := checker.Check(ctx); report.Status != StatusOk {
+		t.Fatalf("report for a cancelled caller = %s, want %s", report.Status, StatusOk)
+	}
+	if report := checker.Check(context.Background()); report.Status != StatusOk {
+		t.Fatalf("cached report = %s, want %s", report.Status, StatusOk)
+	}
+}
+
+func TestCheckTimesOut(t *testing.T) {
+	logger := logging.GetLogger("panic")
+	checker := NewChecker(0, &logger, Check{
+		Name:     "db",
+		Critical: true,
+		Timeout:  10 * time.Millisecond,
+		Probe: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	report := checker.Check(context.Background())
+	if report.Status != StatusUnavailable || report.Checks["db"].Status != StatusTimeout {
+		t.Fatalf("report = %s with db %s, want %s with db %s", report.Status, report.Checks["db"].Status, StatusUnavailable, StatusTimeout)
+	}
+}
+
+func TestShuttingDownIsNotReady(t *testing.T) {
+	logger := logging.GetLogger("panic")
+	checker := NewChecker(time.Minute, &logger)
+
+	if report := checker.Check(context.Background()); !report.Ready() {
+		t.Fatalf("report before shutdown = %s, want ready", report.Status)
+	}
+
+	checker.SetShuttingDown()
+
+	if report := checker.Check(context.Background()); report.Status != StatusShutdown || report.Ready() {
+		t.Fatalf("report while shutting down = %s, want %s", report.Status, StatusShutdown)
+	}
+}
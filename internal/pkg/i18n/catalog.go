@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+
+	"jwtgo/pkg/logging"
+)
+
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var supportedLocales = []string{DefaultLocale, "ru", "de"}
+
+type Catalog struct {
+	messages map[string]map[string]string
+	matcher  language.Matcher
+	warned   sync.Map
+	logger   *logging.Logger
+}
+
+func NewCatalog(logger *logging.Logger) (*Catalog, error) {
+	messages := make(map[string]map[string]string, len(supportedLocales))
+	tags := make([]language.Tag, 0, len(supportedLocales))
+
+	for _, locale := range supportedLocales {
+		data, err := localeFiles.ReadFile("locales/" + locale + ".json")
+		if err != nil {
+			return nil, err
+		}
+
+		var localeMessages map[string]string
+		if err := json.Unmarshal(data, &localeMessages); err != nil {
+			return nil, err
+		}
+
+		messages[locale] = localeMessages
+		tags = append(tags, language.Make(locale))
+	}
+
+	return &Catalog{
+		messages: messages,
+		matcher:  language.NewMatcher(tags),
+		logger:   logger,
+	}, nil
+}
+
+func (c *Catalog) Locale(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return DefaultLocale
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	_, index, confidence := c.matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+
+	return supportedLocales[index]
+}
+
+func (c *Catalog) HasMessage(locale, code string) bool {
+	_, ok := c.messages[locale][code]
+	return ok
+}
+
+func (c *Catalog) Message(locale, code string, params map[string]string) string {
+	template, ok := c.messages[locale][code]
+	if !ok {
+		if locale != DefaultLocale {
+			c.warnMissing(locale, code)
+		}
+
+		template, ok = c.messages[DefaultLocale][code]
+		if !ok {
+			c.warnMissing(DefaultLocale, code)
+			return code
+		}
+	}
+
+	if len(params) == 0 {
+		return template
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		replacements = append(replacements, "{"+key+"}", value)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+func (c *Catalog) warnMissing(locale, code string) {
+	if _, alreadyWarned := c.warned.LoadOrStore(locale+":"+code, struct{}{}); alreadyWarned {
+		return
+	}
+
+	c.logger.Warnf("Missing %s translation for error code %s", locale, code)
+}
@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"jwtgo/pkg/logging"
+)
+
+func newTestCatalog(t *testing.T) (*Catalog, *test.Hook) {
+	t.Helper()
+
+	logger, hook := test.NewNullLogger()
+	catalog, err := NewCatalog(&logging.Logger{Entry: logrus.NewEntry(logger)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return catalog, hook
+}
+
+func TestMessageParams(t *testing.T) {
+	catalog, _ := newTestCatalog(t)
+
+	tests := []struct {
+		name   string
+		locale string
+		code   string
+		params map[string]string
+		want   string
+	}{
+		{"no params", DefaultLocale, "INVALID_TOKEN", nil, "Token is invalid"},
+		{"one param", DefaultLocale, "WRONG_TOKEN_TYPE", map[string]string{"expected": "refresh"}, "Expected refresh token"},
+		{"translated", "de", "WRONG_TOKEN_TYPE", map[string]string{"expected": "refresh"}, "Es wurde ein Token vom Typ refresh erwartet"},
+		{"unused param", DefaultLocale, "WRONG_TOKEN_TYPE", map[string]string{"expected": "access", "other": "x"}, "Expected access token"},
+		{"missing param", DefaultLocale, "WRONG_TOKEN_TYPE", map[string]string{}, "Expected {expected} token"},
+		{"value is not expanded", DefaultLocale, "WRONG_TOKEN_TYPE", map[string]string{"expected": "{expected}"}, "Expected {expected} token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Message(tt.locale, tt.code, tt.params); got != tt.want {
+				t.Fatalf("Message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocale(t *testing.T) {
+	catalog, _ := newTestCatalog(t)
+
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", DefaultLocale},
+		{"de", "de"},
+		{"de-AT", "de"},
+		{"ru-RU,ru;q=0.9,en;q=0.8", "ru"},
+		{"fr-FR,de;q=0.5", "de"},
+		{"en;q=0.1,de;q=0.9", "de"},
+		{"fr", DefaultLocale},
+		{"*", DefaultLocale},
+		{"not a language tag;;", DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			if got := catalog.Locale(tt.acceptLanguage); got != tt.want {
+				t.Fatalf("Locale(%q) = %q, want %q", tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingTranslationFallsBackAndWarnsOnce(t *testing.T) {
+	catalog, hook := newTestCatalog(t)
+	delete(catalog.messages["de"], "INVALID_TOKEN")
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := catalog.Message("de", "INVALID_TOKEN", nil); got != "Token is invalid" {
+				t.Errorf("Message = %q, want the English fallback", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if warnings := len(hook.AllEntries()); warnings != 1 {
+		t.Fatalf("%d warnings, want 1", warnings)
+	}
+	if entry := hook.LastEntry(); entry.Level != logrus.WarnLevel || entry.Message != "Missing de translation for error code INVALID_TOKEN" {
+		t.Fatalf("warning = %s %q", entry.Level, entry.Message)
+	}
+
+	// Another locale missing the same code is a separate gap.
+	delete(catalog.messages["ru"], "INVALID_TOKEN")
+	catalog.Message("ru", "INVALID_TOKEN", nil)
+	catalog.Message("ru", "INVALID_TOKEN", nil)
+
+	if warnings := len(hook.AllEntries()); warnings != 2 {
+		t.Fatalf("%d warnings, want 2", warnings)
+	}
+}
+
+func TestUnknownCodeReturnsCode(t *testing.T) {
+	catalog, hook := newTestCatalog(t)
+
+	for range 2 {
+		if got := catalog.Message("de", "NOT_A_CODE", nil); got != "NOT_A_CODE" {
+			t.Fatalf("Message = %q, want the code itself", got)
+		}
+	}
+
+	// Both the German and the English entry are missing, each warned once.
+	if warnings := len(hook.AllEntries()); warnings != 2 {
+		t.Fatalf("%d warnings, want 2", warnings)
+	}
+}
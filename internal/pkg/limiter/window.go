@@ -0,0 +1,102 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// WindowLimiter counts events per key over a sliding window. An event is
+// counted as soon as it is reserved, so concurrent callers cannot all pass
+// the limit, and is taken back when the caller releases it.
+type WindowLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	events    map[string][]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewWindowLimiter(limit int, window time.Duration) *WindowLimiter {
+	return &WindowLimiter{
+		limit:  limit,
+		window: window,
+		events: make(map[string][]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Reserve counts an event for key if the limit allows it and returns a
+// function that takes the event back, for callers whose action then fails.
+// When the limit is reached it reports how long until the oldest counted
+// event leaves the window.
+func (l *WindowLimiter) Reserve(key string) (func(), bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	events := l.prune(key, now)
+	if len(events) >= l.limit {
+		return nil, false, events[0].Add(l.window).Sub(now)
+	}
+
+	l.events[key] = append(events, now)
+	l.sweep(now)
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { l.release(key, now) })
+	}
+
+	return release, true, 0
+}
+
+func (l *WindowLimiter) release(key string, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	events := l.events[key]
+	for i, event := range events {
+		if event.Equal(at) {
+			events = append(events[:i:i], events[i+1:]...)
+			break
+		}
+	}
+
+	if len(events) == 0 {
+		delete(l.events, key)
+		return
+	}
+
+	l.events[key] = events
+}
+
+func (l *WindowLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) <= l.window {
+		return
+	}
+
+	for otherKey := range l.events {
+		l.prune(otherKey, now)
+	}
+	l.lastSweep = now
+}
+
+func (l *WindowLimiter) prune(key string, now time.Time) []time.Time {
+	events := l.events[key]
+	cutoff := now.Add(-l.window)
+
+	firstValid := 0
+	for firstValid < len(events) && !events[firstValid].After(cutoff) {
+		firstValid++
+	}
+	events = events[firstValid:]
+
+	if len(events) == 0 {
+		delete(l.events, key)
+		return nil
+	}
+
+	l.events[key] = events
+	return events
+}
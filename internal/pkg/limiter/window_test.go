@@ -0,0 +1,91 @@
+package limiter
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(limit int, window time.Duration) (*WindowLimiter, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	l := NewWindowLimiter(limit, window)
+	l.now = func() time.Time { return now }
+
+	return l, &now
+}
+
+func TestReserveStopsAtLimit(t *testing.T) {
+	l, now := newTestLimiter(2, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, allowed, _ := l.Reserve("203.0.113.1"); !allowed {
+			t.Fatalf("reservation %d was refused", i+1)
+		}
+		*now = now.Add(10 * time.Minute)
+	}
+
+	_, allowed, retryAfter := l.Reserve("203.0.113.1")
+	if allowed {
+		t.Fatal("reservation beyond the limit was allowed")
+	}
+	if retryAfter != 40*time.Minute {
+		t.Fatalf("retryAfter = %s, want %s", retryAfter, 40*time.Minute)
+	}
+
+	if _, allowed, _ := l.Reserve("203.0.113.2"); !allowed {
+		t.Fatal("another key shares the limit")
+	}
+}
+
+func TestReserveAllowsAgainAfterWindow(t *testing.T) {
+	l, now := newTestLimiter(1, time.Hour)
+
+	l.Reserve("203.0.113.1")
+	*now = now.Add(time.Hour)
+
+	if _, allowed, _ := l.Reserve("203.0.113.1"); !allowed {
+		t.Fatal("reservation was refused after the window passed")
+	}
+}
+
+func TestReleaseReturnsTheSlot(t *testing.T) {
+	l, _ := newTestLimiter(1, time.Hour)
+
+	release, _, _ := l.Reserve("203.0.113.1")
+	release()
+	release()
+
+	first, allowed, _ := l.Reserve("203.0.113.1")
+	if !allowed {
+		t.Fatal("released slot was not returned")
+	}
+	if _, allowed, _ := l.Reserve("203.0.113.1"); allowed {
+		t.Fatal("releasing twice returned two slots")
+	}
+	first()
+}
+
+func TestReserveIsAtomic(t *testing.T) {
+	const limit = 3
+
+	l := NewWindowLimiter(limit, time.Hour)
+
+	var allowedCount atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, allowed, _ := l.Reserve("203.0.113.1"); allowed {
+				allowedCount.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := allowedCount.Load(); got != limit {
+		t.Fatalf("%d concurrent reservations were allowed, want %d", got, limit)
+	}
+}
@@ -0,0 +1,297 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
+
+	"jwtgo/internal/pkg/buildinfo"
+)
+
+const namespace = "jwtgo"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	signUps              *prometheus.CounterVec
+	signInAttempts       *prometheus.CounterVec
+	refreshes            *prometheus.CounterVec
+	refreshReuse         prometheus.Counter
+	passwordHashDuration *prometheus.HistogramVec
+	tokenIssueDuration   prometheus.Histogram
+	repositoryDuration   *prometheus.HistogramVec
+	requestDuration      *prometheus.HistogramVec
+	tokenSignDuration    *prometheus.HistogramVec
+	tokenVerifyDuration  *prometheus.HistogramVec
+	tokenVerifyFailures  *prometheus.CounterVec
+	rpcDuration          *prometheus.HistogramVec
+	requestsShed         prometheus.Counter
+	dbPoolConnections    *prometheus.GaugeVec
+	dbPoolWaitCount      prometheus.Gauge
+	dbPoolWaitDuration   prometheus.Gauge
+}
+
+// DefaultRequestBuckets spans the 5ms to 2s SLO range with log-spaced bounds.
+var DefaultRequestBuckets = prometheus.ExponentialBucketsRange(0.005, 2, 10)
+
+func New(requestBuckets []float64) *Metrics {
+	registry := prometheus.NewRegistry()
+
+	if len(requestBuckets) == 0 {
+		requestBuckets = DefaultRequestBuckets
+	}
+
+	m := &Metrics{
+		registry: registry,
+		signUps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "signups_total",
+			Help:      "Sign-up attempts by outcome.",
+		}, []string{"outcome"}),
+		signInAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "signin_attempts_total",
+			Help:      "Sign-in attempts by outcome and client type.",
+		}, []string{"outcome", "client"}),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "refreshes_total",
+			Help:      "Token refresh attempts by outcome.",
+		}, []string{"outcome"}),
+		refreshReuse: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "refresh_token_reuse_total",
+			Help:      "Refresh tokens presented after they were superseded.",
+		}),
+		passwordHashDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "password_hash_duration_seconds",
+			Help:      "Time spent hashing or verifying passwords.",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation"}),
+		tokenIssueDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "token_issue_duration_seconds",
+			Help:      "Time spent issuing and persisting a token pair.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_duration_seconds",
+			Help:      "Repository call latency by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status.",
+			Buckets:   requestBuckets,
+		}, []string{"method", "route", "status"}),
+		tokenSignDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "token_sign_duration_seconds",
+			Help:      "Time spent signing a single token by algorithm and token type.",
+			Buckets:   []float64{.00001, .000025, .00005, .0001, .00025, .0005, .001, .0025, .005},
+		}, []string{"algorithm", "token_type"}),
+		tokenVerifyDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "token_verify_duration_seconds",
+			Help:      "Time spent verifying a token by algorithm and outcome.",
+			Buckets:   []float64{.00001, .000025, .00005, .0001, .00025, .0005, .001, .0025, .005},
+		}, []string{"algorithm", "outcome"}),
+		tokenVerifyFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "token_verification_failures_total",
+			Help:      "Rejected tokens by failure reason.",
+		}, []string{"reason"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "gRPC call latency by method and status code.",
+			Buckets:   requestBuckets,
+		}, []string{"method", "code"}),
+		requestsShed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_shed_total",
+			Help:      "Requests rejected because the in-flight limit was reached.",
+		}),
+		dbPoolConnections: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "db_pool_connections",
+			Help:      "Database pool connections by state: in_use, idle or waiting for a checkout.",
+		}, []string{"state"}),
+		dbPoolWaitCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "db_pool_wait_count",
+			Help:      "Total connection checkouts since start.",
+		}),
+		dbPoolWaitDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "db_pool_wait_duration_seconds",
+			Help:      "Total time spent waiting for connection checkouts since start.",
+		}),
+	}
+
+	info := buildinfo.Get()
+	buildInfo := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "build_info",
+		Help:      "Build information of the running binary, always 1.",
+		ConstLabels: prometheus.Labels{
+			"version":    info.Version,
+			"commit":     info.Commit,
+			"build_date": info.BuildDate,
+			"go_version": info.GoVersion,
+		},
+	})
+	buildInfo.Set(1)
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		buildInfo,
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.signUps,
+		m.signInAttempts,
+		m.refreshes,
+		m.refreshReuse,
+		m.passwordHashDuration,
+		m.tokenIssueDuration,
+		m.repositoryDuration,
+		m.requestDuration,
+		m.tokenSignDuration,
+		m.tokenVerifyDuration,
+		m.tokenVerifyFailures,
+		m.rpcDuration,
+		m.requestsShed,
+		m.dbPoolConnections,
+		m.dbPoolWaitCount,
+		m.dbPoolWaitDuration,
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry, EnableOpenMetrics: true})
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) SignUp(outcome string) {
+	if m == nil {
+		return
+	}
+	m.signUps.WithLabelValues(outcome).Inc()
+}
+
+func (m *Metrics) SignIn(outcome, client string) {
+	if m == nil {
+		return
+	}
+	m.signInAttempts.WithLabelValues(outcome, client).Inc()
+}
+
+func (m *Metrics) Refresh(outcome string) {
+	if m == nil {
+		return
+	}
+	m.refreshes.WithLabelValues(outcome).Inc()
+}
+
+func (m *Metrics) RefreshReuse() {
+	if m == nil {
+		return
+	}
+	m.refreshReuse.Inc()
+}
+
+func (m *Metrics) ObservePasswordHash(operation string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.passwordHashDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) ObserveTokenIssue(start time.Time) {
+	if m == nil {
+		return
+	}
+	m.tokenIssueDuration.Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) ObserveRepository(operation string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.repositoryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) ObserveTokenSign(algorithm, tokenType string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.tokenSignDuration.WithLabelValues(algorithm, tokenType).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) ObserveTokenVerify(algorithm, failureReason string, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	outcome := "success"
+	if failureReason != "" {
+		outcome = "failure"
+		m.tokenVerifyFailures.WithLabelValues(failureReason).Inc()
+	}
+	m.tokenVerifyDuration.WithLabelValues(algorithm, outcome).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) ObserveRequest(ctx context.Context, method, route string, status int, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	observer := m.requestDuration.WithLabelValues(method, route, strconv.Itoa(status))
+	elapsed := time.Since(start).Seconds()
+
+	spanContext := trace.SpanContextFromContext(ctx)
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && spanContext.IsSampled() {
+		exemplarObserver.ObserveWithExemplar(elapsed, prometheus.Labels{"trace_id": spanContext.TraceID().String()})
+		return
+	}
+
+	observer.Observe(elapsed)
+}
+
+func (m *Metrics) ObserveRPC(method, code string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.rpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) RequestShed() {
+	if m == nil {
+		return
+	}
+	m.requestsShed.Inc()
+}
+
+func (m *Metrics) SetDatabasePool(inUse, idle, waiting int64, waitCount uint64, waitDuration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.dbPoolConnections.WithLabelValues("in_use").Set(float64(inUse))
+	m.dbPoolConnections.WithLabelValues("idle").Set(float64(idle))
+	m.dbPoolConnections.WithLabelValues("waiting").Set(float64(waiting))
+	m.dbPoolWaitCount.Set(float64(waitCount))
+	m.dbPoolWaitDuration.Set(waitDuration.Seconds())
+}
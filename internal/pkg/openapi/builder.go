@@ -0,0 +1,171 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	SchemeAccessCookie  = "accessTokenCookie"
+	SchemeRefreshCookie = "refreshTokenCookie"
+
+	jsonContentType = "application/json"
+)
+
+type Operation struct {
+	Method      string
+	Path        string
+	Id          string
+	Summary     string
+	Description string
+	Tag         string
+	Security    []string
+	Request     any
+	Response    any
+	SetsCookies bool
+	Errors      []string
+}
+
+type StatusResolver func(code string) (int, bool)
+
+type MessageResponse struct {
+	Message string `json:"message" validate:"required"`
+}
+
+func Build(info Info, operations []Operation, statuses StatusResolver) *Document {
+	document := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{
+				SchemeAccessCookie:  {Type: "apiKey", In: "cookie", Name: "access_token", Description: "Access token issued on sign-in"},
+				SchemeRefreshCookie: {Type: "apiKey", In: "cookie", Name: "refresh_token", Description: "Refresh token issued on sign-in"},
+			},
+		},
+	}
+
+	for _, operation := range operations {
+		item, ok := document.Paths[operation.Path]
+		if !ok {
+			item = &PathItem{}
+			document.Paths[operation.Path] = item
+		}
+
+		(*item)[strings.ToLower(operation.Method)] = document.operationObject(operation, statuses)
+	}
+
+	return document
+}
+
+func (d *Document) operationObject(operation Operation, statuses StatusResolver) *OperationObject {
+	object := &OperationObject{
+		OperationId: operation.Id,
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		Responses:   map[string]*Response{},
+	}
+
+	if operation.Tag != "" {
+		object.Tags = []string{operation.Tag}
+	}
+
+	for _, scheme := range operation.Security {
+		object.Security = append(object.Security, map[string][]string{scheme: {}})
+	}
+
+	if operation.Request != nil {
+		object.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{jsonContentType: {Schema: d.reference(operation.Request)}},
+		}
+	}
+
+	response := operation.Response
+	if response == nil {
+		response = MessageResponse{}
+	}
+
+	success := &Response{
+		Description: http.StatusText(http.StatusOK),
+		Content:     map[string]MediaType{jsonContentType: {Schema: d.reference(response)}},
+	}
+	if operation.SetsCookies {
+		success.Headers = map[string]Header{
+			"Set-Cookie": {Description: "Sets the access_token and refresh_token cookies", Schema: &Schema{Type: "string"}},
+		}
+	}
+	object.Responses[strconv.Itoa(http.StatusOK)] = success
+
+	for status, codes := range groupByStatus(operation.Errors, statuses) {
+		object.Responses[strconv.Itoa(status)] = errorResponse(status, codes)
+	}
+
+	return object
+}
+
+// reference registers the schema of value's type under the type name and
+// returns a reference to it.
+func (d *Document) reference(value any) *Schema {
+	t := reflect.TypeOf(value)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	name := t.Name()
+	if name == "" || t.Kind() != reflect.Struct {
+		return schemaFor(t)
+	}
+
+	if _, ok := d.Components.Schemas[name]; !ok {
+		d.Components.Schemas[name] = schemaFor(t)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func groupByStatus(codes []string, statuses StatusResolver) map[int][]string {
+	grouped := map[int][]string{}
+
+	for _, code := range codes {
+		status, ok := statuses(code)
+		if !ok {
+			panic(fmt.Sprintf("openapi: unknown error code %s", code))
+		}
+		grouped[status] = append(grouped[status], code)
+	}
+
+	for _, group := range grouped {
+		sort.Strings(group)
+	}
+
+	return grouped
+}
+
+func errorResponse(status int, codes []string) *Response {
+	response := &Response{
+		Description: http.StatusText(status),
+		Content: map[string]MediaType{jsonContentType: {Schema: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"code":       {Type: "string", Enum: codes},
+				"message":    {Type: "string", Description: "Localized according to Accept-Language"},
+				"request_id": {Type: "string"},
+			},
+			Required: []string{"code", "message", "request_id"},
+		}}},
+	}
+
+	if status == http.StatusTooManyRequests {
+		response.Headers = map[string]Header{
+			"Retry-After": {Description: "Seconds until the request may be retried", Schema: &Schema{Type: "integer"}},
+		}
+	}
+
+	return response
+}
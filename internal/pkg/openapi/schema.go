@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor derives a schema from a DTO type using its json tags for property
+// names and its validate tags for constraints, so the document follows the
+// structs the handlers actually bind.
+func schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := schemaFor(field.Type)
+		if applyConstraints(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// applyConstraints maps the validator rules the DTOs use onto the schema and
+// reports whether the field is required.
+func applyConstraints(schema *Schema, rules string) bool {
+	required := false
+
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "required":
+			required = true
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "uuid":
+			schema.Format = "uuid"
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "min", "max", "len":
+			applyBound(schema, name, param)
+		}
+	}
+
+	return required
+}
+
+func applyBound(schema *Schema, rule string, param string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	count := int(value)
+
+	switch schema.Type {
+	case "string":
+		if rule != "max" {
+			schema.MinLength = &count
+		}
+		if rule != "min" {
+			schema.MaxLength = &count
+		}
+	case "array":
+		if rule != "max" {
+			schema.MinItems = &count
+		}
+		if rule != "min" {
+			schema.MaxItems = &count
+		}
+	case "integer", "number":
+		if rule != "max" {
+			schema.Minimum = &value
+		}
+		if rule != "min" {
+			schema.Maximum = &value
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	swaggerFiles "github.com/swaggo/files"
+)
+
+//go:embed swagger-ui.html
+var swaggerUIPage []byte
+
+// SwaggerUIPage loads its assets from /api/v1/docs/assets and the document from
+// /api/v1/openapi.json.
+func SwaggerUIPage() []byte {
+	return swaggerUIPage
+}
+
+func SwaggerUIAssets() http.FileSystem {
+	return swaggerFiles.HTTP
+}
@@ -0,0 +1,27 @@
+package request
+
+import (
+	"context"
+)
+
+type clientIPContextKey struct{}
+
+type userAgentContextKey struct{}
+
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, ip)
+}
+
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	return ip
+}
+
+func WithUserAgent(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, userAgentContextKey{}, userAgent)
+}
+
+func UserAgent(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentContextKey{}).(string)
+	return userAgent
+}
@@ -0,0 +1,123 @@
+package request
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/pkg/i18n"
+	"jwtgo/pkg/logging"
+)
+
+const (
+	fallbackErrorCode = "INTERNAL_SERVER_ERROR"
+	bearerRealm       = "jwtgo"
+)
+
+type codedError interface {
+	Code() string
+}
+
+type parameterizedError interface {
+	Params() map[string]string
+}
+
+type detailedError interface {
+	Details() map[string]interface{}
+}
+
+type retryableError interface {
+	RetryAfter() time.Duration
+}
+
+type bearerError interface {
+	error
+	BearerError() string
+}
+
+type StatusResolver func(code string) (int, bool)
+
+// errorResponse is the body of every error response. A struct rather than a
+// gin.H keeps the map and its per-key allocations off the error path.
+type errorResponse struct {
+	Code      string                 `json:"code"`
+	Message   string                 `json:"message"`
+	RequestId string                 `json:"request_id"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+type ErrorMapper struct {
+	catalog  *i18n.Catalog
+	statuses StatusResolver
+	logger   *logging.Logger
+}
+
+func NewErrorMapper(catalog *i18n.Catalog, statuses StatusResolver, logger *logging.Logger) *ErrorMapper {
+	return &ErrorMapper{
+		catalog:  catalog,
+		statuses: statuses,
+		logger:   logger,
+	}
+}
+
+func (em *ErrorMapper) RespondError(c *gin.Context, err error) {
+	code := fallbackErrorCode
+	var coded codedError
+	if errors.As(err, &coded) {
+		code = coded.Code()
+	}
+
+	status, ok := em.statuses(code)
+	if !ok {
+		code = fallbackErrorCode
+		status = http.StatusInternalServerError
+	}
+
+	var params map[string]string
+	var parameterized parameterizedError
+	if errors.As(err, &parameterized) {
+		params = parameterized.Params()
+	}
+
+	var retryable retryableError
+	isRetryable := errors.As(err, &retryable)
+	if isRetryable {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryable.RetryAfter().Seconds()))))
+	}
+
+	// Only a rejected bearer token warrants a challenge; bad credentials, API
+	// keys and callback signatures are not answered by presenting a token.
+	var bearer bearerError
+	if status == http.StatusUnauthorized && errors.As(err, &bearer) {
+		c.Header("WWW-Authenticate", bearerChallenge(bearer))
+	}
+
+	locale := em.catalog.Locale(c.GetHeader("Accept-Language"))
+
+	// Back-pressure responses are expected under load and would flood the log.
+	if status >= http.StatusInternalServerError && !isRetryable {
+		em.logger.ForContext(c.Request.Context()).WithFields(map[string]interface{}{
+			"method": c.Request.Method,
+			"path":   c.FullPath(),
+		}).Error("Request failed: ", err)
+	}
+
+	response := errorResponse{Code: code, Message: em.catalog.Message(locale, code, params), RequestId: Id(c)}
+
+	var detailed detailedError
+	if errors.As(err, &detailed) {
+		response.Details = detailed.Details()
+	}
+
+	c.JSON(status, &response)
+}
+
+// bearerChallenge builds an RFC 6750 challenge for a rejected bearer token.
+func bearerChallenge(err bearerError) string {
+	return `Bearer realm="` + bearerRealm + `", error="` + err.BearerError() + `", error_description="` + strings.ReplaceAll(err.Error(), `"`, `'`) + `"`
+}
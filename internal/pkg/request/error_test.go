@@ -0,0 +1,118 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jwtgo/internal/pkg/i18n"
+	"jwtgo/pkg/logging"
+)
+
+type testError struct {
+	code       string
+	retryAfter time.Duration
+}
+
+func (e testError) Error() string { return e.code }
+
+func (e testError) Code() string { return e.code }
+
+func (e testError) RetryAfter() time.Duration { return e.retryAfter }
+
+var testStatuses = map[string]int{
+	"INVALID_CREDENTIALS":   http.StatusUnauthorized,
+	"SERVER_OVERLOADED":     http.StatusServiceUnavailable,
+	"INTERNAL_SERVER_ERROR": http.StatusInternalServerError,
+}
+
+func newTestErrorMapper(tb testing.TB) *ErrorMapper {
+	tb.Helper()
+
+	logger := logging.GetLogger("panic")
+	catalog, err := i18n.NewCatalog(&logger)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return NewErrorMapper(catalog, func(code string) (int, bool) {
+		status, ok := testStatuses[code]
+		return status, ok
+	}, &logger)
+}
+
+func respondError(errorMapper *ErrorMapper, err error) *httptest.ResponseRecorder {
+	c, recorder := newTestContext()
+	c.Request = httptest.NewRequest(http.MethodPost, "/auth/signin", nil)
+	c.Request.Header.Set("Accept-Language", "de")
+
+	errorMapper.RespondError(c, err)
+
+	return recorder
+}
+
+func TestRespondError(t *testing.T) {
+	errorMapper := newTestErrorMapper(t)
+
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		retryAfter string
+	}{
+		{"coded error", testError{code: "INVALID_CREDENTIALS"}, http.StatusUnauthorized, ""},
+		{"retryable error", testError{code: "SERVER_OVERLOADED", retryAfter: 1500 * time.Millisecond}, http.StatusServiceUnavailable, "2"},
+		{"unknown code", testError{code: "NOT_REGISTERED"}, http.StatusInternalServerError, ""},
+		{"uncoded error", errors.New("connection reset"), http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := respondError(errorMapper, tt.err)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+			if retryAfter := recorder.Header().Get("Retry-After"); tt.retryAfter != "" && retryAfter != tt.retryAfter {
+				t.Fatalf("Retry-After = %q, want %q", retryAfter, tt.retryAfter)
+			}
+		})
+	}
+}
+
+// TestRespondErrorAllocations caps the allocations of an error response,
+// test context included, just above the current count.
+func TestRespondErrorAllocations(t *testing.T) {
+	errorMapper := newTestErrorMapper(t)
+	err := testError{code: "INVALID_CREDENTIALS"}
+
+	allocs := testing.AllocsPerRun(100, func() { respondError(errorMapper, err) })
+	if allocs > 50 {
+		t.Fatalf("allocations = %.0f/op, want at most 50", allocs)
+	}
+}
+
+func BenchmarkRespondError(b *testing.B) {
+	errorMapper := newTestErrorMapper(b)
+	err := testError{code: "INVALID_CREDENTIALS"}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/auth/signin", func(c *gin.Context) { errorMapper.RespondError(c, err) })
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/signin", nil)
+	req.Header.Set("Accept-Language", "de")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusUnauthorized {
+			b.Fatalf("status = %d", recorder.Code)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package request
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdHeader = "X-Request-ID"
+
+	idContextKey = "requestId"
+	maxIdLength  = 128
+)
+
+func GenerateId() string {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "unavailable"
+	}
+	return hex.EncodeToString(randomBytes)
+}
+
+func Id(c *gin.Context) string {
+	return c.GetString(idContextKey)
+}
+
+func SetId(c *gin.Context, id string) {
+	c.Set(idContextKey, id)
+	c.Header(IdHeader, id)
+}
+
+func IsValidId(id string) bool {
+	if id == "" || len(id) > maxIdLength {
+		return false
+	}
+
+	for _, r := range id {
+		isAlphanumeric := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlphanumeric && r != '-' && r != '_' && r != '.' && r != ':' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -11,6 +11,11 @@ import (
 
 func SetCookies(c *gin.Context, cookies []schema.Cookie) {
 	for _, cookieData := range cookies {
+		sameSite := cookieData.SameSite
+		if sameSite == 0 {
+			sameSite = http.SameSiteStrictMode
+		}
+
 		cookie := &http.Cookie{
 			Name:     cookieData.Name,
 			Value:    cookieData.Value,
@@ -19,7 +24,7 @@ func SetCookies(c *gin.Context, cookies []schema.Cookie) {
 			Expires:  time.Now().UTC().Add(cookieData.Duration),
 			HttpOnly: true,
 			Secure:   true,
-			SameSite: http.SameSiteStrictMode,
+			SameSite: sameSite,
 		}
 		http.SetCookie(c.Writer, cookie)
 	}
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"net/http"
 	"time"
 )
 
@@ -8,4 +9,5 @@ type Cookie struct {
 	Name     string
 	Value    string
 	Duration time.Duration
+	SameSite http.SameSite
 }
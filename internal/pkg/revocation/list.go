@@ -0,0 +1,77 @@
+package revocation
+
+import (
+	"sync"
+	"time"
+)
+
+// List holds the ids of revoked tokens until they would have expired anyway.
+// It lives in process memory, so every instance only knows the revocations it
+// accepted itself.
+type List struct {
+	mu       sync.RWMutex
+	revoked  map[string]time.Time
+	subjects map[string]subjectRevocation
+	now      func() time.Time
+}
+
+type subjectRevocation struct {
+	issuedBefore time.Time
+	until        time.Time
+}
+
+func NewList() *List {
+	return &List{
+		revoked:  make(map[string]time.Time),
+		subjects: make(map[string]subjectRevocation),
+		now:      time.Now,
+	}
+}
+
+func (l *List) Revoke(tokenId string, expiresAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.prune()
+	l.revoked[tokenId] = expiresAt
+}
+
+// RevokeSubject revokes every token of subject issued before issuedBefore. The
+// entry is kept until the longest-lived of those tokens would have expired.
+func (l *List) RevokeSubject(subject string, issuedBefore, until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.prune()
+	l.subjects[subject] = subjectRevocation{issuedBefore: issuedBefore, until: until}
+}
+
+func (l *List) prune() {
+	now := l.now()
+	for id, until := range l.revoked {
+		if !now.Before(until) {
+			delete(l.revoked, id)
+		}
+	}
+	for subject, revocation := range l.subjects {
+		if !now.Before(revocation.until) {
+			delete(l.subjects, subject)
+		}
+	}
+}
+
+func (l *List) IsRevoked(tokenId string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	until, ok := l.revoked[tokenId]
+	return ok && l.now().Before(until)
+}
+
+func (l *List) IsSubjectRevoked(subject string, issuedAt time.Time) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	revocation, ok := l.subjects[subject]
+	return ok && l.now().Before(revocation.until) && issuedAt.Before(revocation.issuedBefore)
+}
@@ -0,0 +1,21 @@
+package security
+
+import (
+	"time"
+)
+
+const (
+	EventRefreshTokenReuse = "refresh_token_reuse"
+	EventSessionsRevoked   = "sessions_revoked"
+
+	SeverityHigh = "high"
+	SeverityLow  = "low"
+)
+
+type Event struct {
+	Type     string            `json:"type"`
+	Severity string            `json:"severity"`
+	UserId   string            `json:"user_id,omitempty"`
+	Time     time.Time         `json:"time"`
+	Fields   map[string]string `json:"fields,omitempty"`
+}
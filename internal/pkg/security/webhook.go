@@ -0,0 +1,113 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"jwtgo/pkg/logging"
+)
+
+const (
+	defaultWebhookRetryBackoff = time.Second
+	defaultWebhookTimeout      = 10 * time.Second
+)
+
+type WebhookOptions struct {
+	URL          string
+	MaxRetries   int
+	RetryBackoff time.Duration
+	Client       *http.Client
+}
+
+type WebhookNotifier struct {
+	options WebhookOptions
+	logger  *logging.Logger
+}
+
+func NewWebhookNotifier(options WebhookOptions, logger *logging.Logger) (*WebhookNotifier, error) {
+	if options.URL == "" {
+		return nil, fmt.Errorf("security webhook url is empty")
+	}
+	if options.RetryBackoff <= 0 {
+		options.RetryBackoff = defaultWebhookRetryBackoff
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+
+	return &WebhookNotifier{
+		options: options,
+		logger:  logger,
+	}, nil
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	event.Fields = logging.Redact(event.Fields)
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode security event: %w", err)
+	}
+
+	backoff := n.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+		retryable, err = n.send(ctx, body)
+		if err == nil || !retryable || attempt >= n.options.MaxRetries {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+			continue
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		break
+	}
+
+	if err != nil {
+		n.deadLetter(event, err)
+		return fmt.Errorf("deliver security event: %w", err)
+	}
+
+	return nil
+}
+
+func (n *WebhookNotifier) send(ctx context.Context, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, n.options.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := n.options.Client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		retryable := response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+		return retryable, fmt.Errorf("security webhook responded with status %d", response.StatusCode)
+	}
+
+	return false, nil
+}
+
+// Undelivered events are kept in the log so they can be replayed by hand.
+func (n *WebhookNotifier) deadLetter(event Event, err error) {
+	n.logger.WithFields(map[string]interface{}{
+		"dead_letter": true,
+		"event_type":  event.Type,
+		"severity":    event.Severity,
+		"user_id":     event.UserId,
+		"event_time":  event.Time,
+		"fields":      event.Fields,
+	}).Error("Security event could not be delivered: ", err)
+}
@@ -0,0 +1,132 @@
+package state
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	nonceSize  = 16
+	expirySize = 8
+	defaultTTL = 10 * time.Minute
+)
+
+var (
+	ErrInvalidState = errors.New("invalid state")
+	ErrExpiredState = errors.New("expired state")
+	ErrReusedState  = errors.New("state already used")
+)
+
+type Option func(*Signer)
+
+func WithTTL(ttl time.Duration) Option {
+	return func(s *Signer) {
+		s.ttl = ttl
+	}
+}
+
+func WithSingleUse() Option {
+	return func(s *Signer) {
+		s.singleUse = true
+	}
+}
+
+type Signer struct {
+	key       []byte
+	ttl       time.Duration
+	singleUse bool
+	now       func() time.Time
+
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+func NewSigner(secret string, options ...Option) *Signer {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("oauth-state"))
+
+	s := &Signer{
+		key:  mac.Sum(nil),
+		ttl:  defaultTTL,
+		now:  time.Now,
+		used: map[string]time.Time{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+func (s *Signer) GenerateState(payload string) (string, error) {
+	body := make([]byte, nonceSize+expirySize, nonceSize+expirySize+len(payload))
+	if _, err := rand.Read(body[:nonceSize]); err != nil {
+		return "", err
+	}
+
+	binary.BigEndian.PutUint64(body[nonceSize:], uint64(s.now().Add(s.ttl).Unix()))
+	body = append(body, payload...)
+
+	encodedBody := base64.RawURLEncoding.EncodeToString(body)
+	return encodedBody + "." + base64.RawURLEncoding.EncodeToString(s.sign(encodedBody)), nil
+}
+
+func (s *Signer) VerifyState(state string) (string, error) {
+	encodedBody, encodedSignature, ok := strings.Cut(state, ".")
+	if !ok {
+		return "", ErrInvalidState
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(encodedBody)) {
+		return "", ErrInvalidState
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(encodedBody)
+	if err != nil || len(body) < nonceSize+expirySize {
+		return "", ErrInvalidState
+	}
+
+	now := s.now()
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(body[nonceSize:nonceSize+expirySize])), 0)
+	if !now.Before(expiresAt) {
+		return "", ErrExpiredState
+	}
+
+	if s.singleUse && !s.markUsed(string(body[:nonceSize]), expiresAt, now) {
+		return "", ErrReusedState
+	}
+
+	return string(body[nonceSize+expirySize:]), nil
+}
+
+func (s *Signer) sign(encodedBody string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encodedBody))
+	return mac.Sum(nil)
+}
+
+func (s *Signer) markUsed(nonce string, expiresAt, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for usedNonce, usedUntil := range s.used {
+		if !now.Before(usedUntil) {
+			delete(s.used, usedNonce)
+		}
+	}
+
+	if _, used := s.used[nonce]; used {
+		return false
+	}
+
+	s.used[nonce] = expiresAt
+	return true
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "jwtgo"
+
+type Options struct {
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+	ServiceName string
+}
+
+type codedError interface {
+	Code() string
+}
+
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if options.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOptions := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(options.Endpoint)}
+	if options.Insecure {
+		exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, exporterOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(options.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+func End(span trace.Span, err error) {
+	if err != nil {
+		code := "INTERNAL_SERVER_ERROR"
+		var coded codedError
+		if errors.As(err, &coded) {
+			code = coded.Code()
+		}
+
+		span.SetAttributes(attribute.String("outcome", "failure"), attribute.String("error.code", code))
+		span.SetStatus(codes.Error, code)
+	} else {
+		span.SetAttributes(attribute.String("outcome", "success"))
+	}
+
+	span.End()
+}
+
+func UserId(id string) attribute.KeyValue {
+	sum := sha256.Sum256([]byte(id))
+	return attribute.String("user_id_hash", hex.EncodeToString(sum[:8]))
+}
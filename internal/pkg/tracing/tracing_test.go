@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type codeError struct{}
+
+func (codeError) Error() string { return "invalid token" }
+func (codeError) Code() string  { return "INVALID_TOKEN" }
+
+// newTestExporter records every span ended through the global provider until
+// the test finishes.
+func newTestExporter(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+
+	return exporter
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]string {
+	values := make(map[attribute.Key]string, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		values[kv.Key] = kv.Value.Emit()
+	}
+	return values
+}
+
+func TestEndRecordsOutcome(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		outcome string
+		code    string
+	}{
+		{"success", nil, "success", ""},
+		{"coded error", codeError{}, "failure", "INVALID_TOKEN"},
+		{"wrapped coded error", errors.Join(errors.New("refresh"), codeError{}), "failure", "INVALID_TOKEN"},
+		{"plain error", errors.New("connection refused"), "failure", "INTERNAL_SERVER_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := newTestExporter(t)
+
+			_, span := Start(context.Background(), "operation", UserId("user-1"))
+			End(span, tt.err)
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+
+			values := attributes(spans[0])
+			if values["outcome"] != tt.outcome {
+				t.Errorf("outcome = %q, want %q", values["outcome"], tt.outcome)
+			}
+			if values["error.code"] != tt.code {
+				t.Errorf("error.code = %q, want %q", values["error.code"], tt.code)
+			}
+
+			wantStatus := codes.Unset
+			if tt.err != nil {
+				wantStatus = codes.Error
+			}
+			if spans[0].Status.Code != wantStatus {
+				t.Errorf("status = %s, want %s", spans[0].Status.Code, wantStatus)
+			}
+		})
+	}
+}
+
+func TestUserIdIsHashed(t *testing.T) {
+	value := UserId("user-1").Value.AsString()
+
+	if value == "user-1" || len(value) != 16 {
+		t.Fatalf("user_id_hash = %q, want 16 hex characters", value)
+	}
+	if UserId("user-2").Value.AsString() == value {
+		t.Fatal("different users share a hash")
+	}
+}
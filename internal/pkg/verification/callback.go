@@ -0,0 +1,118 @@
+package verification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Signature"
+
+	DecisionApproved = "approved"
+	DecisionRejected = "rejected"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid callback signature")
+	ErrInvalidCallback  = errors.New("invalid callback payload")
+	ErrStaleCallback    = errors.New("callback timestamp is outside the accepted window")
+	ErrReplayedCallback = errors.New("callback nonce was already used")
+)
+
+type Callback struct {
+	UserId    string `json:"user_id"`
+	Decision  string `json:"decision"`
+	Nonce     string `json:"nonce"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// CallbackVerifier authenticates provider callbacks. Each nonce is accepted
+// once while its timestamp is within the tolerance, so a captured callback
+// cannot be replayed to flip an account back.
+type CallbackVerifier struct {
+	secret    []byte
+	tolerance time.Duration
+	now       func() time.Time
+
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+func NewCallbackVerifier(secret string, tolerance time.Duration) *CallbackVerifier {
+	return &CallbackVerifier{
+		secret:    []byte(secret),
+		tolerance: tolerance,
+		now:       time.Now,
+		used:      map[string]time.Time{},
+	}
+}
+
+func (v *CallbackVerifier) Verify(body []byte, signature string) (*Callback, error) {
+	encodedSignature, ok := strings.CutPrefix(signature, signaturePrefix)
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+
+	decodedSignature, err := hex.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(decodedSignature, sign(v.secret, body)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var callback Callback
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return nil, ErrInvalidCallback
+	}
+	if callback.UserId == "" || callback.Nonce == "" || (callback.Decision != DecisionApproved && callback.Decision != DecisionRejected) {
+		return nil, ErrInvalidCallback
+	}
+
+	now := v.now()
+	issuedAt := time.Unix(callback.Timestamp, 0)
+	if issuedAt.Before(now.Add(-v.tolerance)) || issuedAt.After(now.Add(v.tolerance)) {
+		return nil, ErrStaleCallback
+	}
+
+	if !v.markUsed(callback.Nonce, issuedAt.Add(v.tolerance), now) {
+		return nil, ErrReplayedCallback
+	}
+
+	return &callback, nil
+}
+
+func (v *CallbackVerifier) markUsed(nonce string, expiresAt, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for usedNonce, usedUntil := range v.used {
+		if !now.Before(usedUntil) {
+			delete(v.used, usedNonce)
+		}
+	}
+
+	if _, used := v.used[nonce]; used {
+		return false
+	}
+
+	v.used[nonce] = expiresAt
+	return true
+}
+
+// Signature returns the header value for body, in the format providers are
+// expected to send with callbacks.
+func Signature(secret string, body []byte) string {
+	return signaturePrefix + hex.EncodeToString(sign([]byte(secret), body))
+}
+
+func sign(secret, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
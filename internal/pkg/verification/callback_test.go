@@ -0,0 +1,109 @@
+package verification
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testSecret = "callback-secret"
+
+func newTestCallbackVerifier() (*CallbackVerifier, *time.Time) {
+	now := time.Unix(1_700_000_000, 0)
+
+	verifier := NewCallbackVerifier(testSecret, 5*time.Minute)
+	verifier.now = func() time.Time { return now }
+
+	return verifier, &now
+}
+
+func callbackBody(t *testing.T, callback Callback) []byte {
+	t.Helper()
+
+	body, err := json.Marshal(callback)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return body
+}
+
+func TestCallbackVerifier(t *testing.T) {
+	verifier, now := newTestCallbackVerifier()
+	valid := Callback{UserId: "user-1", Decision: DecisionApproved, Nonce: "nonce-1", Timestamp: now.Unix()}
+
+	body := callbackBody(t, valid)
+	callback, err := verifier.Verify(body, Signature(testSecret, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *callback != valid {
+		t.Fatalf("callback = %+v, want %+v", callback, valid)
+	}
+
+	if _, err := verifier.Verify(body, Signature(testSecret, body)); !errors.Is(err, ErrReplayedCallback) {
+		t.Fatalf("replayed callback error = %v, want ErrReplayedCallback", err)
+	}
+}
+
+func TestCallbackVerifierRejects(t *testing.T) {
+	verifier, now := newTestCallbackVerifier()
+
+	callback := func(modify func(*Callback)) []byte {
+		c := Callback{UserId: "user-1", Decision: DecisionRejected, Nonce: "nonce-1", Timestamp: now.Unix()}
+		modify(&c)
+		return callbackBody(t, c)
+	}
+	unchanged := callback(func(*Callback) {})
+
+	tests := []struct {
+		name      string
+		body      []byte
+		signature string
+		want      error
+	}{
+		{"wrong secret", unchanged, Signature("other-secret", unchanged), ErrInvalidSignature},
+		{"missing prefix", unchanged, Signature(testSecret, unchanged)[len(signaturePrefix):], ErrInvalidSignature},
+		{"not hex", unchanged, signaturePrefix + "zz", ErrInvalidSignature},
+		{"body changed after signing", callback(func(c *Callback) { c.Decision = DecisionApproved }), Signature(testSecret, unchanged), ErrInvalidSignature},
+		{"not JSON", []byte("approved"), Signature(testSecret, []byte("approved")), ErrInvalidCallback},
+		{"unknown decision", callback(func(c *Callback) { c.Decision = "maybe" }), "", ErrInvalidCallback},
+		{"no nonce", callback(func(c *Callback) { c.Nonce = "" }), "", ErrInvalidCallback},
+		{"no user", callback(func(c *Callback) { c.UserId = "" }), "", ErrInvalidCallback},
+		{"too old", callback(func(c *Callback) { c.Timestamp = now.Add(-10 * time.Minute).Unix() }), "", ErrStaleCallback},
+		{"from the future", callback(func(c *Callback) { c.Timestamp = now.Add(10 * time.Minute).Unix() }), "", ErrStaleCallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := tt.signature
+			if signature == "" {
+				signature = Signature(testSecret, tt.body)
+			}
+
+			if _, err := verifier.Verify(tt.body, signature); !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallbackVerifierForgetsExpiredNonces(t *testing.T) {
+	verifier, now := newTestCallbackVerifier()
+
+	body := callbackBody(t, Callback{UserId: "user-1", Decision: DecisionApproved, Nonce: "nonce-1", Timestamp: now.Unix()})
+	if _, err := verifier.Verify(body, Signature(testSecret, body)); err != nil {
+		t.Fatal(err)
+	}
+
+	*now = now.Add(time.Hour)
+	other := callbackBody(t, Callback{UserId: "user-2", Decision: DecisionApproved, Nonce: "nonce-2", Timestamp: now.Unix()})
+	if _, err := verifier.Verify(other, Signature(testSecret, other)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(verifier.used) != 1 {
+		t.Fatalf("%d nonces remembered, want only the unexpired one", len(verifier.used))
+	}
+}
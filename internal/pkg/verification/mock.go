@@ -0,0 +1,32 @@
+package verification
+
+import (
+	"context"
+	"sync"
+)
+
+// Mock is an IdentityVerifier for tests. It answers every request with
+// Approve and Err and records the users it was asked to verify.
+type Mock struct {
+	Approve bool
+	Err     error
+
+	mu      sync.Mutex
+	started []string
+}
+
+func (m *Mock) StartVerification(ctx context.Context, userId, email string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.started = append(m.started, email)
+	return m.Approve, m.Err
+}
+
+// Started returns the emails verification was started for, in order.
+func (m *Mock) Started() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.started...)
+}
@@ -0,0 +1,12 @@
+package verification
+
+import (
+	"context"
+)
+
+// Noop approves every account as soon as it is created.
+type Noop struct{}
+
+func (Noop) StartVerification(ctx context.Context, userId, email string) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,103 @@
+package verification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultProviderRetryBackoff = time.Second
+	defaultProviderTimeout      = 10 * time.Second
+)
+
+type HTTPProviderOptions struct {
+	URL          string
+	CallbackURL  string
+	Secret       string
+	MaxRetries   int
+	RetryBackoff time.Duration
+	Client       *http.Client
+}
+
+// HTTPProvider starts a check at an external provider, which reports the
+// decision asynchronously to the callback endpoint.
+type HTTPProvider struct {
+	options HTTPProviderOptions
+}
+
+type verificationRequest struct {
+	UserId      string `json:"user_id"`
+	Email       string `json:"email"`
+	CallbackURL string `json:"callback_url"`
+}
+
+func NewHTTPProvider(options HTTPProviderOptions) (*HTTPProvider, error) {
+	if options.URL == "" {
+		return nil, fmt.Errorf("verification provider url is empty")
+	}
+	if options.RetryBackoff <= 0 {
+		options.RetryBackoff = defaultProviderRetryBackoff
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: defaultProviderTimeout}
+	}
+
+	return &HTTPProvider{options: options}, nil
+}
+
+func (p *HTTPProvider) StartVerification(ctx context.Context, userId, email string) (bool, error) {
+	body, err := json.Marshal(verificationRequest{UserId: userId, Email: email, CallbackURL: p.options.CallbackURL})
+	if err != nil {
+		return false, fmt.Errorf("encode verification request: %w", err)
+	}
+
+	backoff := p.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+		retryable, err = p.send(ctx, body)
+		if err == nil || !retryable || attempt >= p.options.MaxRetries {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+			continue
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		break
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("start identity verification: %w", err)
+	}
+
+	return false, nil
+}
+
+func (p *HTTPProvider) send(ctx context.Context, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.options.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, Signature(p.options.Secret, body))
+
+	response, err := p.options.Client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		retryable := response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+		return retryable, fmt.Errorf("verification provider responded with status %d", response.StatusCode)
+	}
+
+	return false, nil
+}
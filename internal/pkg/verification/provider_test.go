@@ -0,0 +1,107 @@
+package verification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestProvider(t *testing.T, maxRetries int, handler http.HandlerFunc) *HTTPProvider {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	provider, err := NewHTTPProvider(HTTPProviderOptions{
+		URL:          server.URL,
+		CallbackURL:  "https://auth.example.com/auth/verification/callback",
+		Secret:       testSecret,
+		MaxRetries:   maxRetries,
+		RetryBackoff: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return provider
+}
+
+func TestHTTPProviderSendsSignedRequest(t *testing.T) {
+	var received verificationRequest
+	provider := newTestProvider(t, 0, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(SignatureHeader) != Signature(testSecret, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	approved, err := provider.StartVerification(context.Background(), "user-1", "user@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approved {
+		t.Fatal("the provider decides asynchronously, but the account was approved")
+	}
+
+	want := verificationRequest{UserId: "user-1", Email: "user@example.com", CallbackURL: "https://auth.example.com/auth/verification/callback"}
+	if received != want {
+		t.Fatalf("request = %+v, want %+v", received, want)
+	}
+}
+
+func TestHTTPProviderRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		failures int32
+		attempts int32
+		wantErr  bool
+	}{
+		{"server error then accepted", http.StatusBadGateway, 2, 3, false},
+		{"rate limited then accepted", http.StatusTooManyRequests, 1, 2, false},
+		{"server error beyond max retries", http.StatusInternalServerError, 10, 4, true},
+		{"client error is not retried", http.StatusBadRequest, 10, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			provider := newTestProvider(t, 3, func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			})
+
+			_, err := provider.StartVerification(context.Background(), "user-1", "user@example.com")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want an error: %t", err, tt.wantErr)
+			}
+			if got := attempts.Load(); got != tt.attempts {
+				t.Fatalf("attempts = %d, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestHTTPProviderStopsRetryingWhenContextEnds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	provider := newTestProvider(t, 100, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := provider.StartVerification(ctx, "user-1", "user@example.com"); err == nil {
+		t.Fatal("verification started after the context ended")
+	}
+}
@@ -0,0 +1,40 @@
+package authclient
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Claims describes a verified access token. Email and Roles are only known to
+// the LocalVerifier; the gRPC validation response does not carry them.
+type Claims struct {
+	UserId    string
+	TokenType string
+	Email     string
+	Roles     []string
+	ExpiresAt time.Time
+	AuthTime  time.Time
+}
+
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+type claimsContextKey struct{}
+
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*Claims)
+	return claims, ok
+}
+
+func UserId(ctx context.Context) string {
+	if claims, ok := FromContext(ctx); ok {
+		return claims.UserId
+	}
+	return ""
+}
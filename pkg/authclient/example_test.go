@@ -0,0 +1,48 @@
+package authclient_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"jwtgo/pkg/authclient"
+)
+
+// A downstream service that trusts access tokens signed with the shared
+// secret and reads the caller from the request context.
+func Example() {
+	gin.SetMode(gin.ReleaseMode)
+	secret := []byte("shared-secret")
+
+	router := gin.New()
+	router.Use(authclient.Middleware(authclient.NewLocalVerifier(secret, 5*time.Second), authclient.Options{}))
+	router.GET("/orders", func(c *gin.Context) {
+		claims, _ := authclient.FromContext(c.Request.Context())
+		c.String(http.StatusOK, "orders of %s", claims.UserId)
+	})
+
+	accessToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":        "user-1",
+		"token_type": "access",
+		"exp":        time.Now().Add(time.Minute).Unix(),
+	}).SignedString(secret)
+
+	for _, authorization := range []string{"Bearer " + accessToken, ""} {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		fmt.Println(recorder.Code, recorder.Body.String())
+	}
+
+	// Output:
+	// 200 orders of user-1
+	// 401 {"code":"INVALID_TOKEN","message":"Access token is missing"}
+}
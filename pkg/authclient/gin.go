@@ -0,0 +1,59 @@
+package authclient
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const accessTokenCookie = "access_token"
+
+type Options struct {
+	// FailOpen lets requests through without claims when the verifier is
+	// unavailable instead of rejecting them.
+	FailOpen bool
+}
+
+func Middleware(verifier Verifier, options Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c.GetHeader("Authorization"))
+		if token == "" {
+			token, _ = c.Cookie(accessTokenCookie)
+		}
+
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"code": "INVALID_TOKEN", "message": "Access token is missing"})
+			return
+		}
+
+		claims, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil {
+			if errors.Is(err, ErrUnavailable) {
+				if options.FailOpen {
+					c.Next()
+					return
+				}
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"code": "AUTH_UNAVAILABLE", "message": "Token verification is unavailable"})
+				return
+			}
+
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"code": "INVALID_TOKEN", "message": "Access token is invalid"})
+			return
+		}
+
+		c.Set("id", claims.UserId)
+		c.Request = c.Request.WithContext(WithClaims(c.Request.Context(), claims))
+
+		c.Next()
+	}
+}
+
+func bearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) > len(prefix) && strings.EqualFold(header[:len(prefix)], prefix) {
+		return strings.TrimSpace(header[len(prefix):])
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeVerifier struct {
+	claims *Claims
+	err    error
+}
+
+func (v fakeVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return v.claims, nil
+}
+
+func newProtectedRouter(verifier Verifier, options Options) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/", Middleware(verifier, options), func(c *gin.Context) {
+		c.String(http.StatusOK, UserId(c.Request.Context()))
+	})
+
+	return router
+}
+
+func TestMiddleware(t *testing.T) {
+	verified := fakeVerifier{claims: &Claims{UserId: "user-1"}}
+
+	tests := []struct {
+		name     string
+		verifier Verifier
+		options  Options
+		header   string
+		cookie   string
+		status   int
+		body     string
+	}{
+		{"bearer token", verified, Options{}, "Bearer token", "", http.StatusOK, "user-1"},
+		{"lower-case scheme", verified, Options{}, "bearer token", "", http.StatusOK, "user-1"},
+		{"cookie", verified, Options{}, "", "token", http.StatusOK, "user-1"},
+		{"no token", verified, Options{}, "", "", http.StatusUnauthorized, ""},
+		{"basic auth", verified, Options{}, "Basic dXNlcjpwYXNz", "", http.StatusUnauthorized, ""},
+		{"invalid token", fakeVerifier{err: ErrUnauthenticated}, Options{}, "Bearer token", "", http.StatusUnauthorized, ""},
+		{"unavailable", fakeVerifier{err: ErrUnavailable}, Options{}, "Bearer token", "", http.StatusServiceUnavailable, ""},
+		{"unavailable fail-open", fakeVerifier{err: ErrUnavailable}, Options{FailOpen: true}, "Bearer token", "", http.StatusOK, ""},
+		{"invalid token fail-open", fakeVerifier{err: ErrUnauthenticated}, Options{FailOpen: true}, "Bearer token", "", http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: accessTokenCookie, Value: tt.cookie})
+			}
+
+			recorder := httptest.NewRecorder()
+			newProtectedRouter(tt.verifier, tt.options).ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+			if tt.status == http.StatusOK && recorder.Body.String() != tt.body {
+				t.Fatalf("user id = %q, want %q", recorder.Body.String(), tt.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func UnaryServerInterceptor(verifier Verifier, options Options) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, verifier, options)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamServerInterceptor(verifier Verifier, options Options) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), verifier, options)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, verifier Verifier, options Options) (context.Context, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			token = bearerToken(values[0])
+		}
+	}
+
+	if token == "" {
+		return ctx, status.Error(codes.Unauthenticated, "access token is missing")
+	}
+
+	claims, err := verifier.Verify(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrUnavailable) {
+			if options.FailOpen {
+				return ctx, nil
+			}
+			return ctx, status.Error(codes.Unavailable, "token verification is unavailable")
+		}
+
+		return ctx, status.Error(codes.Unauthenticated, "access token is invalid")
+	}
+
+	return WithClaims(ctx, claims), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
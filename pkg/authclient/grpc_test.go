@@ -0,0 +1,53 @@
+package authclient
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	verified := fakeVerifier{claims: &Claims{UserId: "user-1"}}
+
+	tests := []struct {
+		name          string
+		verifier      Verifier
+		options       Options
+		authorization string
+		code          codes.Code
+		userId        string
+	}{
+		{"bearer token", verified, Options{}, "Bearer token", codes.OK, "user-1"},
+		{"no token", verified, Options{}, "", codes.Unauthenticated, ""},
+		{"invalid token", fakeVerifier{err: ErrUnauthenticated}, Options{}, "Bearer token", codes.Unauthenticated, ""},
+		{"unavailable", fakeVerifier{err: ErrUnavailable}, Options{}, "Bearer token", codes.Unavailable, ""},
+		{"unavailable fail-open", fakeVerifier{err: ErrUnavailable}, Options{FailOpen: true}, "Bearer token", codes.OK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+
+			var userId string
+			handler := func(ctx context.Context, req any) (any, error) {
+				userId = UserId(ctx)
+				return nil, nil
+			}
+
+			_, err := UnaryServerInterceptor(tt.verifier, tt.options)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test/Method"}, handler)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("code = %s, want %s", code, tt.code)
+			}
+			if userId != tt.userId {
+				t.Fatalf("user id = %q, want %q", userId, tt.userId)
+			}
+		})
+	}
+}
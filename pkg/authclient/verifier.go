@@ -0,0 +1,110 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "jwtgo/api/auth/v1"
+)
+
+const accessTokenType = "access"
+
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrUnavailable     = errors.New("token verification unavailable")
+)
+
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+type tokenClaims struct {
+	TokenType string           `json:"token_type"`
+	Email     string           `json:"email,omitempty"`
+	Roles     []string         `json:"roles,omitempty"`
+	AuthTime  *jwt.NumericDate `json:"auth_time,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type LocalVerifier struct {
+	secret []byte
+	parser *jwt.Parser
+}
+
+func NewLocalVerifier(secret []byte, leeway time.Duration) *LocalVerifier {
+	return &LocalVerifier{
+		secret: secret,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithLeeway(leeway),
+			jwt.WithExpirationRequired(),
+		),
+	}
+}
+
+func (v *LocalVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	var parsed tokenClaims
+	_, err := v.parser.ParseWithClaims(token, &parsed, func(*jwt.Token) (interface{}, error) {
+		return v.secret, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	if parsed.TokenType != accessTokenType || parsed.Subject == "" {
+		return nil, fmt.Errorf("%w: not an access token", ErrUnauthenticated)
+	}
+
+	claims := &Claims{UserId: parsed.Subject, TokenType: parsed.TokenType, Email: parsed.Email, Roles: parsed.Roles, ExpiresAt: parsed.ExpiresAt.Time}
+	if parsed.AuthTime != nil {
+		claims.AuthTime = parsed.AuthTime.Time
+	}
+
+	return claims, nil
+}
+
+type RemoteVerifier struct {
+	client  authv1.AuthServiceClient
+	timeout time.Duration
+}
+
+func NewRemoteVerifier(client authv1.AuthServiceClient, timeout time.Duration) *RemoteVerifier {
+	return &RemoteVerifier{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+func (v *RemoteVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	if v.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, v.timeout)
+		defer cancel()
+	}
+
+	response, err := v.client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: token, TokenType: accessTokenType})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated, codes.InvalidArgument, codes.PermissionDenied:
+			return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+		}
+	}
+
+	claims := &Claims{UserId: response.GetUserId(), TokenType: response.GetTokenType()}
+	if response.GetExpiresAt() != nil {
+		claims.ExpiresAt = response.GetExpiresAt().AsTime()
+	}
+	if response.GetAuthTime() != nil {
+		claims.AuthTime = response.GetAuthTime().AsTime()
+	}
+
+	return claims, nil
+}
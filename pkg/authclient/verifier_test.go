@@ -0,0 +1,95 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "jwtgo/api/auth/v1"
+	"jwtgo/internal/app/schema"
+	"jwtgo/internal/app/service"
+	"jwtgo/internal/pkg/revocation"
+)
+
+const testSecret = "test-secret"
+
+// issueTokens signs a token pair the way the jwtgo service does, so the tests
+// also catch drift between the service's claims and what the client reads.
+func issueTokens(t *testing.T, secret string) (string, string) {
+	t.Helper()
+
+	jwtService := service.NewJWTService(secret, 10, 60, 5, revocation.NewList(), nil, nil)
+	accessToken, refreshToken, err := jwtService.GenerateTokens(schema.Claims{Id: "user-1", Email: "user@example.com", Roles: []string{"admin"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return accessToken, refreshToken
+}
+
+func TestLocalVerifier(t *testing.T) {
+	verifier := NewLocalVerifier([]byte(testSecret), 0)
+	accessToken, refreshToken := issueTokens(t, testSecret)
+	otherAccessToken, _ := issueTokens(t, "other-secret")
+
+	claims, err := verifier.Verify(context.Background(), accessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserId != "user-1" || claims.Email != "user@example.com" || !claims.HasRole("admin") || claims.ExpiresAt.IsZero() {
+		t.Fatalf("claims = %+v, want the issued user, email, roles and expiry", claims)
+	}
+
+	for name, token := range map[string]string{"refresh token": refreshToken, "other secret": otherAccessToken, "garbage": "garbage"} {
+		if _, err := verifier.Verify(context.Background(), token); !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("%s: error = %v, want ErrUnauthenticated", name, err)
+		}
+	}
+}
+
+type fakeAuthServiceClient struct {
+	authv1.AuthServiceClient
+	response *authv1.ValidateTokenResponse
+	err      error
+}
+
+func (c *fakeAuthServiceClient) ValidateToken(ctx context.Context, in *authv1.ValidateTokenRequest, opts ...grpc.CallOption) (*authv1.ValidateTokenResponse, error) {
+	return c.response, c.err
+}
+
+func TestRemoteVerifier(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	verifier := NewRemoteVerifier(&fakeAuthServiceClient{response: &authv1.ValidateTokenResponse{UserId: "user-1", TokenType: "access", ExpiresAt: timestamppb.New(expiresAt)}}, time.Second)
+	claims, err := verifier.Verify(context.Background(), "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserId != "user-1" || !claims.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("claims = %+v, want user-1 expiring at %s", claims, expiresAt)
+	}
+
+	tests := []struct {
+		code codes.Code
+		want error
+	}{
+		{codes.Unauthenticated, ErrUnauthenticated},
+		{codes.InvalidArgument, ErrUnauthenticated},
+		{codes.Unavailable, ErrUnavailable},
+		{codes.DeadlineExceeded, ErrUnavailable},
+		{codes.Internal, ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		verifier := NewRemoteVerifier(&fakeAuthServiceClient{err: status.Error(tt.code, "failed")}, time.Second)
+		if _, err := verifier.Verify(context.Background(), "token"); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.code, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	accessTokenCookie  = "access_token"
+	refreshTokenCookie = "refresh_token"
+	maxRetryDelay      = 30 * time.Second
+)
+
+type AuthClientOptions struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Timeout    time.Duration
+	MaxRetries int
+}
+
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+}
+
+// AuthClient calls the HTTP auth API. The server delivers tokens as cookies;
+// the client reads them from the response instead of keeping a cookie jar.
+type AuthClient struct {
+	baseURL    string
+	httpClient *http.Client
+	timeout    time.Duration
+	maxRetries int
+}
+
+func NewAuthClient(options AuthClientOptions) *AuthClient {
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &AuthClient{
+		baseURL:    strings.TrimRight(options.BaseURL, "/"),
+		httpClient: httpClient,
+		timeout:    options.Timeout,
+		maxRetries: options.MaxRetries,
+	}
+}
+
+func (ac *AuthClient) SignIn(ctx context.Context, email, password string) (*Tokens, error) {
+	body, err := json.Marshal(map[string]string{"email": email, "password": password})
+	if err != nil {
+		return nil, err
+	}
+
+	return ac.tokens(ctx, "/auth/signin", body, nil)
+}
+
+func (ac *AuthClient) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	return ac.tokens(ctx, "/auth/refresh", nil, &http.Cookie{Name: refreshTokenCookie, Value: refreshToken})
+}
+
+func (ac *AuthClient) tokens(ctx context.Context, path string, body []byte, cookie *http.Cookie) (*Tokens, error) {
+	response, err := ac.post(ctx, path, body, cookie)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := &Tokens{}
+	for _, responseCookie := range response.Cookies() {
+		switch responseCookie.Name {
+		case accessTokenCookie:
+			tokens.AccessToken = responseCookie.Value
+		case refreshTokenCookie:
+			tokens.RefreshToken = responseCookie.Value
+		}
+	}
+
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		return nil, errors.New("auth api: response did not set both token cookies")
+	}
+
+	tokens.Expiry, err = tokenExpiry(tokens.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// post retries only when the server shed the request with 503, because the
+// request was never processed and a retried refresh cannot trigger reuse
+// detection.
+func (ac *AuthClient) post(ctx context.Context, path string, body []byte, cookie *http.Cookie) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := ac.do(ctx, path, body, cookie)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode == http.StatusOK {
+			return response, nil
+		}
+
+		apiErr := decodeAPIError(response)
+		if response.StatusCode != http.StatusServiceUnavailable || attempt >= ac.maxRetries {
+			return nil, apiErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryDelay(response, attempt)):
+		}
+	}
+}
+
+func (ac *AuthClient) do(ctx context.Context, path string, body []byte, cookie *http.Cookie) (*http.Response, error) {
+	if ac.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ac.timeout)
+		defer cancel()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, ac.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if cookie != nil {
+		request.AddCookie(cookie)
+	}
+
+	response, err := ac.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// The body is read before the timeout context is cancelled.
+	payload, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(payload))
+
+	return response, nil
+}
+
+func decodeAPIError(response *http.Response) error {
+	apiErr := &APIError{Status: response.StatusCode}
+	if err := json.NewDecoder(response.Body).Decode(apiErr); err != nil || apiErr.Code == "" {
+		return fmt.Errorf("auth api: unexpected status %d", response.StatusCode)
+	}
+
+	return apiErr
+}
+
+func retryDelay(response *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxRetryDelay)
+	}
+
+	return min(time.Duration(1<<attempt)*100*time.Millisecond, maxRetryDelay)
+}
+
+// tokenExpiry reads exp without verifying the signature; the client only uses
+// it to schedule refreshes and never trusts the claims.
+func tokenExpiry(accessToken string) (time.Time, error) {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("auth api: malformed access token: %w", err)
+	}
+	if claims.ExpiresAt == nil {
+		return time.Time{}, errors.New("auth api: access token has no expiry")
+	}
+
+	return claims.ExpiresAt.Time, nil
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"fmt"
+)
+
+// APIError is the error envelope returned by the auth API. Sentinels below
+// match any APIError with the same code through errors.Is.
+type APIError struct {
+	Status    int    `json:"-"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestId string `json:"request_id"`
+}
+
+var (
+	ErrInvalidCredentials = &APIError{Code: "INVALID_CREDENTIALS"}
+	ErrInvalidToken       = &APIError{Code: "INVALID_TOKEN"}
+	ErrExpiredToken       = &APIError{Code: "EXPIRED_TOKEN"}
+	ErrWrongTokenType     = &APIError{Code: "WRONG_TOKEN_TYPE"}
+	ErrUserNotFound       = &APIError{Code: "USER_NOT_FOUND"}
+	ErrInvalidRequest     = &APIError{Code: "INVALID_REQUEST"}
+	ErrServerOverloaded   = &APIError{Code: "SERVER_OVERLOADED"}
+)
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("auth api: %s (%d): %s", e.Code, e.Status, e.Message)
+}
+
+func (e *APIError) Is(target error) bool {
+	apiError, ok := target.(*APIError)
+	return ok && apiError.Code == e.Code
+}
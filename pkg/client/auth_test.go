@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func signedToken(t *testing.T, expiry time.Time) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiry)}).SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func setTokenCookies(w http.ResponseWriter, accessToken, refreshToken string) {
+	http.SetCookie(w, &http.Cookie{Name: accessTokenCookie, Value: accessToken})
+	http.SetCookie(w, &http.Cookie{Name: refreshTokenCookie, Value: refreshToken})
+}
+
+func writeAPIError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"code": code, "message": code, "request_id": "req-1"})
+}
+
+func newTestAuthClient(t *testing.T, handler http.HandlerFunc) *AuthClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewAuthClient(AuthClientOptions{BaseURL: server.URL + "/", Timeout: time.Second, MaxRetries: 2})
+}
+
+func TestSignIn(t *testing.T) {
+	expiry := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	accessToken := signedToken(t, expiry)
+
+	authClient := newTestAuthClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var credentials map[string]string
+		json.NewDecoder(r.Body).Decode(&credentials)
+
+		if r.URL.Path != "/auth/signin" || credentials["password"] != "secret" {
+			writeAPIError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS")
+			return
+		}
+		setTokenCookies(w, accessToken, "refresh-1")
+	})
+
+	tokens, err := authClient.SignIn(context.Background(), "user@example.com", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.AccessToken != accessToken || tokens.RefreshToken != "refresh-1" || !tokens.Expiry.Equal(expiry) {
+		t.Fatalf("tokens = %+v, want the cookies and an expiry of %s", tokens, expiry)
+	}
+
+	_, err = authClient.SignIn(context.Background(), "user@example.com", "wrong")
+	var apiErr *APIError
+	if !errors.Is(err, ErrInvalidCredentials) || !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized || apiErr.RequestId != "req-1" {
+		t.Fatalf("error = %#v, want INVALID_CREDENTIALS with status and request id", err)
+	}
+	if errors.Is(err, ErrExpiredToken) {
+		t.Fatal("error matches a different code")
+	}
+}
+
+func TestRefreshSendsCookie(t *testing.T) {
+	accessToken := signedToken(t, time.Now().Add(time.Minute))
+
+	authClient := newTestAuthClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(refreshTokenCookie)
+		if err != nil || cookie.Value != "refresh-1" {
+			writeAPIError(w, http.StatusUnauthorized, "INVALID_TOKEN")
+			return
+		}
+		setTokenCookies(w, accessToken, "refresh-2")
+	})
+
+	tokens, err := authClient.Refresh(context.Background(), "refresh-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.RefreshToken != "refresh-2" {
+		t.Fatalf("refresh token = %q, want refresh-2", tokens.RefreshToken)
+	}
+
+	if _, err := authClient.Refresh(context.Background(), "stale"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("error = %v, want INVALID_TOKEN", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	accessToken := signedToken(t, time.Now().Add(time.Minute))
+
+	tests := []struct {
+		name     string
+		failures int32
+		status   int
+		attempts int32
+		wantErr  error
+	}{
+		{"shed then served", 2, http.StatusServiceUnavailable, 3, nil},
+		{"shed beyond max retries", 5, http.StatusServiceUnavailable, 3, ErrServerOverloaded},
+		{"other errors are not retried", 5, http.StatusTooManyRequests, 1, &APIError{Code: "RATE_LIMITED"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			authClient := newTestAuthClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) <= tt.failures {
+					w.Header().Set("Retry-After", "0")
+					code := "SERVER_OVERLOADED"
+					if tt.status != http.StatusServiceUnavailable {
+						code = "RATE_LIMITED"
+					}
+					writeAPIError(w, tt.status, code)
+					return
+				}
+				setTokenCookies(w, accessToken, "refresh-1")
+			})
+
+			_, err := authClient.SignIn(context.Background(), "user@example.com", "secret")
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got := attempts.Load(); got != tt.attempts {
+				t.Fatalf("attempts = %d, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestUnexpectedResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"error without envelope", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+		}},
+		{"missing cookies", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}},
+		{"malformed access token", func(w http.ResponseWriter, r *http.Request) {
+			setTokenCookies(w, "garbage", "refresh-1")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newTestAuthClient(t, tt.handler).SignIn(context.Background(), "user@example.com", "secret"); err == nil {
+				t.Fatal("sign-in succeeded")
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	withHeader := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	tests := []struct {
+		response *http.Response
+		attempt  int
+		want     time.Duration
+	}{
+		{withHeader("2"), 0, 2 * time.Second},
+		{withHeader("3600"), 0, maxRetryDelay},
+		{withHeader(""), 0, 100 * time.Millisecond},
+		{withHeader("soon"), 2, 400 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if got := retryDelay(tt.response, tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(%q, %d) = %s, want %s", tt.response.Header.Get("Retry-After"), tt.attempt, got, tt.want)
+		}
+	}
+}
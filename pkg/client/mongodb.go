@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -12,9 +13,22 @@ import (
 
 type MongodbClient struct {
 	url    string
+	pool   MongodbPoolOptions
 	logger *logging.Logger
 }
 
+// MongodbPoolOptions bounds the driver connection pool; zero values keep the
+// driver defaults. There is no separate checkout timeout: waiting for a
+// connection counts against the context of the operation, so a saturated pool
+// surfaces as the repository call running into its request deadline.
+type MongodbPoolOptions struct {
+	MaxSize       uint64
+	MinSize       uint64
+	MaxConnecting uint64
+	MaxIdleTime   time.Duration
+	Monitor       *event.PoolMonitor
+}
+
 func NewMongodbClient(url string, logger *logging.Logger) *MongodbClient {
 	return &MongodbClient{
 		url:    url,
@@ -22,10 +36,32 @@ func NewMongodbClient(url string, logger *logging.Logger) *MongodbClient {
 	}
 }
 
+func (mc *MongodbClient) WithPool(pool MongodbPoolOptions) *MongodbClient {
+	mc.pool = pool
+	return mc
+}
+
 func (mc *MongodbClient) Connect() *mongo.Client {
 	mc.logger.Info("Connecting to MongoDB...")
 
-	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mc.url))
+	clientOptions := options.Client().ApplyURI(mc.url)
+	if mc.pool.MaxSize > 0 {
+		clientOptions.SetMaxPoolSize(mc.pool.MaxSize)
+	}
+	if mc.pool.MinSize > 0 {
+		clientOptions.SetMinPoolSize(mc.pool.MinSize)
+	}
+	if mc.pool.MaxConnecting > 0 {
+		clientOptions.SetMaxConnecting(mc.pool.MaxConnecting)
+	}
+	if mc.pool.MaxIdleTime > 0 {
+		clientOptions.SetMaxConnIdleTime(mc.pool.MaxIdleTime)
+	}
+	if mc.pool.Monitor != nil {
+		clientOptions.SetPoolMonitor(mc.pool.Monitor)
+	}
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
 	if err != nil {
 		mc.logger.Fatal("Error while connecting to MongoDB: ", err)
 	}
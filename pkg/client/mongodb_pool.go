@@ -0,0 +1,90 @@
+package client
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"jwtgo/pkg/logging"
+)
+
+type MongodbPoolStats struct {
+	Open         int64
+	InUse        int64
+	Idle         int64
+	Waiting      int64
+	WaitCount    uint64
+	WaitDuration time.Duration
+}
+
+// MongodbPoolMonitor keeps running totals of driver pool events, since the
+// driver does not expose pool statistics itself.
+type MongodbPoolMonitor struct {
+	slowWait time.Duration
+	logger   *logging.Logger
+
+	open      atomic.Int64
+	inUse     atomic.Int64
+	waiting   atomic.Int64
+	waitCount atomic.Uint64
+	waitNanos atomic.Int64
+}
+
+func NewMongodbPoolMonitor(slowWait time.Duration, logger *logging.Logger) *MongodbPoolMonitor {
+	return &MongodbPoolMonitor{
+		slowWait: slowWait,
+		logger:   logger,
+	}
+}
+
+func (m *MongodbPoolMonitor) PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: m.handle}
+}
+
+func (m *MongodbPoolMonitor) Stats() MongodbPoolStats {
+	open, inUse := m.open.Load(), m.inUse.Load()
+
+	return MongodbPoolStats{
+		Open:         open,
+		InUse:        inUse,
+		Idle:         max(open-inUse, 0),
+		Waiting:      m.waiting.Load(),
+		WaitCount:    m.waitCount.Load(),
+		WaitDuration: time.Duration(m.waitNanos.Load()),
+	}
+}
+
+func (m *MongodbPoolMonitor) handle(e *event.PoolEvent) {
+	switch e.Type {
+	case event.ConnectionCreated:
+		m.open.Add(1)
+	case event.ConnectionClosed:
+		m.open.Add(-1)
+	case event.GetStarted:
+		m.waiting.Add(1)
+	case event.GetSucceeded:
+		m.inUse.Add(1)
+		m.finishWait(e)
+	case event.GetFailed:
+		m.finishWait(e)
+	case event.ConnectionReturned:
+		m.inUse.Add(-1)
+	}
+}
+
+func (m *MongodbPoolMonitor) finishWait(e *event.PoolEvent) {
+	m.waiting.Add(-1)
+	m.waitCount.Add(1)
+	m.waitNanos.Add(int64(e.Duration))
+
+	if m.slowWait > 0 && e.Duration > m.slowWait {
+		m.logger.WithFields(map[string]interface{}{
+			"address": e.Address,
+			"wait":    e.Duration.String(),
+			"outcome": e.Type,
+			"in_use":  m.inUse.Load(),
+			"waiting": m.waiting.Load(),
+		}).Warn("Slow MongoDB connection checkout")
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"go.mongodb.org/mongo-driver/event"
+
+	"jwtgo/pkg/logging"
+)
+
+func newTestPoolMonitor(slowWait time.Duration) (*MongodbPoolMonitor, *test.Hook) {
+	l, hook := test.NewNullLogger()
+	return NewMongodbPoolMonitor(slowWait, &logging.Logger{Entry: logrus.NewEntry(l)}), hook
+}
+
+func TestMongodbPoolMonitorUnderLoad(t *testing.T) {
+	monitor, _ := newTestPoolMonitor(0)
+	handle := monitor.PoolMonitor().Event
+
+	const connections, workers, checkouts = 10, 50, 20
+
+	for range connections {
+		handle(&event.PoolEvent{Type: event.ConnectionCreated})
+	}
+
+	// Every worker keeps its last connection checked out and has one checkout
+	// still waiting, so the totals below are what a scrape taken under load
+	// would see.
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range checkouts {
+				handle(&event.PoolEvent{Type: event.GetStarted})
+				handle(&event.PoolEvent{Type: event.GetSucceeded, Duration: time.Millisecond})
+				if i < checkouts-1 {
+					handle(&event.PoolEvent{Type: event.ConnectionReturned})
+				}
+			}
+			handle(&event.PoolEvent{Type: event.GetStarted})
+			handle(&event.PoolEvent{Type: event.GetStarted})
+			handle(&event.PoolEvent{Type: event.GetFailed, Duration: 2 * time.Millisecond})
+		}()
+	}
+	wg.Wait()
+
+	want := MongodbPoolStats{
+		Open:         connections,
+		InUse:        workers,
+		Idle:         0,
+		Waiting:      workers,
+		WaitCount:    workers * (checkouts + 1),
+		WaitDuration: workers * (checkouts*time.Millisecond + 2*time.Millisecond),
+	}
+	if got := monitor.Stats(); got != want {
+		t.Fatalf("stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestMongodbPoolMonitorIdleConnections(t *testing.T) {
+	monitor, _ := newTestPoolMonitor(0)
+	handle := monitor.PoolMonitor().Event
+
+	for range 5 {
+		handle(&event.PoolEvent{Type: event.ConnectionCreated})
+	}
+	handle(&event.PoolEvent{Type: event.GetStarted})
+	handle(&event.PoolEvent{Type: event.GetSucceeded})
+	handle(&event.PoolEvent{Type: event.ConnectionClosed})
+
+	stats := monitor.Stats()
+	if stats.Open != 4 || stats.InUse != 1 || stats.Idle != 3 {
+		t.Fatalf("stats = %+v, want 4 open, 1 in use and 3 idle", stats)
+	}
+}
+
+func TestMongodbPoolMonitorWarnsOnSlowCheckout(t *testing.T) {
+	monitor, hook := newTestPoolMonitor(50 * time.Millisecond)
+	handle := monitor.PoolMonitor().Event
+
+	handle(&event.PoolEvent{Type: event.GetStarted})
+	handle(&event.PoolEvent{Type: event.GetSucceeded, Duration: 10 * time.Millisecond})
+	if len(hook.AllEntries()) != 0 {
+		t.Fatalf("fast checkout logged %q", hook.LastEntry().Message)
+	}
+
+	handle(&event.PoolEvent{Type: event.GetStarted})
+	handle(&event.PoolEvent{Type: event.GetFailed, Duration: time.Second, Address: "mongo:27017"})
+
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != logrus.WarnLevel {
+		t.Fatalf("slow checkout logged %v, want a warning", entry)
+	}
+	if entry.Data["address"] != "mongo:27017" || entry.Data["wait"] != "1s" {
+		t.Fatalf("warning fields = %v", entry.Data)
+	}
+}
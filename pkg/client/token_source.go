@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+)
+
+const defaultRefreshMargin = 30 * time.Second
+
+// TokenSource implements oauth2.TokenSource on top of the auth API. It
+// refreshes refreshMargin before the access token expires, and concurrent
+// callers share a single refresh because the server rotates the refresh token
+// and rejects the superseded one as reuse.
+type TokenSource struct {
+	ctx           context.Context
+	client        *AuthClient
+	refreshMargin time.Duration
+
+	mu     sync.Mutex
+	tokens Tokens
+	group  singleflight.Group
+}
+
+func (ac *AuthClient) TokenSource(ctx context.Context, tokens *Tokens, refreshMargin time.Duration) *TokenSource {
+	if refreshMargin <= 0 {
+		refreshMargin = defaultRefreshMargin
+	}
+
+	return &TokenSource{
+		ctx:           ctx,
+		client:        ac,
+		refreshMargin: refreshMargin,
+		tokens:        *tokens,
+	}
+}
+
+func (ts *TokenSource) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	tokens := ts.tokens
+	ts.mu.Unlock()
+
+	if time.Until(tokens.Expiry) > ts.refreshMargin {
+		return oauth2Token(tokens), nil
+	}
+
+	refreshed, err, _ := ts.group.Do(tokens.RefreshToken, func() (interface{}, error) {
+		ts.mu.Lock()
+		current := ts.tokens
+		ts.mu.Unlock()
+
+		// Another caller already rotated this refresh token.
+		if current.RefreshToken != tokens.RefreshToken {
+			return current, nil
+		}
+
+		newTokens, err := ts.client.Refresh(ts.ctx, tokens.RefreshToken)
+		if err != nil {
+			return nil, err
+		}
+
+		ts.mu.Lock()
+		ts.tokens = *newTokens
+		ts.mu.Unlock()
+
+		return *newTokens, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth2Token(refreshed.(Tokens)), nil
+}
+
+func oauth2Token(tokens Tokens) *oauth2.Token {
+	return &oauth2.Token{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		Expiry:       tokens.Expiry,
+	}
+}
+
+var _ oauth2.TokenSource = (*TokenSource)(nil)
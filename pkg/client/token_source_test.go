@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenSourceReusesValidToken(t *testing.T) {
+	var refreshes atomic.Int32
+	authClient := newTestAuthClient(t, func(w http.ResponseWriter, r *http.Request) {
+		refreshes.Add(1)
+	})
+
+	tokens := &Tokens{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(time.Hour)}
+	token, err := authClient.TokenSource(context.Background(), tokens, time.Minute).Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "access-1" || refreshes.Load() != 0 {
+		t.Fatalf("token = %+v after %d refreshes, want the cached token", token, refreshes.Load())
+	}
+}
+
+func TestTokenSourceRefreshesOnceUnderConcurrency(t *testing.T) {
+	var refreshes atomic.Int32
+	authClient := newTestAuthClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(refreshTokenCookie)
+		if err != nil || cookie.Value != "refresh-1" {
+			writeAPIError(w, http.StatusUnauthorized, "INVALID_TOKEN")
+			return
+		}
+
+		n := refreshes.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		setTokenCookies(w, signedToken(t, time.Now().Add(time.Hour)), fmt.Sprintf("refresh-%d", n+1))
+	})
+
+	tokens := &Tokens{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(10 * time.Second)}
+	tokenSource := authClient.TokenSource(context.Background(), tokens, time.Minute)
+
+	var wg sync.WaitGroup
+	refreshTokens := make([]string, 20)
+	for i := range refreshTokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := tokenSource.Token()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			refreshTokens[i] = token.RefreshToken
+		}()
+	}
+	wg.Wait()
+
+	if got := refreshes.Load(); got != 1 {
+		t.Fatalf("refreshed %d times, want 1", got)
+	}
+	for _, refreshToken := range refreshTokens {
+		if refreshToken != "refresh-2" {
+			t.Fatalf("a caller got refresh token %q, want refresh-2", refreshToken)
+		}
+	}
+}
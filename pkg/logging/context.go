@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+func WithContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+func FromContext(ctx context.Context) *Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*Logger); ok {
+		return logger
+	}
+
+	return &instance
+}
+
+func (s *Logger) ForContext(ctx context.Context) *Logger {
+	contextLogger, ok := ctx.Value(contextKey{}).(*Logger)
+	if !ok {
+		return s
+	}
+
+	if _, ok := s.Data[componentField]; !ok {
+		return s.WithFields(contextLogger.Data)
+	}
+
+	fields := make(map[string]interface{}, len(contextLogger.Data))
+	for key, value := range contextLogger.Data {
+		if key != componentField {
+			fields[key] = value
+		}
+	}
+
+	return s.WithFields(fields)
+}
@@ -0,0 +1,248 @@
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+type FileSinkOptions struct {
+	Path       string
+	MaxSize    int64
+	MaxBackups int
+	MaxAge     time.Duration
+	Compress   bool
+}
+
+type FileSink struct {
+	mu      sync.Mutex
+	options FileSinkOptions
+	file    *os.File
+	size    int64
+	now     func() time.Time
+}
+
+func NewFileSink(options FileSinkOptions) (*FileSink, error) {
+	if options.Path == "" {
+		return nil, fmt.Errorf("log file path is empty")
+	}
+
+	sink := &FileSink{options: options, now: time.Now}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+func (f *FileSink) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.options.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.options.MaxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+func (f *FileSink) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.close(); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+func (f *FileSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.close()
+}
+
+func (f *FileSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.options.Path), 0o755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.options.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+func (f *FileSink) close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	f.size = 0
+
+	return err
+}
+
+func (f *FileSink) rotate() error {
+	if err := f.close(); err != nil {
+		return err
+	}
+
+	backup := f.backupName(f.now())
+	if err := os.Rename(f.options.Path, backup); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if f.options.Compress {
+		if err := compressFile(backup); err != nil {
+			fmt.Fprintln(os.Stderr, "logging: compress rotated log file:", err)
+		}
+	}
+
+	if err := f.removeExpiredBackups(); err != nil {
+		fmt.Fprintln(os.Stderr, "logging: remove old log files:", err)
+	}
+
+	return nil
+}
+
+func (f *FileSink) backupName(t time.Time) string {
+	dir := filepath.Dir(f.options.Path)
+	ext := filepath.Ext(f.options.Path)
+	name := strings.TrimSuffix(filepath.Base(f.options.Path), ext)
+
+	stamp := t.Format(backupTimeFormat)
+
+	backup := filepath.Join(dir, fmt.Sprintf("%s-%s%s", name, stamp, ext))
+	for i := 1; fileExists(backup) || fileExists(backup+".gz"); i++ {
+		backup = filepath.Join(dir, fmt.Sprintf("%s-%s.%d%s", name, stamp, i, ext))
+	}
+
+	return backup
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func (f *FileSink) backups() ([]string, error) {
+	dir := filepath.Dir(f.options.Path)
+	ext := filepath.Ext(f.options.Path)
+	prefix := strings.TrimSuffix(filepath.Base(f.options.Path), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if !strings.HasSuffix(name, ext) && !strings.HasSuffix(name, ext+".gz") {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+
+	return backups, nil
+}
+
+func (f *FileSink) removeExpiredBackups() error {
+	if f.options.MaxBackups <= 0 && f.options.MaxAge <= 0 {
+		return nil
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	cutoff := f.now().Add(-f.options.MaxAge)
+	for i, backup := range backups {
+		expired := f.options.MaxBackups > 0 && i >= f.options.MaxBackups
+		if !expired && f.options.MaxAge > 0 {
+			info, err := os.Stat(backup)
+			expired = err == nil && info.ModTime().Before(cutoff)
+		}
+
+		if expired {
+			if err := os.Remove(backup); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func compressFile(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	writer := gzip.NewWriter(target)
+	if _, err := io.Copy(writer, source); err != nil {
+		target.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		target.Close()
+		return err
+	}
+	if err := target.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
@@ -0,0 +1,246 @@
+package logging
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestFileSink opens a sink in a temporary directory whose clock moves a
+// second forward on every reading, so each rotation gets its own backup name.
+func newTestFileSink(t *testing.T, options FileSinkOptions) *FileSink {
+	t.Helper()
+
+	options.Path = filepath.Join(t.TempDir(), "app.log")
+	sink, err := NewFileSink(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sink.Close() })
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sink.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	return sink
+}
+
+func writeLines(t *testing.T, sink *FileSink, lines ...string) {
+	t.Helper()
+
+	for _, line := range lines {
+		if _, err := sink.Write([]byte(line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readLines returns the lines of a log file, decompressing gzip backups.
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return lines
+}
+
+func backupFiles(t *testing.T, sink *FileSink) []string {
+	t.Helper()
+
+	backups, err := sink.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return backups
+}
+
+func TestFileSinkRotatesAtMaxSize(t *testing.T) {
+	// Every line is 10 bytes with its newline, so three fit in 30.
+	sink := newTestFileSink(t, FileSinkOptions{MaxSize: 30})
+
+	writeLines(t, sink, "line-0001", "line-0002", "line-0003")
+	if backups := backupFiles(t, sink); len(backups) != 0 {
+		t.Fatalf("rotated at exactly the limit: %v", backups)
+	}
+
+	writeLines(t, sink, "line-0004")
+
+	backups := backupFiles(t, sink)
+	if len(backups) != 1 {
+		t.Fatalf("got %d backups, want 1", len(backups))
+	}
+	if got := readLines(t, backups[0]); strings.Join(got, ",") != "line-0001,line-0002,line-0003" {
+		t.Fatalf("backup holds %v", got)
+	}
+	if got := readLines(t, sink.options.Path); strings.Join(got, ",") != "line-0004" {
+		t.Fatalf("current file holds %v", got)
+	}
+}
+
+func TestFileSinkKeepsOversizedWrite(t *testing.T) {
+	sink := newTestFileSink(t, FileSinkOptions{MaxSize: 5})
+
+	writeLines(t, sink, "longer than the limit")
+
+	if backups := backupFiles(t, sink); len(backups) != 0 {
+		t.Fatalf("an empty file was rotated: %v", backups)
+	}
+	if got := readLines(t, sink.options.Path); len(got) != 1 {
+		t.Fatalf("current file holds %v", got)
+	}
+}
+
+func TestFileSinkPrunesBackups(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compress %t", compress), func(t *testing.T) {
+			sink := newTestFileSink(t, FileSinkOptions{MaxSize: 10, MaxBackups: 2, Compress: compress})
+
+			// Every write after the first rotates the one before it out.
+			for i := 1; i <= 6; i++ {
+				writeLines(t, sink, fmt.Sprintf("line-%04d", i))
+			}
+
+			backups := backupFiles(t, sink)
+			if len(backups) != 2 {
+				t.Fatalf("got %d backups, want 2: %v", len(backups), backups)
+			}
+			for _, backup := range backups {
+				if strings.HasSuffix(backup, ".gz") != compress {
+					t.Errorf("backup %s, compression %t", filepath.Base(backup), compress)
+				}
+			}
+
+			// The newest backups are kept.
+			if got := readLines(t, backups[0]); len(got) != 1 || got[0] != "line-0005" {
+				t.Errorf("newest backup holds %v", got)
+			}
+			if got := readLines(t, backups[1]); len(got) != 1 || got[0] != "line-0004" {
+				t.Errorf("older backup holds %v", got)
+			}
+		})
+	}
+}
+
+func TestFileSinkPrunesByAge(t *testing.T) {
+	sink := newTestFileSink(t, FileSinkOptions{MaxSize: 10, MaxAge: time.Hour})
+
+	writeLines(t, sink, "line-0001", "line-0002")
+	old := backupFiles(t, sink)
+	if len(old) != 1 {
+		t.Fatalf("got %d backups, want 1", len(old))
+	}
+
+	past := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(old[0], past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	writeLines(t, sink, "line-0003")
+
+	backups := backupFiles(t, sink)
+	if len(backups) != 1 || backups[0] == old[0] {
+		t.Fatalf("backups = %v, want only the new one", backups)
+	}
+}
+
+func TestFileSinkConcurrentWritersLoseNothing(t *testing.T) {
+	const writers, perWriter = 8, 500
+
+	sink := newTestFileSink(t, FileSinkOptions{MaxSize: 4096})
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWriter {
+				if _, err := fmt.Fprintf(sink, "writer=%d line=%04d\n", w, i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	backups := backupFiles(t, sink)
+	if len(backups) == 0 {
+		t.Fatal("nothing was rotated")
+	}
+
+	seen := make(map[string]int, writers*perWriter)
+	for _, path := range append(backups, sink.options.Path) {
+		for _, line := range readLines(t, path) {
+			var w, i int
+			if _, err := fmt.Sscanf(line, "writer=%d line=%d", &w, &i); err != nil {
+				t.Fatalf("torn line %q in %s", line, filepath.Base(path))
+			}
+			seen[line]++
+		}
+	}
+
+	if len(seen) != writers*perWriter {
+		t.Fatalf("found %d distinct lines, want %d", len(seen), writers*perWriter)
+	}
+	for line, count := range seen {
+		if count != 1 {
+			t.Fatalf("%q written %d times", line, count)
+		}
+	}
+}
+
+func TestFileSinkReopen(t *testing.T) {
+	sink := newTestFileSink(t, FileSinkOptions{})
+
+	writeLines(t, sink, "before")
+
+	// An external tool such as logrotate moves the file away, then signals.
+	moved := sink.options.Path + ".1"
+	if err := os.Rename(sink.options.Path, moved); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+
+	writeLines(t, sink, "after")
+
+	if got := readLines(t, moved); len(got) != 1 || got[0] != "before" {
+		t.Fatalf("moved file holds %v", got)
+	}
+	if got := readLines(t, sink.options.Path); len(got) != 1 || got[0] != "after" {
+		t.Fatalf("reopened file holds %v", got)
+	}
+}
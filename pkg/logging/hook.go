@@ -0,0 +1,180 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const defaultHookQueueSize = 1024
+
+type Entry struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+type Hook interface {
+	Fire(entry Entry) error
+}
+
+type HookOptions struct {
+	MinLevel  string
+	Fields    map[string]string
+	QueueSize int
+}
+
+type AsyncHook struct {
+	hook     Hook
+	minLevel logrus.Level
+	fields   map[string]string
+	queue    chan Entry
+	dropped  atomic.Uint64
+	done     chan struct{}
+	stopped  chan struct{}
+	once     sync.Once
+}
+
+func (s *Logger) AddHook(hook Hook, options HookOptions) (*AsyncHook, error) {
+	minLevel := logrus.TraceLevel
+	if options.MinLevel != "" {
+		parsedLevel, err := logrus.ParseLevel(options.MinLevel)
+		if err != nil {
+			return nil, err
+		}
+		minLevel = parsedLevel
+	}
+
+	queueSize := options.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultHookQueueSize
+	}
+
+	asyncHook := &AsyncHook{
+		hook:     hook,
+		minLevel: minLevel,
+		fields:   options.Fields,
+		queue:    make(chan Entry, queueSize),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go asyncHook.run()
+
+	s.Logger.AddHook(asyncHook)
+
+	return asyncHook, nil
+}
+
+func (h *AsyncHook) Levels() []logrus.Level {
+	levels := make([]logrus.Level, 0, len(logrus.AllLevels))
+	for _, level := range logrus.AllLevels {
+		if level <= h.minLevel {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+func (h *AsyncHook) Fire(entry *logrus.Entry) error {
+	if isDropped(entry) || !h.matches(entry) {
+		return nil
+	}
+
+	select {
+	case <-h.done:
+		h.dropped.Add(1)
+	default:
+		select {
+		case h.queue <- newEntry(entry):
+		default:
+			h.dropped.Add(1)
+		}
+	}
+
+	return nil
+}
+
+func (h *AsyncHook) Dropped() uint64 {
+	return h.dropped.Load()
+}
+
+func (h *AsyncHook) Close(ctx context.Context) error {
+	h.once.Do(func() {
+		close(h.done)
+	})
+
+	select {
+	case <-h.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if closer, ok := h.hook.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func (h *AsyncHook) matches(entry *logrus.Entry) bool {
+	for key, value := range h.fields {
+		if fmt.Sprint(entry.Data[key]) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *AsyncHook) run() {
+	defer close(h.stopped)
+
+	for {
+		select {
+		case entry := <-h.queue:
+			h.fire(entry)
+		case <-h.done:
+			h.drain()
+			return
+		}
+	}
+}
+
+func (h *AsyncHook) drain() {
+	for {
+		select {
+		case entry := <-h.queue:
+			h.fire(entry)
+		default:
+			return
+		}
+	}
+}
+
+func (h *AsyncHook) fire(entry Entry) {
+	if err := h.hook.Fire(entry); err != nil {
+		fmt.Fprintln(os.Stderr, "logging: hook failed:", err)
+	}
+}
+
+func newEntry(entry *logrus.Entry) Entry {
+	fields := make(map[string]interface{}, len(entry.Data))
+	for key, value := range entry.Data {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		fields[key] = value
+	}
+
+	return Entry{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+		Fields:  fields,
+	}
+}
@@ -0,0 +1,220 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// recordingHook collects delivered entries. When gate is set, every Fire
+// blocks until the gate is closed.
+type recordingHook struct {
+	mu      sync.Mutex
+	entries []Entry
+	gate    chan struct{}
+	closed  bool
+}
+
+func (h *recordingHook) Fire(entry Entry) error {
+	if h.gate != nil {
+		<-h.gate
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func (h *recordingHook) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	return nil
+}
+
+func (h *recordingHook) messages() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	messages := make([]string, 0, len(h.entries))
+	for _, entry := range h.entries {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+// newHookTestLogger returns a logger of its own, so hooks added in one test
+// never see another test's entries.
+func newHookTestLogger() *Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.TraceLevel)
+
+	return &Logger{Entry: logrus.NewEntry(logger)}
+}
+
+func closeHook(t *testing.T, hook *AsyncHook) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := hook.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAsyncHookFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		options HookOptions
+		want    []string
+	}{
+		{"everything", HookOptions{}, []string{"debug", "info auth", "warn", "error auth"}},
+		{"min level", HookOptions{MinLevel: "warn"}, []string{"warn", "error auth"}},
+		{"fields", HookOptions{Fields: map[string]string{"component": "auth"}}, []string{"info auth", "error auth"}},
+		{"min level and fields", HookOptions{MinLevel: "warn", Fields: map[string]string{"component": "auth"}}, []string{"error auth"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := newHookTestLogger()
+			recorder := &recordingHook{}
+			hook, err := logger.AddHook(recorder, tt.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			auth := logger.WithField("component", "auth")
+			logger.Debug("debug")
+			auth.Info("info auth")
+			logger.Warn("warn")
+			auth.Error("error auth")
+			logger.WithField(droppedField, true).Error("sampled out")
+
+			closeHook(t, hook)
+
+			if got := recorder.messages(); !slices.Equal(got, tt.want) {
+				t.Fatalf("delivered %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsyncHookRejectsUnknownLevel(t *testing.T) {
+	if _, err := newHookTestLogger().AddHook(&recordingHook{}, HookOptions{MinLevel: "loud"}); err == nil {
+		t.Fatal("AddHook accepted an unknown level")
+	}
+}
+
+func TestAsyncHookEntry(t *testing.T) {
+	logger := newHookTestLogger()
+	recorder := &recordingHook{}
+	hook, err := logger.AddHook(recorder, HookOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.WithError(errors.New("connection refused")).WithField("attempt", 2).Warn("retrying")
+	closeHook(t, hook)
+
+	if len(recorder.entries) != 1 {
+		t.Fatalf("delivered %d entries, want 1", len(recorder.entries))
+	}
+	entry := recorder.entries[0]
+	if entry.Level != "warning" || entry.Message != "retrying" || entry.Fields["error"] != "connection refused" || entry.Fields["attempt"] != 2 {
+		t.Fatalf("entry = %+v", entry)
+	}
+}
+
+func TestAsyncHookFlushesOnClose(t *testing.T) {
+	logger := newHookTestLogger()
+	recorder := &recordingHook{gate: make(chan struct{})}
+	hook, err := logger.AddHook(recorder, HookOptions{QueueSize: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 50 {
+		logger.Info("queued")
+	}
+	close(recorder.gate)
+	closeHook(t, hook)
+
+	if delivered := len(recorder.messages()); delivered != 50 || hook.Dropped() != 0 {
+		t.Fatalf("delivered %d, dropped %d, want 50 and 0", delivered, hook.Dropped())
+	}
+	if !recorder.closed {
+		t.Fatal("Close did not close the wrapped hook")
+	}
+
+	logger.Info("after close")
+	if hook.Dropped() != 1 {
+		t.Fatalf("dropped %d after close, want 1", hook.Dropped())
+	}
+}
+
+func TestAsyncHookBackPressure(t *testing.T) {
+	const queueSize, logged = 4, 100
+
+	logger := newHookTestLogger()
+	recorder := &recordingHook{gate: make(chan struct{})}
+	hook, err := logger.AddHook(recorder, HookOptions{QueueSize: queueSize})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for range logged {
+			logger.Info("burst")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("logging blocked on a stalled hook")
+	}
+
+	// At most the queue plus the entry the worker is stuck on get through.
+	if dropped := hook.Dropped(); dropped < logged-queueSize-1 {
+		t.Fatalf("dropped %d of %d with a queue of %d", dropped, logged, queueSize)
+	}
+
+	close(recorder.gate)
+	closeHook(t, hook)
+
+	if delivered := uint64(len(recorder.messages())); delivered+hook.Dropped() != logged {
+		t.Fatalf("delivered %d + dropped %d, want %d", delivered, hook.Dropped(), logged)
+	}
+}
+
+func TestAsyncHookCloseTimesOut(t *testing.T) {
+	logger := newHookTestLogger()
+	recorder := &recordingHook{gate: make(chan struct{})}
+	hook, err := logger.AddHook(recorder, HookOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close(recorder.gate)
+
+	logger.Info("stuck")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := hook.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,177 @@
+package logging
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type levelController struct {
+	mu         sync.Mutex
+	baseLevel  logrus.Level
+	timer      *time.Timer
+	generation uint64
+	components map[string]logrus.Level
+	current    atomic.Pointer[levelSnapshot]
+}
+
+type levelSnapshot struct {
+	level      logrus.Level
+	components map[string]logrus.Level
+}
+
+var levels levelController
+
+func (s *Logger) SetLevel(level string) error {
+	parsedLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	levels.stopTimer()
+	levels.baseLevel = parsedLevel
+	levels.apply(s.Logger, parsedLevel)
+
+	return nil
+}
+
+func (s *Logger) SetComponentLevels(componentLevels map[string]string) error {
+	components := make(map[string]logrus.Level, len(componentLevels))
+	for component, level := range componentLevels {
+		parsedLevel, err := logrus.ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		components[component] = parsedLevel
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	levels.components = components
+	levels.apply(s.Logger, levels.level(s.Logger))
+
+	return nil
+}
+
+func (s *Logger) RaiseLevel(level string, duration time.Duration) error {
+	parsedLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if levels.timer == nil {
+		levels.baseLevel = levels.level(s.Logger)
+	}
+	levels.stopTimer()
+
+	levels.apply(s.Logger, parsedLevel)
+	generation := levels.generation
+	levels.timer = time.AfterFunc(duration, func() {
+		levels.mu.Lock()
+		defer levels.mu.Unlock()
+
+		// Stop cannot recall a callback that already fired and is waiting for
+		// the lock, so a raise or level change made meanwhile is detected by
+		// its generation and left in place.
+		if levels.generation != generation {
+			return
+		}
+		levels.timer = nil
+		levels.apply(s.Logger, levels.baseLevel)
+		s.Infof("Log level reverted to %s", levels.baseLevel)
+	})
+
+	return nil
+}
+
+func (s *Logger) IncreaseVerbosity() logrus.Level {
+	return s.shiftLevel(1)
+}
+
+func (s *Logger) DecreaseVerbosity() logrus.Level {
+	return s.shiftLevel(-1)
+}
+
+func (s *Logger) shiftLevel(delta int) logrus.Level {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	levels.stopTimer()
+
+	level := int(levels.level(s.Logger)) + delta
+	if level < int(logrus.PanicLevel) {
+		level = int(logrus.PanicLevel)
+	}
+	if level > int(logrus.TraceLevel) {
+		level = int(logrus.TraceLevel)
+	}
+
+	levels.baseLevel = logrus.Level(level)
+	levels.apply(s.Logger, levels.baseLevel)
+
+	return levels.baseLevel
+}
+
+func (lc *levelController) stopTimer() {
+	lc.generation++
+	if lc.timer != nil {
+		lc.timer.Stop()
+		lc.timer = nil
+	}
+}
+
+func (lc *levelController) level(logger *logrus.Logger) logrus.Level {
+	if snapshot := lc.current.Load(); snapshot != nil {
+		return snapshot.level
+	}
+	return logger.GetLevel()
+}
+
+func (lc *levelController) apply(logger *logrus.Logger, level logrus.Level) {
+	lc.current.Store(&levelSnapshot{level: level, components: lc.components})
+
+	effectiveLevel := level
+	for _, componentLevel := range lc.components {
+		if componentLevel > effectiveLevel {
+			effectiveLevel = componentLevel
+		}
+	}
+	logger.SetLevel(effectiveLevel)
+}
+
+type levelHook struct{}
+
+var levelFilter levelHook
+
+func (levelHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (levelHook) Fire(entry *logrus.Entry) error {
+	snapshot := levels.current.Load()
+	if snapshot == nil {
+		return nil
+	}
+
+	limit := snapshot.level
+	if component, ok := entry.Data[componentField].(string); ok {
+		if componentLevel, ok := snapshot.components[component]; ok {
+			limit = componentLevel
+		}
+	}
+
+	if entry.Level > limit {
+		entry.Data[droppedField] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package logging
+
+import (
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// newLevelTestLogger returns the shared logger at info level with no raise
+// pending, writing nowhere.
+func newLevelTestLogger(t *testing.T) *Logger {
+	t.Helper()
+
+	logger := GetLogger("info")
+	logger.Logger.SetOutput(io.Discard)
+	if err := logger.SetLevel("info"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { logger.SetLevel("info") })
+
+	return &logger
+}
+
+func currentLevel(logger *Logger) logrus.Level {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	return levels.level(logger.Logger)
+}
+
+func waitForLevel(t *testing.T, logger *Logger, want logrus.Level) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for currentLevel(logger) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("level = %s, want %s", currentLevel(logger), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRaiseLevelExpires(t *testing.T) {
+	logger := newLevelTestLogger(t)
+
+	if err := logger.RaiseLevel("debug", 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if level := currentLevel(logger); level != logrus.DebugLevel {
+		t.Fatalf("raised level = %s, want debug", level)
+	}
+
+	waitForLevel(t, logger, logrus.InfoLevel)
+}
+
+func TestOverlappingRaisesRevertToBaseLevel(t *testing.T) {
+	logger := newLevelTestLogger(t)
+
+	logger.RaiseLevel("debug", 20*time.Millisecond)
+	logger.RaiseLevel("trace", 80*time.Millisecond)
+
+	time.Sleep(40 * time.Millisecond)
+	if level := currentLevel(logger); level != logrus.TraceLevel {
+		t.Fatalf("level after the first raise expired = %s, want trace", level)
+	}
+
+	waitForLevel(t, logger, logrus.InfoLevel)
+}
+
+func TestSetLevelCancelsRaise(t *testing.T) {
+	logger := newLevelTestLogger(t)
+
+	logger.RaiseLevel("debug", 20*time.Millisecond)
+	logger.SetLevel("warn")
+
+	time.Sleep(40 * time.Millisecond)
+	if level := currentLevel(logger); level != logrus.WarnLevel {
+		t.Fatalf("level = %s, want warn", level)
+	}
+}
+
+func TestStaleRevertIsIgnored(t *testing.T) {
+	logger := newLevelTestLogger(t)
+
+	logger.RaiseLevel("debug", 10*time.Millisecond)
+
+	// Hold the lock until the timer has fired, so its callback is already
+	// waiting when a new raise replaces it and Stop can no longer cancel it.
+	levels.mu.Lock()
+	time.Sleep(30 * time.Millisecond)
+	levels.stopTimer()
+	levels.apply(logger.Logger, logrus.TraceLevel)
+	levels.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+	if level := currentLevel(logger); level != logrus.TraceLevel {
+		t.Fatalf("stale revert changed the level to %s, want trace", level)
+	}
+}
+
+func TestComponentLevelsApplyOnlyToNamedLoggers(t *testing.T) {
+	logger, out := newJSONTestLogger(t)
+	t.Cleanup(func() { logger.SetComponentLevels(nil) })
+
+	if err := logger.SetComponentLevels(map[string]string{"auth": "debug", "repo": "error"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The most verbose component lowers the logrus level for everyone, so the
+	// hook alone keeps the other components at the base level.
+	if level := logger.Logger.GetLevel(); level != logrus.DebugLevel {
+		t.Fatalf("logrus level = %s, want debug", level)
+	}
+
+	logger.Named("auth").Debug("auth debug")
+	logger.Named("auth").Trace("auth trace")
+	logger.Named("http").Debug("http debug")
+	logger.Named("http").Info("http info")
+	logger.Debug("unnamed debug")
+	logger.Named("repo").Warn("repo warn")
+	logger.Named("repo").Error("repo error")
+	logger.WithField("request_id", "req-1").Named("auth").Debug("auth debug with fields")
+
+	var got []string
+	for _, line := range jsonLines(t, out) {
+		got = append(got, line["message"].(string))
+	}
+	want := []string{"auth debug", "http info", "repo error", "auth debug with fields"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("logged %q, want %q", got, want)
+	}
+
+	if err := logger.SetComponentLevels(nil); err != nil {
+		t.Fatal(err)
+	}
+	if level := logger.Logger.GetLevel(); level != logrus.InfoLevel {
+		t.Fatalf("logrus level = %s after clearing overrides, want info", level)
+	}
+}
+
+func TestSetComponentLevelsRejectsUnknownLevel(t *testing.T) {
+	logger := newLevelTestLogger(t)
+
+	if err := logger.SetComponentLevels(map[string]string{"auth": "loud"}); err == nil {
+		t.Fatal("SetComponentLevels accepted an unknown level")
+	}
+}
@@ -2,21 +2,65 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+
+	componentField   = "component"
+	defaultComponent = "app"
+)
+
 type Logger struct {
 	*logrus.Entry
 }
 
 func (s *Logger) ExtraFields(fields map[string]interface{}) *Logger {
-	return &Logger{s.WithFields(fields)}
+	return s.WithFields(fields)
+}
+
+func (s *Logger) WithField(key string, value interface{}) *Logger {
+	return &Logger{s.Entry.WithField(key, value)}
+}
+
+func (s *Logger) WithFields(fields map[string]interface{}) *Logger {
+	return &Logger{s.Entry.WithFields(fields)}
+}
+
+func (s *Logger) Named(component string) *Logger {
+	return s.WithField(componentField, component)
+}
+
+func (s *Logger) SetFormat(format string) error {
+	switch format {
+	case FormatText:
+		s.Logger.SetFormatter(&samplingFormatter{formatter: newTextFormatter()})
+	case FormatJSON:
+		s.Logger.SetFormatter(&samplingFormatter{formatter: newJSONFormatter()})
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+
+	return nil
+}
+
+func (s *Logger) SetOutputs(outputs ...io.Writer) {
+	if len(outputs) == 1 {
+		s.Logger.SetOutput(outputs[0])
+		return
+	}
+
+	s.Logger.SetOutput(io.MultiWriter(outputs...))
 }
 
 var instance Logger
@@ -31,19 +75,10 @@ func GetLogger(level string) Logger {
 
 		l := logrus.New()
 		l.SetReportCaller(true)
-
-		l.Formatter = &logrus.TextFormatter{
-			CallerPrettyfier: func(f *runtime.Frame) (string, string) {
-				filename := path.Base(f.File)
-				return fmt.Sprintf("%s:%d", filename, f.Line), fmt.Sprintf(" %s", f.Function)
-			},
-			DisableQuote:    true,
-			DisableColors:   false,
-			ForceColors:     true,
-			FullTimestamp:   true,
-			PadLevelText:    true,
-			TimestampFormat: "2006-01-02 15:04:05",
-		}
+		l.Formatter = &samplingFormatter{formatter: newTextFormatter()}
+		l.AddHook(redactor)
+		l.AddHook(levelFilter)
+		l.AddHook(sampler)
 
 		l.SetOutput(os.Stdout)
 		l.SetLevel(parsedLevel)
@@ -53,3 +88,50 @@ func GetLogger(level string) Logger {
 
 	return instance
 }
+
+func callerPrettyfier(f *runtime.Frame) (string, string) {
+	filename := path.Base(f.File)
+	return fmt.Sprintf("%s:%d", filename, f.Line), fmt.Sprintf(" %s", f.Function)
+}
+
+func newTextFormatter() logrus.Formatter {
+	return &logrus.TextFormatter{
+		CallerPrettyfier: callerPrettyfier,
+		DisableQuote:     true,
+		DisableColors:    false,
+		ForceColors:      true,
+		FullTimestamp:    true,
+		PadLevelText:     true,
+		TimestampFormat:  "2006-01-02 15:04:05",
+	}
+}
+
+type jsonFormatter struct {
+	formatter *logrus.JSONFormatter
+}
+
+func newJSONFormatter() logrus.Formatter {
+	return &jsonFormatter{
+		formatter: &logrus.JSONFormatter{
+			CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+				return f.Function, fmt.Sprintf("%s:%d", path.Base(f.File), f.Line)
+			},
+			TimestampFormat: time.RFC3339Nano,
+			FieldMap: logrus.FieldMap{
+				logrus.FieldKeyTime:  "timestamp",
+				logrus.FieldKeyLevel: "level",
+				logrus.FieldKeyMsg:   "message",
+				logrus.FieldKeyFunc:  "caller_func",
+				logrus.FieldKeyFile:  "caller",
+			},
+		},
+	}
+}
+
+func (f *jsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if _, ok := entry.Data[componentField]; !ok {
+		entry.Data[componentField] = defaultComponent
+	}
+
+	return f.formatter.Format(entry)
+}
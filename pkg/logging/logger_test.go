@@ -0,0 +1,114 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newJSONTestLogger returns the shared logger writing JSON into a buffer.
+func newJSONTestLogger(t *testing.T) (*Logger, *bytes.Buffer) {
+	t.Helper()
+
+	logger := newLevelTestLogger(t)
+
+	var out bytes.Buffer
+	logger.SetOutputs(&out)
+	if err := logger.SetFormat(FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		logger.SetOutputs(io.Discard)
+		logger.SetFormat(FormatText)
+	})
+
+	return logger, &out
+}
+
+// jsonLines decodes every line of out and fails on any that is not a JSON
+// object of its own.
+func jsonLines(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	for _, raw := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		var line map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("%v: %q", err, raw)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestJSONFormatEscapes(t *testing.T) {
+	logger, out := newJSONTestLogger(t)
+
+	tests := []struct {
+		name    string
+		message string
+		field   string
+	}{
+		{"quotes", `user said "hello"`, `a "quoted" value`},
+		{"newlines", "first line\nsecond line", "multi\nline\r\nfield"},
+		{"backslashes and tabs", `C:\temp\new` + "\tdone", `\"already escaped\"`},
+		{"control characters", "bell \a and nul \x00", "escape \x1b[31m"},
+		{"json lookalike", `{"level":"error","message":"forged"}`, `"}, {"injected": true`},
+		{"unicode", "пароль изменён ✓", "日本語"},
+	}
+
+	for _, tt := range tests {
+		logger.WithField("note", tt.field).Info(tt.message)
+	}
+
+	lines := jsonLines(t, out)
+	if len(lines) != len(tests) {
+		t.Fatalf("got %d lines for %d entries: %q", len(lines), len(tests), out.String())
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if lines[i]["message"] != tt.message {
+				t.Errorf("message = %q, want %q", lines[i]["message"], tt.message)
+			}
+			if lines[i]["note"] != tt.field {
+				t.Errorf("field = %q, want %q", lines[i]["note"], tt.field)
+			}
+			if lines[i]["level"] != "info" {
+				t.Errorf("level = %v, want info, so nothing leaked into the envelope", lines[i]["level"])
+			}
+		})
+	}
+}
+
+func TestJSONFormatFields(t *testing.T) {
+	logger, out := newJSONTestLogger(t)
+
+	logger.Warn("unnamed")
+	logger.Named("auth").WithFields(map[string]interface{}{"attempt": 2, "ok": false}).Warn("named")
+
+	lines := jsonLines(t, out)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+
+	for _, line := range lines {
+		if _, err := time.Parse(time.RFC3339Nano, line["timestamp"].(string)); err != nil {
+			t.Errorf("timestamp: %v", err)
+		}
+		if caller, _ := line["caller"].(string); !strings.HasPrefix(caller, "logger_test.go:") {
+			t.Errorf("caller = %q, want this file", caller)
+		}
+	}
+
+	if lines[0]["component"] != defaultComponent {
+		t.Errorf("component = %v, want %s", lines[0]["component"], defaultComponent)
+	}
+	if lines[1]["component"] != "auth" || lines[1]["attempt"] != float64(2) || lines[1]["ok"] != false {
+		t.Errorf("named line = %v", lines[1])
+	}
+}
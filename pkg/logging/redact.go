@@ -0,0 +1,203 @@
+package logging
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redactedValue = "[REDACTED]"
+
+var DefaultRedactedFields = []string{"password", "token", "authorization", "cookie", "secret"}
+
+var (
+	jwtPattern   = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+)
+
+// redactionPolicy is replaced as a whole on every change so the hook can read
+// it without locking.
+type redactionPolicy struct {
+	fields     []string
+	patterns   []*regexp.Regexp
+	maskEmails bool
+}
+
+type redactionHook struct {
+	policy atomic.Pointer[redactionPolicy]
+}
+
+var redactor = newRedactionHook(DefaultRedactedFields)
+
+func newRedactionHook(fields []string) *redactionHook {
+	hook := &redactionHook{}
+	hook.policy.Store(&redactionPolicy{})
+	hook.setFields(fields)
+	return hook
+}
+
+func (s *Logger) SetRedactedFields(fields []string) {
+	redactor.setFields(fields)
+}
+
+// SetRedactedPatterns replaces every match of the given regular expressions in
+// messages and string fields.
+func (s *Logger) SetRedactedPatterns(patterns []string) error {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	redactor.update(func(policy *redactionPolicy) { policy.patterns = compiled })
+	return nil
+}
+
+// SetEmailMasking keeps the first character and the domain of email addresses,
+// so jane.doe@example.com is logged as j***@example.com.
+func (s *Logger) SetEmailMasking(enabled bool) {
+	redactor.update(func(policy *redactionPolicy) { policy.maskEmails = enabled })
+}
+
+func (h *redactionHook) setFields(fields []string) {
+	normalized := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field != "" {
+			normalized = append(normalized, field)
+		}
+	}
+	h.update(func(policy *redactionPolicy) { policy.fields = normalized })
+}
+
+func (h *redactionHook) update(change func(policy *redactionPolicy)) {
+	for {
+		current := h.policy.Load()
+		next := *current
+		change(&next)
+		if h.policy.CompareAndSwap(current, &next) {
+			return
+		}
+	}
+}
+
+func (h *redactionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *redactionHook) Fire(entry *logrus.Entry) error {
+	policy := h.policy.Load()
+
+	entry.Message = policy.scrubString(entry.Message)
+	for key, value := range entry.Data {
+		entry.Data[key] = policy.redactValue(key, value)
+	}
+
+	return nil
+}
+
+func (p *redactionPolicy) redactValue(key string, value interface{}) interface{} {
+	if p.isSensitiveKey(key) {
+		return redactedValue
+	}
+
+	switch typed := value.(type) {
+	case string:
+		return p.scrubString(typed)
+	case error:
+		if text := typed.Error(); p.scrubString(text) != text {
+			return p.scrubString(text)
+		}
+		return value
+	case fmt.Stringer:
+		if text := typed.String(); p.scrubString(text) != text {
+			return p.scrubString(text)
+		}
+		return value
+	case logrus.Fields:
+		return p.redactMap(typed)
+	case map[string]interface{}:
+		return p.redactMap(typed)
+	case map[string]string:
+		redacted := make(map[string]string, len(typed))
+		for nestedKey, nestedValue := range typed {
+			if p.isSensitiveKey(nestedKey) {
+				redacted[nestedKey] = redactedValue
+			} else {
+				redacted[nestedKey] = p.scrubString(nestedValue)
+			}
+		}
+		return redacted
+	case http.Header:
+		return p.redactMultiMap(typed)
+	case map[string][]string:
+		return p.redactMultiMap(typed)
+	default:
+		return value
+	}
+}
+
+func (p *redactionPolicy) redactMap(values map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		redacted[key] = p.redactValue(key, value)
+	}
+	return redacted
+}
+
+func (p *redactionPolicy) redactMultiMap(values map[string][]string) map[string][]string {
+	redacted := make(map[string][]string, len(values))
+	for key, list := range values {
+		redactedList := make([]string, len(list))
+		for i, value := range list {
+			if p.isSensitiveKey(key) {
+				redactedList[i] = redactedValue
+			} else {
+				redactedList[i] = p.scrubString(value)
+			}
+		}
+		redacted[key] = redactedList
+	}
+	return redacted
+}
+
+func (p *redactionPolicy) isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range p.fields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *redactionPolicy) scrubString(value string) string {
+	if strings.Contains(value, "eyJ") {
+		value = jwtPattern.ReplaceAllString(value, redactedValue)
+	}
+
+	for _, pattern := range p.patterns {
+		value = pattern.ReplaceAllString(value, redactedValue)
+	}
+
+	if p.maskEmails && strings.Contains(value, "@") {
+		value = emailPattern.ReplaceAllString(value, "$1***@$2")
+	}
+
+	return value
+}
+
+func Redact(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+
+	return redactor.policy.Load().redactValue("", values).(map[string]string)
+}
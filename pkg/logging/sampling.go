@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	sampleKeyField  = "sample_key"
+	suppressedField = "suppressed"
+	droppedField    = "_sampling_dropped"
+)
+
+type SamplingPolicy struct {
+	First      int           `yaml:"first"`
+	Thereafter int           `yaml:"thereafter"`
+	Interval   time.Duration `yaml:"interval"`
+}
+
+type samplingWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+type samplingHook struct {
+	mu       sync.Mutex
+	policies map[string]SamplingPolicy
+	windows  map[string]*samplingWindow
+	now      func() time.Time
+}
+
+var sampler = newSamplingHook(time.Now)
+
+func newSamplingHook(now func() time.Time) *samplingHook {
+	return &samplingHook{
+		policies: map[string]SamplingPolicy{},
+		windows:  map[string]*samplingWindow{},
+		now:      now,
+	}
+}
+
+func (s *Logger) Sampled(key string) *Logger {
+	return s.WithField(sampleKeyField, key)
+}
+
+func (s *Logger) SetSampling(policies map[string]SamplingPolicy) {
+	sampler.setPolicies(policies)
+}
+
+func (h *samplingHook) setPolicies(policies map[string]SamplingPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.policies = make(map[string]SamplingPolicy, len(policies))
+	for key, policy := range policies {
+		h.policies[key] = policy
+	}
+	h.windows = map[string]*samplingWindow{}
+}
+
+func (h *samplingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *samplingHook) Fire(entry *logrus.Entry) error {
+	key, ok := entry.Data[sampleKeyField].(string)
+	if !ok || isDropped(entry) {
+		return nil
+	}
+
+	emit, suppressed := h.sample(key)
+	if !emit {
+		entry.Data[droppedField] = true
+	} else if suppressed > 0 {
+		entry.Data[suppressedField] = suppressed
+	}
+
+	return nil
+}
+
+func (h *samplingHook) sample(key string) (bool, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	policy, ok := h.policies[key]
+	if !ok {
+		return true, 0
+	}
+
+	now := h.now()
+	window, ok := h.windows[key]
+	if !ok {
+		window = &samplingWindow{start: now}
+		h.windows[key] = window
+	} else if policy.Interval > 0 && now.Sub(window.start) >= policy.Interval {
+		window.start = now
+		window.count = 0
+	}
+
+	window.count++
+
+	emit := window.count <= policy.First
+	if !emit && policy.Thereafter > 0 {
+		emit = (window.count-policy.First)%policy.Thereafter == 0
+	}
+
+	if !emit {
+		window.suppressed++
+		return false, 0
+	}
+
+	suppressed := window.suppressed
+	window.suppressed = 0
+	return true, suppressed
+}
+
+func isDropped(entry *logrus.Entry) bool {
+	dropped, _ := entry.Data[droppedField].(bool)
+	return dropped
+}
+
+type samplingFormatter struct {
+	formatter logrus.Formatter
+}
+
+func (f *samplingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if isDropped(entry) {
+		return nil, nil
+	}
+	return f.formatter.Format(entry)
+}
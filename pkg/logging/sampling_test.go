@@ -0,0 +1,171 @@
+package logging
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeClock is a hand-advanced clock for the sampling windows.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestSampler(policies map[string]SamplingPolicy) (*samplingHook, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	hook := newSamplingHook(clock.Now)
+	hook.setPolicies(policies)
+
+	return hook, clock
+}
+
+// fire runs calls entries for key through the hook and returns, for each, -1
+// when it was dropped or else the suppressed count it carried.
+func fire(t *testing.T, hook *samplingHook, key string, calls int) []int {
+	t.Helper()
+
+	results := make([]int, 0, calls)
+	for range calls {
+		entry := &logrus.Entry{Data: logrus.Fields{sampleKeyField: key}}
+		if err := hook.Fire(entry); err != nil {
+			t.Fatal(err)
+		}
+
+		switch {
+		case isDropped(entry):
+			results = append(results, -1)
+		case entry.Data[suppressedField] != nil:
+			results = append(results, entry.Data[suppressedField].(int))
+		default:
+			results = append(results, 0)
+		}
+	}
+
+	return results
+}
+
+func TestSamplingFirstAndThereafter(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy SamplingPolicy
+		want   []int
+	}{
+		{"first only", SamplingPolicy{First: 2}, []int{0, 0, -1, -1, -1, -1, -1, -1}},
+		{"every third after the first two", SamplingPolicy{First: 2, Thereafter: 3}, []int{0, 0, -1, -1, 2, -1, -1, 2}},
+		{"every other from the start", SamplingPolicy{Thereafter: 2}, []int{-1, 1, -1, 1, -1, 1, -1, 1}},
+		{"nothing", SamplingPolicy{}, []int{-1, -1, -1, -1, -1, -1, -1, -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook, _ := newTestSampler(map[string]SamplingPolicy{"key": tt.policy})
+
+			if got := fire(t, hook, "key", len(tt.want)); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSamplingIntervalReset(t *testing.T) {
+	hook, clock := newTestSampler(map[string]SamplingPolicy{"key": {First: 2, Interval: time.Minute}})
+
+	if got := fire(t, hook, "key", 5); !slices.Equal(got, []int{0, 0, -1, -1, -1}) {
+		t.Fatalf("first window: %v", got)
+	}
+
+	// Still inside the window.
+	clock.Advance(59 * time.Second)
+	if got := fire(t, hook, "key", 1); !slices.Equal(got, []int{-1}) {
+		t.Fatalf("before the interval: %v", got)
+	}
+
+	// The new window starts over, and its first line reports everything
+	// dropped in the last one.
+	clock.Advance(time.Second)
+	if got := fire(t, hook, "key", 3); !slices.Equal(got, []int{4, 0, -1}) {
+		t.Fatalf("second window: %v", got)
+	}
+
+	// The window restarts from the call that reset it, not from a fixed grid.
+	clock.Advance(59 * time.Second)
+	if got := fire(t, hook, "key", 1); !slices.Equal(got, []int{-1}) {
+		t.Fatalf("inside the second window: %v", got)
+	}
+	clock.Advance(time.Second)
+	if got := fire(t, hook, "key", 1); !slices.Equal(got, []int{2}) {
+		t.Fatalf("third window: %v", got)
+	}
+}
+
+func TestSamplingKeysAreIndependent(t *testing.T) {
+	hook, _ := newTestSampler(map[string]SamplingPolicy{
+		"signin_failure": {First: 1},
+		"refresh":        {First: 1},
+	})
+
+	fire(t, hook, "signin_failure", 3)
+
+	if got := fire(t, hook, "refresh", 2); !slices.Equal(got, []int{0, -1}) {
+		t.Fatalf("refresh: %v", got)
+	}
+	if got := fire(t, hook, "unconfigured", 3); !slices.Equal(got, []int{0, 0, 0}) {
+		t.Fatalf("a key without a policy was sampled: %v", got)
+	}
+}
+
+func TestSamplingPoliciesResetWindows(t *testing.T) {
+	hook, _ := newTestSampler(map[string]SamplingPolicy{"key": {First: 1}})
+	fire(t, hook, "key", 3)
+
+	hook.setPolicies(map[string]SamplingPolicy{"key": {First: 1}})
+
+	if got := fire(t, hook, "key", 2); !slices.Equal(got, []int{0, -1}) {
+		t.Fatalf("after new policies: %v", got)
+	}
+}
+
+func TestSamplingLeavesOtherEntriesAlone(t *testing.T) {
+	hook, _ := newTestSampler(map[string]SamplingPolicy{"key": {}})
+
+	entry := &logrus.Entry{Data: logrus.Fields{"user_id": "user-1"}}
+	if err := hook.Fire(entry); err != nil {
+		t.Fatal(err)
+	}
+	if isDropped(entry) {
+		t.Fatal("an entry without a sample key was dropped")
+	}
+
+	// An entry another hook already dropped does not count towards a window.
+	dropped := &logrus.Entry{Data: logrus.Fields{sampleKeyField: "key", droppedField: true}}
+	if err := hook.Fire(dropped); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := hook.windows["key"]; ok {
+		t.Fatal("a dropped entry opened a sampling window")
+	}
+}
+
+func TestSamplingFormatterSkipsDroppedEntries(t *testing.T) {
+	formatter := &samplingFormatter{formatter: &logrus.JSONFormatter{}}
+
+	out, err := formatter.Format(&logrus.Entry{Message: "dropped", Data: logrus.Fields{droppedField: true}})
+	if err != nil || out != nil {
+		t.Fatalf("Format = %q, %v, want nothing", out, err)
+	}
+
+	out, err = formatter.Format(&logrus.Entry{Message: "kept", Data: logrus.Fields{}})
+	if err != nil || len(out) == 0 {
+		t.Fatalf("Format = %q, %v, want the entry", out, err)
+	}
+}
@@ -0,0 +1,199 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"runtime"
+
+	"github.com/sirupsen/logrus"
+)
+
+const levelTrace = slog.LevelDebug - 4
+
+func NewSlogLogger(handler slog.Handler) *Logger {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.SetFormatter(discardFormatter{})
+	l.SetReportCaller(true)
+	l.SetLevel(lowestEnabledLevel(handler))
+	l.AddHook(redactor)
+	l.AddHook(sampler)
+	l.AddHook(&slogHook{handler: handler})
+
+	return &Logger{logrus.NewEntry(l)}
+}
+
+func NewSlogHandler(logger *Logger) slog.Handler {
+	return &slogHandler{logger: logger}
+}
+
+type slogHandler struct {
+	logger *Logger
+	attrs  []slog.Attr
+	groups []string
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Logger.IsLevelEnabled(toLogrusLevel(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make(logrus.Fields, len(h.attrs)+record.NumAttrs()+1)
+	for _, attr := range h.attrs {
+		addAttr(fields, "", attr)
+	}
+
+	prefix := groupPrefix(h.groups)
+	record.Attrs(func(attr slog.Attr) bool {
+		addAttr(fields, prefix, attr)
+		return true
+	})
+
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		fields["source"] = frame.Function
+	}
+
+	entry := h.logger.ForContext(ctx).Entry.WithContext(ctx).WithTime(record.Time).WithFields(fields)
+	entry.Log(toLogrusLevel(record.Level), record.Message)
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefix := groupPrefix(h.groups)
+
+	prefixed := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	prefixed = append(prefixed, h.attrs...)
+	for _, attr := range attrs {
+		prefixed = append(prefixed, slog.Attr{Key: prefix + attr.Key, Value: attr.Value})
+	}
+
+	return &slogHandler{logger: h.logger, attrs: prefixed, groups: h.groups}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+	groups = append(groups, name)
+
+	return &slogHandler{logger: h.logger, attrs: h.attrs, groups: groups}
+}
+
+type slogHook struct {
+	handler slog.Handler
+}
+
+func (h *slogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *slogHook) Fire(entry *logrus.Entry) error {
+	if isDropped(entry) {
+		return nil
+	}
+
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	level := toSlogLevel(entry.Level)
+	if !h.handler.Enabled(ctx, level) {
+		return nil
+	}
+
+	var pc uintptr
+	if entry.Caller != nil {
+		pc = entry.Caller.PC
+	}
+
+	record := slog.NewRecord(entry.Time, level, entry.Message, pc)
+	for key, value := range entry.Data {
+		record.AddAttrs(slog.Any(key, value))
+	}
+
+	return h.handler.Handle(ctx, record)
+}
+
+type discardFormatter struct{}
+
+func (discardFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return nil, nil
+}
+
+func addAttr(fields logrus.Fields, prefix string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, groupAttr := range value.Group() {
+			addAttr(fields, groupPrefix, groupAttr)
+		}
+		return
+	}
+
+	if attr.Key == "" {
+		return
+	}
+
+	fields[prefix+attr.Key] = value.Any()
+}
+
+func groupPrefix(groups []string) string {
+	prefix := ""
+	for _, group := range groups {
+		prefix += group + "."
+	}
+	return prefix
+}
+
+func lowestEnabledLevel(handler slog.Handler) logrus.Level {
+	for _, level := range []logrus.Level{logrus.TraceLevel, logrus.DebugLevel, logrus.InfoLevel, logrus.WarnLevel, logrus.ErrorLevel, logrus.FatalLevel} {
+		if handler.Enabled(context.Background(), toSlogLevel(level)) {
+			return level
+		}
+	}
+	return logrus.PanicLevel
+}
+
+func toSlogLevel(level logrus.Level) slog.Level {
+	switch level {
+	case logrus.TraceLevel:
+		return levelTrace
+	case logrus.DebugLevel:
+		return slog.LevelDebug
+	case logrus.InfoLevel:
+		return slog.LevelInfo
+	case logrus.WarnLevel:
+		return slog.LevelWarn
+	case logrus.ErrorLevel:
+		return slog.LevelError
+	case logrus.FatalLevel:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError + 8
+	}
+}
+
+func toLogrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return logrus.TraceLevel
+	case level < slog.LevelInfo:
+		return logrus.DebugLevel
+	case level < slog.LevelWarn:
+		return logrus.InfoLevel
+	case level < slog.LevelError:
+		return logrus.WarnLevel
+	default:
+		return logrus.ErrorLevel
+	}
+}
@@ -0,0 +1,198 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+// newSlogTestLogger returns a logger of its own at level, recording entries.
+func newSlogTestLogger(level logrus.Level) (*Logger, *test.Hook) {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.SetLevel(level)
+
+	return &Logger{Entry: logrus.NewEntry(l)}, test.NewLocal(l)
+}
+
+func TestSlogHandlerFields(t *testing.T) {
+	logger, hook := newSlogTestLogger(logrus.TraceLevel)
+	slogger := slog.New(NewSlogHandler(logger.Named("bridge")))
+
+	slogger.With("service", "auth").
+		WithGroup("request").
+		With("id", "req-1").
+		Warn("slow request", "ms", 250, slog.Group("user", "id", "user-1", "roles", []string{"admin"}))
+
+	entry := hook.LastEntry()
+	if entry == nil {
+		t.Fatal("nothing was logged")
+	}
+	if entry.Level != logrus.WarnLevel || entry.Message != "slow request" {
+		t.Fatalf("entry = %s %q", entry.Level, entry.Message)
+	}
+
+	want := map[string]interface{}{
+		"component":       "bridge",
+		"service":         "auth",
+		"request.id":      "req-1",
+		"request.ms":      int64(250),
+		"request.user.id": "user-1",
+	}
+	for key, value := range want {
+		if entry.Data[key] != value {
+			t.Errorf("%s = %#v, want %#v", key, entry.Data[key], value)
+		}
+	}
+	if roles, _ := entry.Data["request.user.roles"].([]string); len(roles) != 1 || roles[0] != "admin" {
+		t.Errorf("request.user.roles = %#v", entry.Data["request.user.roles"])
+	}
+	if source, _ := entry.Data["source"].(string); !strings.HasSuffix(source, "TestSlogHandlerFields") {
+		t.Errorf("source = %q, want this test", source)
+	}
+}
+
+func TestSlogLevels(t *testing.T) {
+	tests := []struct {
+		slog   slog.Level
+		logrus logrus.Level
+	}{
+		{levelTrace, logrus.TraceLevel},
+		{slog.LevelDebug, logrus.DebugLevel},
+		{slog.LevelInfo, logrus.InfoLevel},
+		{slog.LevelInfo + 2, logrus.InfoLevel},
+		{slog.LevelWarn, logrus.WarnLevel},
+		{slog.LevelError, logrus.ErrorLevel},
+		{slog.LevelError + 4, logrus.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		if got := toLogrusLevel(tt.slog); got != tt.logrus {
+			t.Errorf("toLogrusLevel(%s) = %s, want %s", tt.slog, got, tt.logrus)
+		}
+	}
+
+	for _, level := range []logrus.Level{logrus.TraceLevel, logrus.DebugLevel, logrus.InfoLevel, logrus.WarnLevel, logrus.ErrorLevel} {
+		if got := toLogrusLevel(toSlogLevel(level)); got != level {
+			t.Errorf("%s came back as %s", level, got)
+		}
+	}
+
+	logger, hook := newSlogTestLogger(logrus.WarnLevel)
+	slogger := slog.New(NewSlogHandler(logger))
+	slogger.Info("filtered")
+	slogger.Error("kept")
+
+	if entries := hook.AllEntries(); len(entries) != 1 || entries[0].Message != "kept" {
+		t.Fatalf("entries = %v, want only the error", entries)
+	}
+}
+
+// TestSlogRoundTrip sends slog records into a Logger and back out through an
+// slog handler, as happens when a library using slog logs through the
+// application logger configured with an slog backend.
+func TestSlogRoundTrip(t *testing.T) {
+	var out bytes.Buffer
+	backend := slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})
+
+	logger := NewSlogLogger(backend)
+	slogger := slog.New(NewSlogHandler(logger))
+
+	slogger.Debug("below info")
+	slogger.With("tenant", "acme").WithGroup("db").Info("query", "rows", 3, slog.Group("pool", "idle", 1))
+	logger.WithField("password", "hunter2").Warn("from logrus")
+
+	var lines []map[string]interface{}
+	for _, raw := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		var line map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("%v: %q", err, raw)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %s", len(lines), out.String())
+	}
+
+	if lines[0]["level"] != "DEBUG" || lines[0]["msg"] != "below info" {
+		t.Errorf("debug line = %v", lines[0])
+	}
+
+	query := lines[1]
+	if query["level"] != "INFO" || query["msg"] != "query" || query["tenant"] != "acme" || query["db.rows"] != float64(3) || query["db.pool.idle"] != float64(1) {
+		t.Errorf("query line = %v", query)
+	}
+
+	if lines[2]["level"] != "WARN" || lines[2]["password"] != redactedValue {
+		t.Errorf("logrus line = %v, want a redacted warning", lines[2])
+	}
+}
+
+func TestSlogLoggerFollowsBackendLevel(t *testing.T) {
+	backend := slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn})
+
+	if level := NewSlogLogger(backend).Logger.GetLevel(); level != logrus.WarnLevel {
+		t.Fatalf("level = %s, want warning", level)
+	}
+}
+
+func TestSlogDisabledLevelDoesNotAllocate(t *testing.T) {
+	logger, _ := newSlogTestLogger(logrus.WarnLevel)
+	slogger := slog.New(NewSlogHandler(logger))
+	ctx := context.Background()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		slogger.DebugContext(ctx, "filtered", "attempt", 1, "user", "user-1")
+	})
+	if allocs != 0 {
+		t.Fatalf("a disabled slog call allocated %.0f times", allocs)
+	}
+
+	backendLogger := NewSlogLogger(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	allocs = testing.AllocsPerRun(100, func() {
+		backendLogger.Debug("filtered")
+	})
+	if allocs != 0 {
+		t.Fatalf("a disabled call on an slog backend allocated %.0f times", allocs)
+	}
+}
+
+func BenchmarkSlogDisabledLevel(b *testing.B) {
+	logger, _ := newSlogTestLogger(logrus.WarnLevel)
+	slogger := slog.New(NewSlogHandler(logger))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		slogger.DebugContext(ctx, "filtered", "attempt", 1, "user", "user-1")
+	}
+}
+
+func BenchmarkSlogBackendDisabledLevel(b *testing.B) {
+	logger := NewSlogLogger(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Debug("filtered")
+	}
+}
+
+func BenchmarkSlogEnabledLevel(b *testing.B) {
+	logger, _ := newSlogTestLogger(logrus.InfoLevel)
+	// Drop the recording hook so b.N entries do not pile up in memory.
+	logger.Logger.ReplaceHooks(logrus.LevelHooks{})
+	slogger := slog.New(NewSlogHandler(logger))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		slogger.InfoContext(ctx, "logged", "attempt", 1, "user", "user-1")
+	}
+}
@@ -0,0 +1,156 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWebhookBatchSize     = 50
+	defaultWebhookFlushInterval = 5 * time.Second
+	defaultWebhookRetryBackoff  = 500 * time.Millisecond
+	defaultWebhookTimeout       = 10 * time.Second
+)
+
+type WebhookOptions struct {
+	URL           string
+	BatchSize     int
+	FlushInterval time.Duration
+	MaxRetries    int
+	RetryBackoff  time.Duration
+	Client        *http.Client
+}
+
+type WebhookHook struct {
+	mu      sync.Mutex
+	options WebhookOptions
+	batch   []Entry
+	stop    chan struct{}
+	once    sync.Once
+}
+
+func NewWebhookHook(options WebhookOptions) (*WebhookHook, error) {
+	if options.URL == "" {
+		return nil, fmt.Errorf("webhook url is empty")
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultWebhookBatchSize
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = defaultWebhookFlushInterval
+	}
+	if options.RetryBackoff <= 0 {
+		options.RetryBackoff = defaultWebhookRetryBackoff
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+
+	hook := &WebhookHook{
+		options: options,
+		batch:   make([]Entry, 0, options.BatchSize),
+		stop:    make(chan struct{}),
+	}
+	go hook.flushPeriodically()
+
+	return hook, nil
+}
+
+func (h *WebhookHook) Fire(entry Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.batch = append(h.batch, entry)
+	if len(h.batch) < h.options.BatchSize {
+		return nil
+	}
+
+	return h.flush()
+}
+
+func (h *WebhookHook) Flush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.flush()
+}
+
+func (h *WebhookHook) Close() error {
+	h.once.Do(func() {
+		close(h.stop)
+	})
+
+	return h.Flush()
+}
+
+func (h *WebhookHook) flushPeriodically() {
+	ticker := time.NewTicker(h.options.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := h.Flush(); err != nil {
+				h.report(err)
+			}
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+func (h *WebhookHook) flush() error {
+	if len(h.batch) == 0 {
+		return nil
+	}
+
+	batch := h.batch
+	h.batch = make([]Entry, 0, h.options.BatchSize)
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("encode webhook batch: %w", err)
+	}
+
+	backoff := h.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+		retryable, err = h.send(body)
+		if err == nil || !retryable || attempt >= h.options.MaxRetries {
+			break
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	if err != nil {
+		return fmt.Errorf("deliver %d log entries: %w", len(batch), err)
+	}
+
+	return nil
+}
+
+func (h *WebhookHook) send(body []byte) (bool, error) {
+	response, err := h.options.Client.Post(h.options.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		retryable := response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+		return retryable, fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return false, nil
+}
+
+func (h *WebhookHook) report(err error) {
+	fmt.Fprintln(os.Stderr, "logging: webhook flush failed:", err)
+}
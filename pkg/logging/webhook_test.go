@@ -0,0 +1,171 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookServer records the size of every batch it accepts and answers with
+// the queued statuses first, then 200.
+type webhookServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	batches  []int
+	statuses []int
+	requests atomic.Int32
+}
+
+func newWebhookServer(t *testing.T, statuses ...int) *webhookServer {
+	t.Helper()
+
+	server := &webhookServer{statuses: statuses}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.requests.Add(1)
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		if len(server.statuses) > 0 {
+			status := server.statuses[0]
+			server.statuses = server.statuses[1:]
+			w.WriteHeader(status)
+			return
+		}
+
+		var batch []Entry
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		server.batches = append(server.batches, len(batch))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *webhookServer) batchSizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]int(nil), s.batches...)
+}
+
+func newTestWebhook(t *testing.T, options WebhookOptions) *WebhookHook {
+	t.Helper()
+
+	hook, err := NewWebhookHook(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { hook.Close() })
+
+	return hook
+}
+
+func TestWebhookRequiresURL(t *testing.T) {
+	if _, err := NewWebhookHook(WebhookOptions{}); err == nil {
+		t.Fatal("NewWebhookHook accepted an empty url")
+	}
+}
+
+func TestWebhookBatches(t *testing.T) {
+	server := newWebhookServer(t)
+	hook := newTestWebhook(t, WebhookOptions{URL: server.URL, BatchSize: 3, FlushInterval: time.Hour})
+
+	for range 7 {
+		if err := hook.Fire(Entry{Message: "entry"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := server.batchSizes(); len(got) != 2 || got[0] != 3 || got[1] != 3 {
+		t.Fatalf("batches before close = %v, want [3 3]", got)
+	}
+
+	if err := hook.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := server.batchSizes(); len(got) != 3 || got[2] != 1 {
+		t.Fatalf("batches after close = %v, want [3 3 1]", got)
+	}
+}
+
+func TestWebhookFlushesPeriodically(t *testing.T) {
+	server := newWebhookServer(t)
+	hook := newTestWebhook(t, WebhookOptions{URL: server.URL, BatchSize: 100, FlushInterval: 10 * time.Millisecond})
+
+	if err := hook.Fire(Entry{Message: "entry"}); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(server.batchSizes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the partial batch was never flushed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		requests   int32
+		wantErr    bool
+	}{
+		{"server errors then success", []int{http.StatusServiceUnavailable, http.StatusInternalServerError}, 3, 3, false},
+		{"rate limited then success", []int{http.StatusTooManyRequests}, 1, 2, false},
+		{"retries exhausted", []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, 2, 3, true},
+		{"client error is not retried", []int{http.StatusBadRequest}, 3, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newWebhookServer(t, tt.statuses...)
+			hook := newTestWebhook(t, WebhookOptions{
+				URL:           server.URL,
+				BatchSize:     1,
+				FlushInterval: time.Hour,
+				MaxRetries:    tt.maxRetries,
+				RetryBackoff:  time.Millisecond,
+			})
+
+			err := hook.Fire(Entry{Message: "entry"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fire error = %v, want error %t", err, tt.wantErr)
+			}
+			if requests := server.requests.Load(); requests != tt.requests {
+				t.Fatalf("requests = %d, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestWebhookFlushesOnLoggerShutdown(t *testing.T) {
+	server := newWebhookServer(t)
+	webhook := newTestWebhook(t, WebhookOptions{URL: server.URL, BatchSize: 100, FlushInterval: time.Hour})
+
+	logger := newHookTestLogger()
+	hook, err := logger.AddHook(webhook, HookOptions{MinLevel: "warn"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 5 {
+		logger.Info("filtered")
+		logger.Error("delivered")
+	}
+	closeHook(t, hook)
+
+	if got := server.batchSizes(); len(got) != 1 || got[0] != 5 {
+		t.Fatalf("batches = %v, want [5]", got)
+	}
+}